@@ -9,6 +9,7 @@ require (
 	github.com/sigstore/cosign/v2 v2.2.0
 	github.com/sigstore/sigstore v1.7.2
 	github.com/slsa-framework/slsa-verifier/v2 v2.4.1
+	sigs.k8s.io/yaml v1.3.0
 )
 
 require (
@@ -259,7 +260,6 @@ require (
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
 	sigs.k8s.io/release-utils v0.7.4 // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.2.3 // indirect
-	sigs.k8s.io/yaml v1.3.0 // indirect
 )
 
 replace github.com/slsa-framework/slsa-policy/pkg v0.0.0 => ../../pkg
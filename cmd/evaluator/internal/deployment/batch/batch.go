@@ -0,0 +1,97 @@
+package batch
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/evaluate"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils/crypto"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s deployment batch orgPath projectsPath manifestPath [--parallelism N]\n" +
+		"\n" +
+		"The manifest is a JSON or YAML list of entries with fields 'package', 'digest' and 'policy_id'.\n" +
+		"\n" +
+		"Example:\n" +
+		"%s deployment batch ./path/to/policy/org ./path/to/policy/projects ./manifest.yml --parallelism 8\n" +
+		"\n"
+	fmt.Fprintf(os.Stderr, msg, cli, cli)
+	os.Exit(1)
+}
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	parallelism := fs.Int("parallelism", 4, "maximum number of artifacts evaluated concurrently")
+	positional, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 3 || *parallelism <= 0 {
+		usage(cli)
+	}
+	entries, err := utils.ReadManifest(positional[2])
+	if err != nil {
+		return err
+	}
+	// NOTE: the policy is loaded once and shared by all evaluations.
+	pol, err := evaluate.PolicyNew(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+
+	// NOTE: the keyless signer is created once and shared by all evaluations.
+	signer := crypto.SignerNew()
+	defer signer.Close()
+
+	results := make([]utils.BatchResult, len(entries))
+	utils.RunParallel(len(entries), *parallelism, func(i int) {
+		results[i].ManifestEntry = entries[i]
+		attBytes, err := evaluateEntry(pol, signer, entries[i])
+		if err != nil {
+			results[i].Error = err.Error()
+			return
+		}
+		results[i].Attestation = attBytes
+	})
+	return utils.ReportBatchResults(results)
+}
+
+func evaluateEntry(pol *deployment.Policy, signer *crypto.Signer, entry utils.ManifestEntry) ([]byte, error) {
+	if entry.PolicyID == "" {
+		return nil, fmt.Errorf("policy_id is empty")
+	}
+	if entry.Environment != "" {
+		return nil, fmt.Errorf("environment (%q) is not supported for deployment evaluation", entry.Environment)
+	}
+	imageURI, digest, err := utils.ParseImageReference(entry.ImageReference())
+	if err != nil {
+		return nil, err
+	}
+	digests, err := intoto.DigestSetFromString(digest)
+	if err != nil {
+		return nil, err
+	}
+	att, image, err := evaluate.Evaluate(pol, imageURI, digests, entry.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+	attBytes, err := att.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attestation bytes: %w", err)
+	}
+	immutableImages, err := image.ImmutableImages()
+	if err != nil {
+		return nil, err
+	}
+	if err := signer.Sign(att, immutableImages...); err != nil {
+		return nil, err
+	}
+	return attBytes, nil
+}
@@ -3,8 +3,10 @@ package deployment
 import (
 	"os"
 
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/batch"
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/evaluate"
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/validate"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/verify"
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
 )
 
@@ -15,6 +17,8 @@ func usage(cli string) {
 		"Available options:\n" +
 		"validate \t\tValidate the policy files\n" +
 		"evaluate \t\tEvaluate the policy\n" +
+		"batch \t\t\tEvaluate the policy for a manifest of artifacts\n" +
+		"verify \t\t\tVerify a deployment attestation\n" +
 		"\n"
 	utils.Log(msg, cli)
 	os.Exit(1)
@@ -32,6 +36,10 @@ func Run(cli string, args []string) error {
 		err = validate.Run(cli, args[1:])
 	case "evaluate":
 		err = evaluate.Run(cli, args[1:])
+	case "batch":
+		err = batch.Run(cli, args[1:])
+	case "verify":
+		err = verify.Run(cli, args[1:])
 	}
 	return err
 }
@@ -3,7 +3,6 @@ package evaluate
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/validate"
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
@@ -29,8 +28,7 @@ func Run(cli string, args []string) error {
 		usage(cli)
 	}
 	// Extract inputs.
-	orgPath := args[0]
-	projectsPath, err := utils.ReadFiles(args[1], orgPath)
+	pol, err := PolicyNew(args[0], args[1])
 	if err != nil {
 		return err
 	}
@@ -39,50 +37,77 @@ func Run(cli string, args []string) error {
 		return err
 	}
 	policyID := args[3]
-	digestsArr := strings.Split(digest, ":")
-	if len(digestsArr) != 2 {
-		return fmt.Errorf("invalid digest (%q)", digest)
+	digests, err := intoto.DigestSetFromString(digest)
+	if err != nil {
+		return err
 	}
-	wd, err := os.Getwd()
+
+	// Evaluate the policy and create an attestation.
+	att, image, err := Evaluate(pol, imageURI, digests, policyID)
 	if err != nil {
 		return err
 	}
-	// Create a policy.
+	attBytes, err := att.ToBytes()
+	if err != nil {
+		return fmt.Errorf("failed to get attestation bytes: %v", err)
+	}
+	fmt.Println(string(attBytes))
+
+	// NOTE: the attestation is attached to the index and all its platform manifests,
+	// so that it is found regardless of which digest is pulled.
+	immutableImages, err := image.ImmutableImages()
+	if err != nil {
+		return err
+	}
+	return crypto.Sign(att, immutableImages...)
+}
+
+// PolicyNew creates a policy from the org policy path and the projects directory.
+func PolicyNew(orgPath, projectsDir string) (*deployment.Policy, error) {
+	projectsPath, err := utils.ReadFiles(projectsDir, orgPath)
+	if err != nil {
+		return nil, err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
 	projectsReader := named_files_reader.FromPaths(wd, projectsPath)
 	organizationReader, err := os.Open(orgPath)
 	if err != nil {
-		return fmt.Errorf("failed to read org path: %w", err)
+		return nil, fmt.Errorf("failed to read org path: %w", err)
 	}
 	pol, err := deployment.PolicyNew(organizationReader, projectsReader, deployment.SetValidator(&validate.PolicyValidator{}))
 	if err != nil {
-		return fmt.Errorf("failed to create policy: %w", err)
+		return nil, fmt.Errorf("failed to create policy: %w", err)
 	}
+	return pol, nil
+}
 
-	// Evaluate the policy.
+// Evaluate evaluates the policy for an image and returns a deployment attestation
+// along with the resolved image. If the image is an index, the publish attestation
+// of the index and of each of its platform manifests is verified.
+// It is safe to call concurrently.
+func Evaluate(pol *deployment.Policy, imageURI string, digests intoto.DigestSet, policyID string) (*deployment.Creation, *utils.Image, error) {
+	image, err := utils.ResolveImage(imageURI, digests)
+	if err != nil {
+		return nil, nil, err
+	}
 	opts := deployment.AttestationVerificationOption{
 		Verifier: newPublishVerifier(),
 	}
-	digests := intoto.DigestSet{
-		digestsArr[0]: digestsArr[1],
-	}
 	// NOTE: imageURI must be the same as set in the policy's package name.
-	result := pol.Evaluate(digests, imageURI, policyID, opts)
+	result := pol.EvaluateSubjects(image.Subjects(), imageURI, policyID, opts)
 	if result.Error() != nil {
-		return result.Error()
+		return nil, nil, result.Error()
 	}
 
-	// Create a publish attestation and sign it.
+	// Create a deployment attestation.
 	// TODO(#3): do not attach the attestation, so that caller can do it however they want.
 	// TODO(#2): add policy.
 	att, err := result.AttestationNew()
 	if err != nil {
-		return fmt.Errorf("failed to create attestation: %w", err)
+		return nil, nil, fmt.Errorf("failed to create attestation: %w", err)
 	}
-	attBytes, err := att.ToBytes()
-	if err != nil {
-		return fmt.Errorf("failed to get attestation bytes: %v", err)
-	}
-	fmt.Println(string(attBytes))
-
-	return crypto.Sign(att, utils.ImmutableImage(imageURI, digests))
+	return att, image, nil
 }
@@ -49,16 +49,15 @@ func (v *publishVerifier) verifySignature(imageName string, digests intoto.Diges
 	if strings.Contains(imageName, "@") || strings.Contains(imageName, ":") {
 		return "", nil, fmt.Errorf("invalid image name (%q)", imageName)
 	}
-	// Validate the digests.
-	digest, ok := digests["sha256"]
-	if !ok {
-		return "", nil, fmt.Errorf("invalid digest (%q)", digests)
+	// Select a digest supported by the registry.
+	imageURI, err := utils.ImmutableImage(imageName, digests)
+	if err != nil {
+		return "", nil, err
 	}
-	imageURI := fmt.Sprintf("%s@sha256:%s", imageName, digest)
-	fmt.Println("imageURI:", imageURI)
+	utils.Log("imageURI: %s\n", imageURI)
 
 	// Verify the signature.
-	fullPublishrID, attBytes, err := crypto.VerifySignature(imageURI, v.AttestationVerifierPublishOptions.PublishrID,
+	fullPublishrID, attBytes, err := crypto.VerifySignature(imageURI, publish.PredicateType(), v.AttestationVerifierPublishOptions.PublishrID,
 		v.AttestationVerifierPublishOptions.PublishrIDRegex)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to verify image (%q) with publishr ID (%q) publishr ID regex (%q): %v",
@@ -109,19 +108,32 @@ func (v *publishVerifier) verifyAttestationContent(attBytes []byte, imageName st
 	return nil, nil
 }
 
-func (v *publishVerifier) VerifyPublishAttestation(digests intoto.DigestSet, imageName string, environment []string, opts deployment.AttestationVerifierPublishOptions) (*string, error) {
+func (v *publishVerifier) VerifyPublishAttestation(digests intoto.DigestSet, imageName string, environment []string, opts deployment.AttestationVerifierPublishOptions) (deployment.PublishVerificationResult, error) {
 	if err := v.setOptions(opts); err != nil {
-		return nil, err
+		return deployment.PublishVerificationResult{}, err
 	}
 
 	// Verify the signature.
 	_, attBytes, err := v.verifySignature(imageName, digests)
 	if err != nil {
-		return nil, err
+		return deployment.PublishVerificationResult{}, err
 	}
 
-	fmt.Println(string(attBytes))
+	utils.Log("%s\n", string(attBytes))
 
 	// Verify the attestation content.
-	return v.verifyAttestationContent(attBytes, imageName, digests, environment)
+	env, err := v.verifyAttestationContent(attBytes, imageName, digests, environment)
+	if err != nil {
+		return deployment.PublishVerificationResult{}, err
+	}
+	statement, err := intoto.StatementFromBytes(attBytes)
+	if err != nil {
+		return deployment.PublishVerificationResult{}, fmt.Errorf("failed to parse attestation for image (%q): %w", imageName, err)
+	}
+	return deployment.PublishVerificationResult{
+		Environment:        env,
+		PredicateType:      statement.PredicateType,
+		Predicate:          statement.Predicate,
+		AttestationDigests: utils.AttestationDigests(attBytes),
+	}, nil
 }
@@ -1,6 +1,8 @@
 package validate
 
 import (
+	"flag"
+	"fmt"
 	"os"
 
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
@@ -10,7 +12,10 @@ import (
 
 func usage(cli string) {
 	msg := "" +
-		"Usage: %s deployment validate orgPath projectsPath\n" +
+		"Usage: %s deployment validate orgPath projectsPath [--package-overlap warning|error]\n" +
+		"\n" +
+		"--package-overlap selects whether packages defined in multiple policies for the same\n" +
+		"environment are reported as warnings (default) or errors.\n" +
 		"\n" +
 		"Example:\n" +
 		"%s deployment validate ./path/to/policy/org ./path/to/policy/projects\n" +
@@ -25,13 +30,41 @@ func (v *PolicyValidator) ValidatePackage(pkg deployment.ValidationPackage) erro
 	return utils.ValidatePolicyPackage(pkg.Name, pkg.Environment.AnyOf)
 }
 
+// Package overlap modes.
+const (
+	packageOverlapWarning = "warning"
+	packageOverlapError   = "error"
+)
+
+func packageOverlapMode(mode string) (deployment.PackageOverlapMode, error) {
+	switch mode {
+	case packageOverlapWarning:
+		return deployment.PackageOverlapWarning, nil
+	case packageOverlapError:
+		return deployment.PackageOverlapError, nil
+	}
+	return 0, fmt.Errorf("invalid package overlap (%q). Must be one of %q", mode,
+		[]string{packageOverlapWarning, packageOverlapError})
+}
+
 func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	packageOverlap := fs.String("package-overlap", packageOverlapWarning, "report package overlaps as warning or error")
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
 	// We need 2 paths:
 	// 1. Path to org policy
 	// 2. Path to project policy.
 	if len(args) != 2 {
 		usage(cli)
 	}
+	overlapMode, err := packageOverlapMode(*packageOverlap)
+	if err != nil {
+		return err
+	}
 	orgPath := args[0]
 	projectsPath, err := utils.ReadFiles(args[1], orgPath)
 	if err != nil {
@@ -44,9 +77,13 @@ func Run(cli string, args []string) error {
 	}
 	projectsReader := named_files_reader.FromPaths(cwd, projectsPath)
 	organizationReader, err := os.Open(orgPath)
-	_, err = deployment.PolicyNew(organizationReader, projectsReader, deployment.SetValidator(&PolicyValidator{}))
+	pol, err := deployment.PolicyNew(organizationReader, projectsReader, deployment.SetValidator(&PolicyValidator{}),
+		deployment.SetPackageOverlap(overlapMode))
 	if err != nil {
 		return err
 	}
+	for _, overlap := range pol.PackageOverlaps() {
+		utils.Log("warning: %s\n", overlap)
+	}
 	return nil
 }
@@ -0,0 +1,75 @@
+package verify
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils/crypto"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s deployment verify packageURI --scope key=value [--scope key=value]... (--deployer-id id | --deployer-id-regex regex) [--attestation path [--insecure-skip-signature]]\n" +
+		"\n" +
+		"Example:\n" +
+		"%s deployment verify slsa-framework/echo-server@sha256:xxxx --scope cloud.google.com/service_account/v1=name@project-id.iam.gserviceaccount.com --deployer-id https://github.com/org/repo/.github/workflows/image-deployer.yml@refs/heads/main\n" +
+		"\n"
+	fmt.Fprintf(os.Stderr, msg, cli, cli)
+	os.Exit(1)
+}
+
+func Run(cli string, args []string) error {
+	scopes := utils.KeyValueFlag{}
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	fs.Var(scopes, "scope", "scope the attestation must contain, as key=value. May be repeated")
+	deployerID := fs.String("deployer-id", "", "identity of the deployer that signed the attestation")
+	deployerIDRegex := fs.String("deployer-id-regex", "", "regex for the identity of the deployer that signed the attestation")
+	attestationPath := fs.String("attestation", "", "path to an attestation bundle to use instead of fetching it from the registry")
+	insecureSkipSignature := fs.Bool("insecure-skip-signature", false, "do not verify the signature of the --attestation file (insecure)")
+	positional, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || len(scopes) == 0 {
+		usage(cli)
+	}
+	// Extract inputs.
+	imageURI, digest, err := utils.ParseImageReference(positional[0])
+	if err != nil {
+		return err
+	}
+	digests, err := intoto.DigestSetFromString(digest)
+	if err != nil {
+		return err
+	}
+
+	// Retrieve the attestation.
+	immutableImage, err := utils.ImmutableImage(imageURI, digests)
+	if err != nil {
+		return err
+	}
+	attBytes, err := crypto.ReadAttestation(immutableImage, deployment.PredicateType(),
+		*deployerID, *deployerIDRegex, *attestationPath, *insecureSkipSignature)
+	if err != nil {
+		return err
+	}
+
+	// Verify the attestation content.
+	verification, err := deployment.VerificationNew(io.NopCloser(bytes.NewReader(attBytes)))
+	if err != nil {
+		return fmt.Errorf("failed to create verifier for image (%q): %w", imageURI, err)
+	}
+	// NOTE: convert to the unnamed map type, since scopes are compared with reflect.DeepEqual.
+	if err := verification.Verify(digests, map[string]string(scopes)); err != nil {
+		return fmt.Errorf("failed to verify image (%q) with scopes (%q): %w", imageURI, scopes, err)
+	}
+	utils.Log("Image (%q) verified with scopes (%q)\n", immutableImage, scopes)
+	return nil
+}
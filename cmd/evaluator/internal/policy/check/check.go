@@ -0,0 +1,94 @@
+package check
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	deploymentEvaluate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/evaluate"
+	lintcmd "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy/lint"
+	publishEvaluate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/publish/evaluate"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/consistency"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s policy check --publish-org path --publish-projects path --deployment-org path --deployment-projects path\n" +
+		"       [--publisher-id id] [--format text|sarif] [--fail-on error|warning|note|none] [--rules]\n" +
+		"\n" +
+		"Validates the publish and deployment policies and reports the deployment rules that the publish\n" +
+		"policy cannot satisfy, e.g. an environment or a SLSA level, and the packages that are only published\n" +
+		"or only deployed. --rules lists the rules. The command fails if a finding is at least as severe\n" +
+		"as --fail-on, which defaults to error. The SLSA levels of the publish policy are capped by the\n" +
+		"max_slsa_level of --publisher-id in the deployment org policy, or of the highest publisher if unset.\n" +
+		"\n" +
+		"Example:\n" +
+		"%s policy check --publish-org ./policies/publish/org.json --publish-projects ./policies/publish --deployment-org ./policies/deployment/org.json --deployment-projects ./policies/deployment\n" +
+		"\n"
+	utils.Log(msg, cli, cli)
+	os.Exit(1)
+}
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	var paths utils.PolicyPaths
+	paths.SetFlags(fs)
+	var output lintcmd.OutputFlags
+	output.SetFlags(fs, lint.SeverityError)
+	publisherID := fs.String("publisher-id", "", "identity of the publisher that signs the publish attestations")
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		usage(cli)
+	}
+	rules := consistency.Rules()
+	if output.ListRules {
+		lintcmd.PrintRules(rules)
+		return nil
+	}
+	if err := output.Validate(); err != nil {
+		return err
+	}
+	if err := paths.Validate(); err != nil {
+		return err
+	}
+	if !paths.HasPublish() || !paths.HasDeployment() {
+		return fmt.Errorf("both the publish and deployment policies must be set")
+	}
+	findings, err := Check(paths, *publisherID)
+	if err != nil {
+		return err
+	}
+	return output.Report("check", rules, findings)
+}
+
+// Check returns the consistency findings for the publish and deployment policies.
+// The locations are the paths of the deployment policy files, or the publish projects
+// path for the findings of the publish policy. If set, the publisherID caps the
+// SLSA levels of the publish policy.
+func Check(paths utils.PolicyPaths, publisherID string) ([]lint.Finding, error) {
+	publishPolicy, err := publishEvaluate.PolicyNew(paths.PublishOrg, paths.PublishProjects)
+	if err != nil {
+		return nil, err
+	}
+	deploymentPolicy, err := deploymentEvaluate.PolicyNew(paths.DeploymentOrg, paths.DeploymentProjects)
+	if err != nil {
+		return nil, err
+	}
+	var opts []consistency.Option
+	if publisherID != "" {
+		opts = append(opts, consistency.SetPublisherID(publisherID))
+	}
+	findings := consistency.Check(publishPolicy, deploymentPolicy, opts...)
+	for i := range findings {
+		if findings[i].Location == "" {
+			findings[i].Location = paths.PublishProjects
+		}
+	}
+	return findings, nil
+}
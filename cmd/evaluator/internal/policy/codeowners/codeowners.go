@@ -0,0 +1,270 @@
+package codeowners
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	deploymentEvaluate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/evaluate"
+	publishEvaluate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/publish/evaluate"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/owners"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s policy codeowners [--publish-org path --publish-projects path] [--deployment-org path --deployment-projects path]\n" +
+		"       --admins owner [--admins owner]... [--root path]\n" +
+		"       %s policy codeowners [--publish-org path --publish-projects path] [--deployment-org path --deployment-projects path]\n" +
+		"       --repository repository [--root path] file...\n" +
+		"\n" +
+		"Generates a CODEOWNERS file for the policy repository. Project policies with an 'owners' block\n" +
+		"are owned by their editors. All other files are owned by the admins.\n" +
+		"With --repository, checks instead that the repository is allowed to edit the files, i.e. that they\n" +
+		"are project policies listing it in their owners' repositories.\n" +
+		"Paths are relative to --root, which defaults to the current directory.\n" +
+		"\n" +
+		"Example:\n" +
+		"%s policy codeowners --publish-org ./policies/publish/org.json --publish-projects ./policies/publish --admins @org/policy-admins > .github/CODEOWNERS\n" +
+		"%s policy codeowners --publish-org ./policies/publish/org.json --publish-projects ./policies/publish --repository github.com/org/repo ./policies/publish/team-x.json\n" +
+		"\n"
+	utils.Log(msg, cli, cli, cli, cli)
+	os.Exit(1)
+}
+
+// Entry defines the owners of a project policy file.
+type Entry struct {
+	Path         string
+	Team         string
+	Contacts     []string
+	Editors      []string
+	Repositories []string
+}
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("codeowners", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	var paths utils.PolicyPaths
+	paths.SetFlags(fs)
+	var admins utils.ListFlag
+	fs.Var(&admins, "admins", "owner of the files without owners (repeatable)")
+	repository := fs.String("repository", "", "repository whose changes to the files are checked")
+	root := fs.String("root", ".", "root of the policy repository")
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if (*repository == "") != (len(args) == 0) {
+		usage(cli)
+	}
+	if err := paths.Validate(); err != nil {
+		return err
+	}
+	// NOTE: every file must have an owner.
+	if *repository == "" && len(admins) == 0 {
+		return fmt.Errorf("--admins is required")
+	}
+	var entries []Entry
+	if paths.HasPublish() {
+		// NOTE: this validates all the policy files.
+		if _, err := publishEvaluate.PolicyNew(paths.PublishOrg, paths.PublishProjects); err != nil {
+			return err
+		}
+		publishEntries, err := readEntries(*root, paths.PublishOrg, paths.PublishProjects, func(reader io.ReadCloser) (*Entry, error) {
+			owners, err := publish.ProjectOwners(reader)
+			if err != nil || owners == nil {
+				return nil, err
+			}
+			return &Entry{Team: owners.Team, Contacts: owners.Contacts, Editors: owners.Editors,
+				Repositories: owners.Repositories}, nil
+		})
+		if err != nil {
+			return err
+		}
+		entries = append(entries, publishEntries...)
+	}
+	if paths.HasDeployment() {
+		if _, err := deploymentEvaluate.PolicyNew(paths.DeploymentOrg, paths.DeploymentProjects); err != nil {
+			return err
+		}
+		deploymentEntries, err := readEntries(*root, paths.DeploymentOrg, paths.DeploymentProjects, func(reader io.ReadCloser) (*Entry, error) {
+			owners, err := deployment.ProjectOwners(reader)
+			if err != nil || owners == nil {
+				return nil, err
+			}
+			return &Entry{Team: owners.Team, Contacts: owners.Contacts, Editors: owners.Editors,
+				Repositories: owners.Repositories}, nil
+		})
+		if err != nil {
+			return err
+		}
+		entries = append(entries, deploymentEntries...)
+	}
+	if *repository != "" {
+		files, err := relativePaths(*root, args)
+		if err != nil {
+			return err
+		}
+		return CheckRepository(*repository, files, entries)
+	}
+	content, err := Generate(admins, entries)
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
+
+// readEntries returns the owners of the project policies, with their path relative to root.
+func readEntries(root, orgPath, projectsDir string, owners func(io.ReadCloser) (*Entry, error)) ([]Entry, error) {
+	projectsPath, err := utils.ReadFiles(projectsDir, orgPath)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, path := range projectsPath {
+		reader, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read project path: %w", err)
+		}
+		entry, err := owners(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read owners of (%q): %w", path, err)
+		}
+		if entry == nil {
+			continue
+		}
+		relPath, err := relativePath(root, path)
+		if err != nil {
+			return nil, err
+		}
+		entry.Path = relPath
+		entries = append(entries, *entry)
+	}
+	return entries, nil
+}
+
+// relativePaths returns the paths relative to root.
+func relativePaths(root string, paths []string) ([]string, error) {
+	res := make([]string, 0, len(paths))
+	for _, path := range paths {
+		relPath, err := relativePath(root, path)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, relPath)
+	}
+	return res, nil
+}
+
+// relativePath returns the path relative to root, with forward slashes.
+func relativePath(root, path string) (string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	relPath, err := filepath.Rel(absRoot, absPath)
+	if err != nil {
+		return "", err
+	}
+	if relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path (%q) is not under root (%q)", path, root)
+	}
+	return filepath.ToSlash(relPath), nil
+}
+
+// CheckRepository returns an error if the repository is not allowed to edit
+// one of the files. Files without an entry are owned by the admins,
+// so no repository is allowed to edit them.
+func CheckRepository(repository string, files []string, entries []Entry) error {
+	byPath := make(map[string]Entry, len(entries))
+	for _, entry := range entries {
+		byPath[entry.Path] = entry
+	}
+	for _, file := range files {
+		entry, exists := byPath[file]
+		if !exists {
+			return fmt.Errorf("%w: repository (%q) is not allowed to edit (%q), which has no owners",
+				errs.ErrorVerification, repository, file)
+		}
+		o := entry.toOwners()
+		if !o.CanEdit(repository) {
+			return fmt.Errorf("%w: repository (%q) is not allowed to edit (%q). Must be one of %q",
+				errs.ErrorVerification, repository, file, entry.Repositories)
+		}
+	}
+	return nil
+}
+
+// Generate returns the content of a CODEOWNERS file. The admins own all the files,
+// unless a file has an entry. Entries are sorted by path.
+// Admins are required, so that every file has an owner.
+// Values that would inject additional lines or owners are rejected.
+func Generate(admins []string, entries []Entry) (string, error) {
+	if len(admins) == 0 {
+		return "", fmt.Errorf("%w: no admins", errs.ErrorInvalidInput)
+	}
+	for _, admin := range admins {
+		if admin == "" || !owners.IsSafe(admin) {
+			return "", fmt.Errorf("%w: admin (%q) is empty or contains whitespace or control characters",
+				errs.ErrorInvalidInput, admin)
+		}
+	}
+	var b strings.Builder
+	b.WriteString("# This file is generated by `evaluator policy codeowners`. Do not edit.\n")
+	b.WriteString("\n* " + strings.Join(admins, " ") + "\n")
+	sorted := append([]Entry{}, entries...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Path < sorted[j].Path
+	})
+	for _, entry := range sorted {
+		if err := entry.validate(); err != nil {
+			return "", err
+		}
+		b.WriteString("\n# Team: " + entry.Team)
+		if len(entry.Contacts) > 0 {
+			b.WriteString(" (" + strings.Join(entry.Contacts, ", ") + ")")
+		}
+		// NOTE: CODEOWNERS paths escape spaces with a backslash.
+		path := strings.ReplaceAll(entry.Path, " ", "\\ ")
+		b.WriteString("\n/" + path + " " + strings.Join(entry.Editors, " ") + "\n")
+	}
+	return b.String(), nil
+}
+
+// validate validates an entry before it is written to a CODEOWNERS file.
+// Spaces are allowed in the path because they are escaped.
+func (e Entry) validate() error {
+	if e.Path == "" || strings.IndexFunc(e.Path, func(r rune) bool {
+		return r != ' ' && (unicode.IsSpace(r) || unicode.IsControl(r))
+	}) != -1 {
+		return fmt.Errorf("%w: path (%q) is empty or contains control characters", errs.ErrorInvalidInput, e.Path)
+	}
+	o := e.toOwners()
+	if err := o.Validate(); err != nil {
+		return fmt.Errorf("%w: path (%q)", err, e.Path)
+	}
+	return nil
+}
+
+// toOwners returns the owners of the entry.
+func (e Entry) toOwners() owners.Owners {
+	return owners.Owners{
+		Team:         e.Team,
+		Contacts:     e.Contacts,
+		Editors:      e.Editors,
+		Repositories: e.Repositories,
+	}
+}
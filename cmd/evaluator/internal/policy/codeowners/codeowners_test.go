@@ -0,0 +1,194 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_Generate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		admins   []string
+		entries  []Entry
+		expected string
+		err      error
+	}{
+		{
+			name: "no admins",
+			entries: []Entry{
+				{
+					Path:    "policies/publish/team-x.json",
+					Team:    "team-x",
+					Editors: []string{"@org/team-x"},
+				},
+			},
+			err: errs.ErrorInvalidInput,
+		},
+		{
+			name:   "admins only",
+			admins: []string{"@org/admins", "@admin"},
+			expected: "" +
+				"# This file is generated by `evaluator policy codeowners`. Do not edit.\n" +
+				"\n" +
+				"* @org/admins @admin\n",
+		},
+		{
+			name:   "sorted entries",
+			admins: []string{"@org/admins"},
+			entries: []Entry{
+				{
+					Path:    "policies/publish/team-y.json",
+					Team:    "team-y",
+					Editors: []string{"@org/team-y"},
+				},
+				{
+					Path:     "policies/publish/team x.json",
+					Team:     "team-x",
+					Contacts: []string{"team-x@example.com", "lead@example.com"},
+					Editors:  []string{"@org/team-x", "@user"},
+				},
+			},
+			expected: "" +
+				"# This file is generated by `evaluator policy codeowners`. Do not edit.\n" +
+				"\n" +
+				"* @org/admins\n" +
+				"\n" +
+				"# Team: team-x (team-x@example.com, lead@example.com)\n" +
+				"/policies/publish/team\\ x.json @org/team-x @user\n" +
+				"\n" +
+				"# Team: team-y\n" +
+				"/policies/publish/team-y.json @org/team-y\n",
+		},
+		{
+			name:   "admin with newline",
+			admins: []string{"@org/admins\n* @org/evil"},
+			err:    errs.ErrorInvalidInput,
+		},
+		{
+			name:   "team with newline",
+			admins: []string{"@org/admins"},
+			entries: []Entry{
+				{
+					Path:    "policies/publish/team-x.json",
+					Team:    "x\n* @org/evil",
+					Editors: []string{"@org/team-x"},
+				},
+			},
+			err: errs.ErrorInvalidField,
+		},
+		{
+			name:   "contact with newline",
+			admins: []string{"@org/admins"},
+			entries: []Entry{
+				{
+					Path:     "policies/publish/team-x.json",
+					Team:     "team-x",
+					Contacts: []string{"team-x@example.com)\n* @org/evil"},
+					Editors:  []string{"@org/team-x"},
+				},
+			},
+			err: errs.ErrorInvalidField,
+		},
+		{
+			name:   "editor with space",
+			admins: []string{"@org/admins"},
+			entries: []Entry{
+				{
+					Path:    "policies/publish/team-x.json",
+					Team:    "team-x",
+					Editors: []string{"@org/team-x @org/evil"},
+				},
+			},
+			err: errs.ErrorInvalidField,
+		},
+		{
+			name:   "path with newline",
+			admins: []string{"@org/admins"},
+			entries: []Entry{
+				{
+					Path:    "policies/publish/team-x.json\n* @org/evil",
+					Team:    "team-x",
+					Editors: []string{"@org/team-x"},
+				},
+			},
+			err: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := Generate(tt.admins, tt.entries)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expected, content); diff != "" {
+				t.Fatalf("unexpected content (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_CheckRepository(t *testing.T) {
+	t.Parallel()
+	entries := []Entry{
+		{
+			Path:         "policies/publish/team-x.json",
+			Team:         "team-x",
+			Editors:      []string{"@org/team-x"},
+			Repositories: []string{"github.com/org/repo-x"},
+		},
+		{
+			Path:    "policies/publish/team-y.json",
+			Team:    "team-y",
+			Editors: []string{"@org/team-y"},
+		},
+	}
+	tests := []struct {
+		name       string
+		repository string
+		files      []string
+		err        error
+	}{
+		{
+			name:       "allowed repository",
+			repository: "github.com/org/repo-x",
+			files:      []string{"policies/publish/team-x.json"},
+		},
+		{
+			name:       "other repository",
+			repository: "github.com/org/repo-y",
+			files:      []string{"policies/publish/team-x.json"},
+			err:        errs.ErrorVerification,
+		},
+		{
+			name:       "policy without repositories",
+			repository: "github.com/org/repo-x",
+			files:      []string{"policies/publish/team-x.json", "policies/publish/team-y.json"},
+			err:        errs.ErrorVerification,
+		},
+		{
+			name:       "file without owners",
+			repository: "github.com/org/repo-x",
+			files:      []string{"policies/publish/org.json"},
+			err:        errs.ErrorVerification,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckRepository(tt.repository, tt.files, entries)
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
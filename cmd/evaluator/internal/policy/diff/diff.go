@@ -0,0 +1,200 @@
+package diff
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	deploymentValidate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/validate"
+	publishValidate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/publish/validate"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/diff"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator/files_reader"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator/named_files_reader"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s policy diff [--publish-org path --publish-projects path] [--deployment-org path --deployment-projects path]\n" +
+		"       [--format text|json] [--fail-on-weakening] old-dir new-dir\n" +
+		"\n" +
+		"Validates two versions of the policies and reports their semantic changes. The policy paths\n" +
+		"are relative to old-dir and new-dir. Changes that weaken the policies, such as a lower SLSA level\n" +
+		"or a new environment, are flagged. --fail-on-weakening fails the command if there are any.\n" +
+		"\n" +
+		"Example:\n" +
+		"%s policy diff --publish-org policies/publish/org.json --publish-projects policies/publish ./main ./pr\n" +
+		"\n"
+	utils.Log(msg, cli, cli)
+	os.Exit(1)
+}
+
+// Output formats.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// Policy types.
+const (
+	policyPublish    = "publish"
+	policyDeployment = "deployment"
+)
+
+// Change defines a change of a publish or deployment policy.
+type Change struct {
+	Policy string `json:"policy"`
+	diff.Change
+}
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	var paths utils.PolicyPaths
+	paths.SetFlags(fs)
+	format := fs.String("format", formatText, "output format: text or json")
+	failOnWeakening := fs.Bool("fail-on-weakening", false, "fail if a change weakens the policies")
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		usage(cli)
+	}
+	if *format != formatText && *format != formatJSON {
+		return fmt.Errorf("invalid format (%q). Must be one of %q", *format, []string{formatText, formatJSON})
+	}
+	if err := paths.Validate(); err != nil {
+		return err
+	}
+	changes, err := Diff(paths, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case formatJSON:
+		content, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal changes: %w", err)
+		}
+		fmt.Println(string(content))
+	default:
+		for _, change := range changes {
+			fmt.Println(Format(change))
+		}
+	}
+
+	if !*failOnWeakening {
+		return nil
+	}
+	count := 0
+	for _, change := range changes {
+		if change.Weakening {
+			count++
+		}
+	}
+	if count > 0 {
+		return fmt.Errorf("diff found %d weakening change(s)", count)
+	}
+	return nil
+}
+
+// Diff returns the changes between the policies in the old and new directories.
+// Deployment policy IDs are the paths of the policy files relative to their directory.
+func Diff(paths utils.PolicyPaths, oldDir, newDir string) ([]Change, error) {
+	var changes []Change
+	if paths.HasPublish() {
+		oldPolicy, err := publishPolicyNew(oldDir, paths)
+		if err != nil {
+			return nil, err
+		}
+		newPolicy, err := publishPolicyNew(newDir, paths)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range publish.Diff(oldPolicy, newPolicy) {
+			changes = append(changes, Change{Policy: policyPublish, Change: change})
+		}
+	}
+	if paths.HasDeployment() {
+		oldPolicy, err := deploymentPolicyNew(oldDir, paths)
+		if err != nil {
+			return nil, err
+		}
+		newPolicy, err := deploymentPolicyNew(newDir, paths)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range deployment.Diff(oldPolicy, newPolicy) {
+			changes = append(changes, Change{Policy: policyDeployment, Change: change})
+		}
+	}
+	return changes, nil
+}
+
+func publishPolicyNew(dir string, paths utils.PolicyPaths) (*publish.Policy, error) {
+	orgPath := filepath.Join(dir, paths.PublishOrg)
+	projectsPath, err := utils.ReadFiles(filepath.Join(dir, paths.PublishProjects), orgPath)
+	if err != nil {
+		return nil, err
+	}
+	organizationReader, err := os.Open(orgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read org path: %w", err)
+	}
+	pol, err := publish.PolicyNew(organizationReader, files_reader.FromPaths(projectsPath), &utils.PackageHelper{},
+		publish.SetValidator(&publishValidate.PolicyValidator{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create publish policy in (%q): %w", dir, err)
+	}
+	return pol, nil
+}
+
+func deploymentPolicyNew(dir string, paths utils.PolicyPaths) (*deployment.Policy, error) {
+	orgPath := filepath.Join(dir, paths.DeploymentOrg)
+	projectsPath, err := utils.ReadFiles(filepath.Join(dir, paths.DeploymentProjects), orgPath)
+	if err != nil {
+		return nil, err
+	}
+	organizationReader, err := os.Open(orgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read org path: %w", err)
+	}
+	// NOTE: the IDs are relative to dir so that they are the same in both versions.
+	pol, err := deployment.PolicyNew(organizationReader, named_files_reader.FromPaths(dir, projectsPath),
+		deployment.SetValidator(&deploymentValidate.PolicyValidator{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment policy in (%q): %w", dir, err)
+	}
+	return pol, nil
+}
+
+// Format returns a line describing a change.
+func Format(change Change) string {
+	subject := change.Subject
+	if subject == "" {
+		subject = "org"
+	}
+	var value string
+	switch change.Kind {
+	case diff.KindAdded:
+		value = change.New
+	case diff.KindRemoved:
+		value = change.Old
+	default:
+		value = fmt.Sprintf("%q -> %q", change.Old, change.New)
+	}
+	line := fmt.Sprintf("%s: %s: %s %s", change.Policy, subject, change.Kind, change.Field)
+	if value != "" {
+		line += ": " + value
+	}
+	if change.Weakening {
+		line = "WEAKENING " + line
+	}
+	return line
+}
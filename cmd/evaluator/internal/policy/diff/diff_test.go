@@ -0,0 +1,69 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/utils/diff"
+)
+
+func Test_Format(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		change   Change
+		expected string
+	}{
+		{
+			name: "org change",
+			change: Change{
+				Policy: policyPublish,
+				Change: diff.Change{
+					Kind:  diff.KindAdded,
+					Field: "builder",
+					New:   "builder_name (id builder_id, level 3)",
+				},
+			},
+			expected: "publish: org: added builder: builder_name (id builder_id, level 3)",
+		},
+		{
+			name: "removed without value",
+			change: Change{
+				Policy: policyPublish,
+				Change: diff.Change{
+					Kind:    diff.KindRemoved,
+					Subject: "package_name",
+					Field:   "package",
+				},
+			},
+			expected: "publish: package_name: removed package",
+		},
+		{
+			name: "weakening change",
+			change: Change{
+				Policy: policyDeployment,
+				Change: diff.Change{
+					Kind:      diff.KindModified,
+					Subject:   "policies/deployment/servers.json",
+					Field:     "build.require_slsa_level",
+					Old:       "3",
+					New:       "2",
+					Weakening: true,
+				},
+			},
+			expected: `WEAKENING deployment: policies/deployment/servers.json: modified build.require_slsa_level: "3" -> "2"`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			line := Format(tt.change)
+			if diff := cmp.Diff(tt.expected, line); diff != "" {
+				t.Fatalf("unexpected line (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
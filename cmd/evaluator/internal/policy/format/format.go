@@ -0,0 +1,138 @@
+package format
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	canonical "github.com/slsa-framework/slsa-policy/pkg/utils/format"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s policy fmt [--publish-org path --publish-projects path] [--deployment-org path --deployment-projects path]\n" +
+		"       [--check] [--format json|yaml]\n" +
+		"\n" +
+		"Rewrites the policy files in their canonical JSON encoding: keys are sorted, packages and environments\n" +
+		"are sorted, and null values and empty objects are removed. --check only lists the files that are not\n" +
+		"formatted and fails if there are any, e.g. for pre-submit. --format yaml prints the YAML encoding\n" +
+		"of the files instead.\n" +
+		"\n" +
+		"Example:\n" +
+		"%s policy fmt --check --publish-org ./policies/publish/org.json --publish-projects ./policies/publish\n" +
+		"\n"
+	utils.Log(msg, cli, cli)
+	os.Exit(1)
+}
+
+// Output formats.
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	var paths utils.PolicyPaths
+	paths.SetFlags(fs)
+	check := fs.Bool("check", false, "list the files that are not formatted and fail if there are any")
+	format := fs.String("format", formatJSON, "output format: json or yaml")
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		usage(cli)
+	}
+	if *format != formatJSON && *format != formatYAML {
+		return fmt.Errorf("invalid format (%q). Must be one of %q", *format, []string{formatJSON, formatYAML})
+	}
+	if *check && *format != formatJSON {
+		return fmt.Errorf("--check only supports the %q format", formatJSON)
+	}
+	if err := paths.Validate(); err != nil {
+		return err
+	}
+	files, err := Files(paths)
+	if err != nil {
+		return err
+	}
+
+	if *format == formatYAML {
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %q: %w", file, err)
+			}
+			content, err = canonical.FormatYAML(content)
+			if err != nil {
+				return fmt.Errorf("failed to format %q: %w", file, err)
+			}
+			fmt.Printf("---\n# %s\n%s", file, content)
+		}
+		return nil
+	}
+
+	count := 0
+	for _, file := range files {
+		content, changed, err := Format(file)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		count++
+		fmt.Println(file)
+		if *check {
+			continue
+		}
+		// NOTE: keep the permissions of the existing file.
+		if err := os.WriteFile(file, content, 0o600); err != nil {
+			return fmt.Errorf("failed to write %q: %w", file, err)
+		}
+	}
+	if *check && count > 0 {
+		return fmt.Errorf("%d file(s) not formatted. Run '%s policy fmt' to format them", count, cli)
+	}
+	return nil
+}
+
+// Files returns the paths of the org and project policy files.
+func Files(paths utils.PolicyPaths) ([]string, error) {
+	var files []string
+	if paths.HasPublish() {
+		projects, err := utils.ReadFiles(paths.PublishProjects, paths.PublishOrg)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, paths.PublishOrg)
+		files = append(files, projects...)
+	}
+	if paths.HasDeployment() {
+		projects, err := utils.ReadFiles(paths.DeploymentProjects, paths.DeploymentOrg)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, paths.DeploymentOrg)
+		files = append(files, projects...)
+	}
+	return files, nil
+}
+
+// Format returns the canonical JSON encoding of a policy file,
+// and whether it differs from the content of the file.
+func Format(path string) ([]byte, bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+	formatted, err := canonical.FormatJSON(content)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to format %q: %w", path, err)
+	}
+	return formatted, !bytes.Equal(content, formatted), nil
+}
@@ -0,0 +1,118 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+)
+
+func Test_Format(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		changed  bool
+		err      bool
+	}{
+		{
+			name: "formatted",
+			content: "" +
+				"{\n" +
+				"  \"format\": 1\n" +
+				"}\n",
+			expected: "" +
+				"{\n" +
+				"  \"format\": 1\n" +
+				"}\n",
+		},
+		{
+			name:    "not formatted",
+			content: `{"packages": [{"name": "b"}, {"name": "a", "environment": {"any_of": ["prod", "dev"]}}], "format": 1}`,
+			expected: "" +
+				"{\n" +
+				"  \"format\": 1,\n" +
+				"  \"packages\": [\n" +
+				"    {\n" +
+				"      \"environment\": {\n" +
+				"        \"any_of\": [\n" +
+				"          \"dev\",\n" +
+				"          \"prod\"\n" +
+				"        ]\n" +
+				"      },\n" +
+				"      \"name\": \"a\"\n" +
+				"    },\n" +
+				"    {\n" +
+				"      \"name\": \"b\"\n" +
+				"    }\n" +
+				"  ]\n" +
+				"}\n",
+			changed: true,
+		},
+		{
+			name:    "invalid JSON",
+			content: `{"format": `,
+			err:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "policy.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			content, changed, err := Format(path)
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.changed, changed); diff != "" {
+				t.Fatalf("unexpected changed (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expected, string(content)); diff != "" {
+				t.Fatalf("unexpected content (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Files(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for _, path := range []string{"publish/org.json", "publish/team1.json", "deployment/org.json", "deployment/team2.json"} {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	files, err := Files(utils.PolicyPaths{
+		PublishOrg:         filepath.Join(dir, "publish/org.json"),
+		PublishProjects:    filepath.Join(dir, "publish"),
+		DeploymentOrg:      filepath.Join(dir, "deployment/org.json"),
+		DeploymentProjects: filepath.Join(dir, "deployment"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expected := []string{
+		filepath.Join(dir, "publish/org.json"),
+		filepath.Join(dir, "publish/team1.json"),
+		filepath.Join(dir, "deployment/org.json"),
+		filepath.Join(dir, "deployment/team2.json"),
+	}
+	if diff := cmp.Diff(expected, files); diff != "" {
+		t.Fatalf("unexpected files (-want +got): \n%s", diff)
+	}
+}
@@ -0,0 +1,153 @@
+package lint
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	deploymentValidate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/validate"
+	publishValidate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/publish/validate"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator/named_files_reader"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s policy lint [--publish-org path --publish-projects path] [--deployment-org path --deployment-projects path]\n" +
+		"       [--suppress ruleID[:path]]... [--format text|sarif] [--fail-on error|warning|note|none] [--rules]\n" +
+		"\n" +
+		"Validates the policies and reports violations of best-practice rules. --rules lists the rules.\n" +
+		"--suppress ignores a rule, for all the files or for one file. The command fails if a finding\n" +
+		"is at least as severe as --fail-on, which defaults to warning.\n" +
+		"\n" +
+		"Example:\n" +
+		"%s policy lint --publish-org ./policies/publish/org.json --publish-projects ./policies/publish --format sarif > lint.sarif\n" +
+		"\n"
+	utils.Log(msg, cli, cli)
+	os.Exit(1)
+}
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	var paths utils.PolicyPaths
+	paths.SetFlags(fs)
+	var suppress utils.ListFlag
+	fs.Var(&suppress, "suppress", "rule to ignore, of the form ruleID or ruleID:path (repeatable)")
+	var output OutputFlags
+	output.SetFlags(fs, lint.SeverityWarning)
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		usage(cli)
+	}
+	rules := append(publish.LintRules(), deployment.LintRules()...)
+	if output.ListRules {
+		PrintRules(rules)
+		return nil
+	}
+	if err := output.Validate(); err != nil {
+		return err
+	}
+	suppressions, err := parseSuppressions(suppress, rules)
+	if err != nil {
+		return err
+	}
+	if err := paths.Validate(); err != nil {
+		return err
+	}
+	findings, err := Lint(paths)
+	if err != nil {
+		return err
+	}
+	findings = lint.Filter(findings, suppressions)
+	lint.Sort(findings)
+	return output.Report("lint", rules, findings)
+}
+
+// Lint returns the findings for the publish and deployment policies.
+// The locations are the paths of the policy files.
+func Lint(paths utils.PolicyPaths) ([]lint.Finding, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	var findings []lint.Finding
+	if paths.HasPublish() {
+		projectsPath, err := utils.ReadFiles(paths.PublishProjects, paths.PublishOrg)
+		if err != nil {
+			return nil, err
+		}
+		organizationReader, err := os.Open(paths.PublishOrg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read org path: %w", err)
+		}
+		publishFindings, err := publish.Lint(organizationReader, named_files_reader.FromPaths(cwd, projectsPath),
+			publish.SetValidator(&publishValidate.PolicyValidator{}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to lint publish policy: %w", err)
+		}
+		findings = append(findings, withOrgLocation(publishFindings, cwd, paths.PublishOrg)...)
+	}
+	if paths.HasDeployment() {
+		projectsPath, err := utils.ReadFiles(paths.DeploymentProjects, paths.DeploymentOrg)
+		if err != nil {
+			return nil, err
+		}
+		organizationReader, err := os.Open(paths.DeploymentOrg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read org path: %w", err)
+		}
+		deploymentFindings, err := deployment.Lint(organizationReader, named_files_reader.FromPaths(cwd, projectsPath),
+			deployment.SetValidator(&deploymentValidate.PolicyValidator{}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to lint deployment policy: %w", err)
+		}
+		findings = append(findings, withOrgLocation(deploymentFindings, cwd, paths.DeploymentOrg)...)
+	}
+	return findings, nil
+}
+
+// withOrgLocation sets the location of the org findings. Like the project
+// locations, the org path is relative to root if it is under root.
+func withOrgLocation(findings []lint.Finding, root, orgPath string) []lint.Finding {
+	// NOTE: see named_files_reader.FromPaths.
+	absRoot, _ := filepath.Abs(root)
+	absPath, _ := filepath.Abs(orgPath)
+	location := strings.TrimPrefix(absPath, absRoot+string(os.PathSeparator))
+	for i := range findings {
+		if findings[i].Location == "" {
+			findings[i].Location = location
+		}
+	}
+	return findings
+}
+
+// parseSuppressions parses suppressions of the form ruleID or ruleID:path.
+func parseSuppressions(values []string, rules []lint.Rule) ([]lint.Suppression, error) {
+	suppressions := make([]lint.Suppression, 0, len(values))
+	for _, value := range values {
+		id, location, _ := strings.Cut(value, ":")
+		if !hasRule(rules, id) {
+			return nil, fmt.Errorf("invalid suppression (%q): unknown rule (%q)", value, id)
+		}
+		suppressions = append(suppressions, lint.Suppression{RuleID: id, Location: location})
+	}
+	return suppressions, nil
+}
+
+func hasRule(rules []lint.Rule, id string) bool {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
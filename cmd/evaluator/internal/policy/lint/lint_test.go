@@ -0,0 +1,161 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+func Test_parseSuppressions(t *testing.T) {
+	t.Parallel()
+	rules := []lint.Rule{
+		{ID: "RULE1"},
+		{ID: "RULE2"},
+	}
+	tests := []struct {
+		name         string
+		values       []string
+		suppressions []lint.Suppression
+		err          bool
+	}{
+		{
+			name:         "no suppressions",
+			suppressions: []lint.Suppression{},
+		},
+		{
+			name:   "rule and location",
+			values: []string{"RULE1", "RULE2:path/to/file.json"},
+			suppressions: []lint.Suppression{
+				{RuleID: "RULE1"},
+				{RuleID: "RULE2", Location: "path/to/file.json"},
+			},
+		},
+		{
+			name:   "unknown rule",
+			values: []string{"RULE3"},
+			err:    true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			suppressions, err := parseSuppressions(tt.values, rules)
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.suppressions, suppressions); diff != "" {
+				t.Fatalf("unexpected suppressions (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_withOrgLocation(t *testing.T) {
+	t.Parallel()
+	root := filepath.Join(string(filepath.Separator), "repo")
+	tests := []struct {
+		name     string
+		orgPath  string
+		expected string
+	}{
+		{
+			name:     "absolute path under root",
+			orgPath:  filepath.Join(root, "policies", "org.json"),
+			expected: "policies/org.json",
+		},
+		{
+			name:     "relative path under root",
+			orgPath:  filepath.Join(root, "policies", "..", "policies", "org.json"),
+			expected: "policies/org.json",
+		},
+		{
+			name:     "path outside root",
+			orgPath:  filepath.Join(string(filepath.Separator), "other", "org.json"),
+			expected: "/other/org.json",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			findings := withOrgLocation([]lint.Finding{
+				{RuleID: "RULE1"},
+				{RuleID: "RULE2", Location: "project.json"},
+			}, root, tt.orgPath)
+			expected := []lint.Finding{
+				{RuleID: "RULE1", Location: tt.expected},
+				{RuleID: "RULE2", Location: "project.json"},
+			}
+			if diff := cmp.Diff(expected, findings); diff != "" {
+				t.Fatalf("unexpected findings (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_SarifNew(t *testing.T) {
+	t.Parallel()
+	rules := []lint.Rule{
+		{ID: "RULE1", Name: "rule-1", Description: "description 1", Severity: lint.SeverityWarning},
+		{ID: "RULE2", Name: "rule-2", Description: "description 2", Severity: lint.SeverityNote},
+	}
+	findings := []lint.Finding{
+		{RuleID: "RULE2", Severity: lint.SeverityNote, Location: "path/to/file.json", Message: "message"},
+	}
+	sarif := SarifNew(rules, findings)
+	expected := Sarif{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []SarifRun{
+			{
+				Tool: SarifTool{
+					Driver: SarifDriver{
+						Name:           toolName,
+						InformationURI: toolURI,
+						Rules: []SarifRule{
+							{
+								ID:                   "RULE1",
+								Name:                 "rule-1",
+								ShortDescription:     SarifMessage{Text: "description 1"},
+								DefaultConfiguration: SarifRuleConfiguration{Level: "warning"},
+							},
+							{
+								ID:                   "RULE2",
+								Name:                 "rule-2",
+								ShortDescription:     SarifMessage{Text: "description 2"},
+								DefaultConfiguration: SarifRuleConfiguration{Level: "note"},
+							},
+						},
+					},
+				},
+				Results: []SarifResult{
+					{
+						RuleID:    "RULE2",
+						RuleIndex: 1,
+						Level:     "note",
+						Message:   SarifMessage{Text: "message"},
+						Locations: []SarifLocation{
+							{
+								PhysicalLocation: SarifPhysicalLocation{
+									ArtifactLocation: SarifArtifactLocation{URI: "path/to/file.json"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, sarif); diff != "" {
+		t.Fatalf("unexpected sarif (-want +got): \n%s", diff)
+	}
+}
@@ -0,0 +1,84 @@
+package lint
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+// Output formats.
+const (
+	formatText  = "text"
+	formatSarif = "sarif"
+)
+
+const failOnNone = "none"
+
+// OutputFlags defines the flags to list the rules and output the findings.
+type OutputFlags struct {
+	Format    string
+	FailOn    string
+	ListRules bool
+}
+
+// SetFlags sets the output flags. Findings at least as severe
+// as failOn fail the command, unless set otherwise by the caller.
+func (o *OutputFlags) SetFlags(fs *flag.FlagSet, failOn lint.Severity) {
+	fs.StringVar(&o.Format, "format", formatText, "output format: text or sarif")
+	fs.StringVar(&o.FailOn, "fail-on", string(failOn), "minimum severity that fails the command, or none")
+	fs.BoolVar(&o.ListRules, "rules", false, "list the rules")
+}
+
+// Validate validates the output flags.
+func (o *OutputFlags) Validate() error {
+	if o.Format != formatText && o.Format != formatSarif {
+		return fmt.Errorf("invalid format (%q). Must be one of %q", o.Format, []string{formatText, formatSarif})
+	}
+	if o.FailOn != failOnNone {
+		if err := lint.Severity(o.FailOn).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintRules prints the rules, one per line.
+func PrintRules(rules []lint.Rule) {
+	for _, rule := range rules {
+		fmt.Printf("%s\t%s\t%s\t%s\n", rule.ID, rule.Name, rule.Severity, rule.Description)
+	}
+}
+
+// Report prints the findings in the output format. It returns an error
+// if a finding is at least as severe as the fail-on severity.
+// The command name is used in the error message.
+func (o *OutputFlags) Report(command string, rules []lint.Rule, findings []lint.Finding) error {
+	switch o.Format {
+	case formatSarif:
+		content, err := json.MarshalIndent(SarifNew(rules, findings), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal sarif: %w", err)
+		}
+		fmt.Println(string(content))
+	default:
+		for _, finding := range findings {
+			fmt.Printf("%s: %s: [%s] %s\n", finding.Location, finding.Severity, finding.RuleID, finding.Message)
+		}
+	}
+
+	if o.FailOn == failOnNone {
+		return nil
+	}
+	count := 0
+	for _, finding := range findings {
+		if finding.Severity.AtLeast(lint.Severity(o.FailOn)) {
+			count++
+		}
+	}
+	if count > 0 {
+		return fmt.Errorf("%s found %d finding(s) of severity %q or above", command, count, o.FailOn)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"path/filepath"
+
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "slsa-policy-evaluator"
+	toolURI      = "https://github.com/slsa-framework/slsa-policy"
+)
+
+// Sarif defines a SARIF log.
+type Sarif struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+// SarifRun defines a run of a tool.
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+// SarifTool defines a tool.
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+// SarifDriver defines the component of the tool that ran.
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+// SarifRule defines a rule.
+type SarifRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     SarifMessage           `json:"shortDescription"`
+	DefaultConfiguration SarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+// SarifRuleConfiguration defines the default configuration of a rule.
+type SarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SarifMessage defines a message.
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+// SarifResult defines a result.
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+}
+
+// SarifLocation defines a location.
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+// SarifPhysicalLocation defines a location in a file.
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+}
+
+// SarifArtifactLocation defines a file.
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SarifNew creates a SARIF log for the findings.
+func SarifNew(rules []lint.Rule, findings []lint.Finding) Sarif {
+	run := SarifRun{
+		Tool: SarifTool{
+			Driver: SarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+				Rules:          make([]SarifRule, len(rules)),
+			},
+		},
+		// NOTE: results must be an array, even if empty.
+		Results: make([]SarifResult, 0, len(findings)),
+	}
+	indexes := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		indexes[rule.ID] = i
+		run.Tool.Driver.Rules[i] = SarifRule{
+			ID:               rule.ID,
+			Name:             rule.Name,
+			ShortDescription: SarifMessage{Text: rule.Description},
+			DefaultConfiguration: SarifRuleConfiguration{
+				Level: string(rule.Severity),
+			},
+		}
+	}
+	for _, finding := range findings {
+		run.Results = append(run.Results, SarifResult{
+			RuleID:    finding.RuleID,
+			RuleIndex: indexes[finding.RuleID],
+			Level:     string(finding.Severity),
+			Message:   SarifMessage{Text: finding.Message},
+			Locations: []SarifLocation{
+				{
+					PhysicalLocation: SarifPhysicalLocation{
+						ArtifactLocation: SarifArtifactLocation{
+							URI: filepath.ToSlash(finding.Location),
+						},
+					},
+				},
+			},
+		})
+	}
+	return Sarif{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []SarifRun{run},
+	}
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"os"
+
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy/check"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy/codeowners"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy/diff"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy/format"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy/lint"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy/query"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy/schema"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s policy [options]\n" +
+		"\n" +
+		"Available options:\n" +
+		"check \t\t\tReport the inconsistencies between the publish and deployment policies\n" +
+		"codeowners \t\tGenerate a CODEOWNERS file for the policy repository\n" +
+		"diff \t\t\tReport the semantic changes between two versions of the policies\n" +
+		"fmt \t\t\tRewrite the policy files in their canonical encoding\n" +
+		"lint \t\t\tReport violations of best-practice rules\n" +
+		"query \t\t\tAnswer questions about the policies, e.g. the builders of a package\n" +
+		"schema \t\t\tPrint the JSON Schemas of the policy files\n" +
+		"\n"
+	utils.Log(msg, cli)
+	os.Exit(1)
+}
+
+func Run(cli string, args []string) error {
+	if len(args) < 1 {
+		usage(cli)
+	}
+	var err error
+	switch args[0] {
+	default:
+		usage(cli)
+	case "check":
+		err = check.Run(cli, args[1:])
+	case "codeowners":
+		err = codeowners.Run(cli, args[1:])
+	case "diff":
+		err = diff.Run(cli, args[1:])
+	case "fmt":
+		err = format.Run(cli, args[1:])
+	case "lint":
+		err = lint.Run(cli, args[1:])
+	case "query":
+		err = query.Run(cli, args[1:])
+	case "schema":
+		err = schema.Run(cli, args[1:])
+	}
+	return err
+}
@@ -0,0 +1,227 @@
+package query
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	deploymentEvaluate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment/evaluate"
+	publishEvaluate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/publish/evaluate"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s policy query [--publish-org path --publish-projects path] [--deployment-org path --deployment-projects path]\n" +
+		"       [--format text|json] query [arguments]\n" +
+		"\n" +
+		"Available queries:\n" +
+		"service-account account \t\tPackages deployed under a Google service account\n" +
+		"builders package [environment] \tBuilders allowed to publish a package, optionally to an environment\n" +
+		"level-below level \t\t\tPackages published or deployed with a SLSA level lower than level\n" +
+		"\n" +
+		"Example:\n" +
+		"%s policy query --publish-org ./policies/publish/org.json --publish-projects ./policies/publish builders docker.io/org/echo-server prod\n" +
+		"\n"
+	utils.Log(msg, cli, cli)
+	os.Exit(1)
+}
+
+// Output formats.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// Table defines the result of a query.
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Records returns the rows as maps keyed by column.
+func (t Table) Records() []map[string]string {
+	records := make([]map[string]string, len(t.Rows))
+	for i, row := range t.Rows {
+		records[i] = make(map[string]string, len(t.Columns))
+		for j, column := range t.Columns {
+			records[i][column] = row[j]
+		}
+	}
+	return records
+}
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	var paths utils.PolicyPaths
+	paths.SetFlags(fs)
+	format := fs.String("format", formatText, "output format: text or json")
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
+		usage(cli)
+	}
+	if *format != formatText && *format != formatJSON {
+		return fmt.Errorf("invalid format (%q). Must be one of %q", *format, []string{formatText, formatJSON})
+	}
+	if err := paths.Validate(); err != nil {
+		return err
+	}
+	var publishPolicy *publish.Policy
+	if paths.HasPublish() {
+		publishPolicy, err = publishEvaluate.PolicyNew(paths.PublishOrg, paths.PublishProjects)
+		if err != nil {
+			return err
+		}
+	}
+	var deploymentPolicy *deployment.Policy
+	if paths.HasDeployment() {
+		deploymentPolicy, err = deploymentEvaluate.PolicyNew(paths.DeploymentOrg, paths.DeploymentProjects)
+		if err != nil {
+			return err
+		}
+	}
+
+	var table Table
+	switch args[0] {
+	default:
+		usage(cli)
+	case "service-account":
+		if len(args) != 2 {
+			usage(cli)
+		}
+		if deploymentPolicy == nil {
+			return fmt.Errorf("%q requires the deployment policy", args[0])
+		}
+		table = ServiceAccount(deploymentPolicy, args[1])
+	case "builders":
+		if len(args) != 2 && len(args) != 3 {
+			usage(cli)
+		}
+		if publishPolicy == nil {
+			return fmt.Errorf("%q requires the publish policy", args[0])
+		}
+		var env *string
+		if len(args) == 3 {
+			env = &args[2]
+		}
+		table, err = Builders(publishPolicy, args[1], env)
+		if err != nil {
+			return err
+		}
+	case "level-below":
+		if len(args) != 2 {
+			usage(cli)
+		}
+		level, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid level (%q): %w", args[1], err)
+		}
+		table = LevelBelow(publishPolicy, deploymentPolicy, level)
+	}
+
+	switch *format {
+	case formatJSON:
+		content, err := json.MarshalIndent(table.Records(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal result: %w", err)
+		}
+		fmt.Println(string(content))
+	default:
+		fmt.Println(strings.Join(table.Columns, "\t"))
+		for _, row := range table.Rows {
+			fmt.Println(strings.Join(row, "\t"))
+		}
+	}
+	return nil
+}
+
+// ServiceAccount returns the packages deployed under a Google service account.
+func ServiceAccount(pol *deployment.Policy, account string) Table {
+	table := Table{Columns: []string{"policy_id", "package", "environments"}}
+	for _, pkg := range pol.DeployedPackages() {
+		if pkg.GoogleServiceAccount != account {
+			continue
+		}
+		table.Rows = append(table.Rows, []string{pkg.PolicyID, pkg.Name, strings.Join(pkg.Environments, ",")})
+	}
+	return table
+}
+
+// Builders returns the builders allowed to publish a package to an environment.
+// If the environment is nil and the package has environments, it returns the builders
+// for each of them.
+func Builders(pol *publish.Policy, packageName string, environment *string) (Table, error) {
+	table := Table{Columns: []string{"package", "environment", "builder", "builder_id", "repository", "slsa_level"}}
+	pkg, exists := pol.FindPackage(packageName)
+	if !exists {
+		return Table{}, fmt.Errorf("package (%q) is not present in the publish policy", packageName)
+	}
+	environments := []*string{environment}
+	if environment == nil && len(pkg.Environments) > 0 {
+		environments = make([]*string, len(pkg.Environments))
+		for i := range pkg.Environments {
+			environments[i] = &pkg.Environments[i]
+		}
+	}
+	for _, env := range environments {
+		builders, err := pol.PackageBuilders(packageName, env)
+		if err != nil {
+			return Table{}, err
+		}
+		for _, builder := range builders {
+			table.Rows = append(table.Rows, []string{packageName, value(env), builder.Name, builder.ID,
+				builder.Repository, strconv.Itoa(builder.SlsaLevel)})
+		}
+	}
+	return table, nil
+}
+
+// LevelBelow returns the packages published or deployed with a SLSA level lower than level.
+// For the publish policy, the level is the highest level of the builders allowed
+// to publish the package. Either policy may be nil.
+func LevelBelow(publishPolicy *publish.Policy, deploymentPolicy *deployment.Policy, level int) Table {
+	table := Table{Columns: []string{"policy", "policy_id", "package", "environment", "slsa_level"}}
+	if publishPolicy != nil {
+		for _, pkg := range publishPolicy.PublishedPackages() {
+			environments := []*string{nil}
+			if len(pkg.Environments) > 0 {
+				environments = make([]*string, len(pkg.Environments))
+				for i := range pkg.Environments {
+					environments[i] = &pkg.Environments[i]
+				}
+			}
+			for _, env := range environments {
+				// NOTE: the environment is one of the package's environments, so there is no error.
+				pkgLevel, _ := publishPolicy.MaxSlsaLevel(pkg.Name, env)
+				if pkgLevel < level {
+					table.Rows = append(table.Rows, []string{"publish", "", pkg.Name, value(env), strconv.Itoa(pkgLevel)})
+				}
+			}
+		}
+	}
+	if deploymentPolicy != nil {
+		for _, pkg := range deploymentPolicy.DeployedPackages() {
+			if pkg.RequireSlsaLevel < level {
+				table.Rows = append(table.Rows, []string{"deployment", pkg.PolicyID, pkg.Name,
+					strings.Join(pkg.Environments, ","), strconv.Itoa(pkg.RequireSlsaLevel)})
+			}
+		}
+	}
+	return table
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
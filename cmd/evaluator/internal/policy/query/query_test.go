@@ -0,0 +1,195 @@
+package query
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	publishEvaluate "github.com/slsa-framework/slsa-policy/cli/evaluator/internal/publish/evaluate"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator/named_files_reader"
+)
+
+func Test_Queries(t *testing.T) {
+	t.Parallel()
+	publishPolicy, deploymentPolicy := newPolicies(t)
+	env := "prod"
+	tests := []struct {
+		name     string
+		query    func() (Table, error)
+		expected Table
+		err      bool
+	}{
+		{
+			name: "service account",
+			query: func() (Table, error) {
+				return ServiceAccount(deploymentPolicy, "name1@project.iam.gserviceaccount.com"), nil
+			},
+			expected: Table{
+				Columns: []string{"policy_id", "package", "environments"},
+				Rows: [][]string{
+					{"deployment/servers.json", "docker.io/org/echo-server", "dev,prod"},
+				},
+			},
+		},
+		{
+			name: "builders for all environments",
+			query: func() (Table, error) {
+				return Builders(publishPolicy, "docker.io/org/echo-server", nil)
+			},
+			expected: Table{
+				Columns: []string{"package", "environment", "builder", "builder_id", "repository", "slsa_level"},
+				Rows: [][]string{
+					{"docker.io/org/echo-server", "dev", "builder_name1", "builder_id1", "github.com/org/echo-server", "2"},
+					{"docker.io/org/echo-server", "prod", "builder_name2", "builder_id2", "github.com/org/echo-server", "3"},
+				},
+			},
+		},
+		{
+			name: "builders for one environment",
+			query: func() (Table, error) {
+				return Builders(publishPolicy, "docker.io/org/echo-server", &env)
+			},
+			expected: Table{
+				Columns: []string{"package", "environment", "builder", "builder_id", "repository", "slsa_level"},
+				Rows: [][]string{
+					{"docker.io/org/echo-server", "prod", "builder_name2", "builder_id2", "github.com/org/echo-server", "3"},
+				},
+			},
+		},
+		{
+			name: "builders for unknown package",
+			query: func() (Table, error) {
+				return Builders(publishPolicy, "docker.io/org/other", nil)
+			},
+			err: true,
+		},
+		{
+			name: "level below",
+			query: func() (Table, error) {
+				return LevelBelow(publishPolicy, deploymentPolicy, 3), nil
+			},
+			expected: Table{
+				Columns: []string{"policy", "policy_id", "package", "environment", "slsa_level"},
+				Rows: [][]string{
+					{"publish", "", "docker.io/org/echo-server", "dev", "2"},
+					{"deployment", "deployment/tools.json", "docker.io/org/tool", "", "2"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			table, err := tt.query()
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.expected, table); diff != "" {
+				t.Fatalf("unexpected table (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Records(t *testing.T) {
+	t.Parallel()
+	table := Table{
+		Columns: []string{"package", "slsa_level"},
+		Rows: [][]string{
+			{"package_name1", "2"},
+			{"package_name2", "3"},
+		},
+	}
+	expected := []map[string]string{
+		{"package": "package_name1", "slsa_level": "2"},
+		{"package": "package_name2", "slsa_level": "3"},
+	}
+	if diff := cmp.Diff(expected, table.Records()); diff != "" {
+		t.Fatalf("unexpected records (-want +got): \n%s", diff)
+	}
+}
+
+// newPolicies creates the policies in a temporary directory.
+// The deployment policy IDs are relative to the directory.
+func newPolicies(t *testing.T) (*publish.Policy, *deployment.Policy) {
+	files := map[string]string{
+		"publish/org.json": `{
+			"format": 1,
+			"roots": {
+				"build": [
+					{"id": "builder_id1", "name": "builder_name1", "slsa_level": 2},
+					{"id": "builder_id2", "name": "builder_name2", "slsa_level": 3}
+				]
+			}
+		}`,
+		"publish/echo-server.json": `{
+			"format": 1,
+			"package": {"name": "docker.io/org/echo-server", "environment": {"any_of": ["dev", "prod"]}},
+			"build": {
+				"require_slsa_builder": "builder_name2",
+				"repository": {"uri": "github.com/org/echo-server"},
+				"environments": {"dev": {"require_slsa_builder": "builder_name1"}}
+			}
+		}`,
+		"deployment/org.json": `{
+			"format": 1,
+			"roots": {
+				"publish": [
+					{"id": "publisher_id", "build": {"max_slsa_level": 3}}
+				]
+			}
+		}`,
+		"deployment/servers.json": `{
+			"format": 1,
+			"protection": {"google_service_account": "name1@project.iam.gserviceaccount.com"},
+			"packages": [
+				{"name": "docker.io/org/echo-server", "environment": {"any_of": ["dev", "prod"]}}
+			],
+			"build": {"require_slsa_level": 3}
+		}`,
+		"deployment/tools.json": `{
+			"format": 1,
+			"protection": {"google_service_account": "name2@project.iam.gserviceaccount.com"},
+			"packages": [
+				{"name": "docker.io/org/tool"}
+			],
+			"build": {"require_slsa_level": 2}
+		}`,
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	publishPolicy, err := publishEvaluate.PolicyNew(filepath.Join(dir, "publish/org.json"), filepath.Join(dir, "publish"))
+	if err != nil {
+		t.Fatalf("failed to create publish policy: %v", err)
+	}
+	orgReader, err := os.Open(filepath.Join(dir, "deployment/org.json"))
+	if err != nil {
+		t.Fatalf("failed to read org: %v", err)
+	}
+	projectsReader := named_files_reader.FromPaths(dir, []string{
+		filepath.Join(dir, "deployment/servers.json"),
+		filepath.Join(dir, "deployment/tools.json"),
+	})
+	deploymentPolicy, err := deployment.PolicyNew(orgReader, projectsReader)
+	if err != nil {
+		t.Fatalf("failed to create deployment policy: %v", err)
+	}
+	return publishPolicy, deploymentPolicy
+}
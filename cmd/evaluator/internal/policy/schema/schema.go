@@ -0,0 +1,91 @@
+package schema
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s policy schema kind\n" +
+		"       %s policy schema --output-dir path\n" +
+		"\n" +
+		"Prints the JSON Schema of a policy file, where kind is one of publish-org, publish-project,\n" +
+		"deployment-org or deployment-project. --output-dir writes the schemas of all the kinds\n" +
+		"to kind.schema.json files instead, e.g. for editors to provide autocomplete.\n" +
+		"\n" +
+		"Example:\n" +
+		"%s policy schema publish-project > publish-project.schema.json\n" +
+		"\n"
+	utils.Log(msg, cli, cli, cli)
+	os.Exit(1)
+}
+
+// schemas contains the schema generators, keyed by policy kind.
+var schemas = map[string]func() ([]byte, error){
+	"publish-org":        publish.OrgSchema,
+	"publish-project":    publish.ProjectSchema,
+	"deployment-org":     deployment.OrgSchema,
+	"deployment-project": deployment.ProjectSchema,
+}
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	outputDir := fs.String("output-dir", "", "directory to write the schemas to")
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *outputDir != "" {
+		if len(args) != 0 {
+			usage(cli)
+		}
+		return Write(*outputDir)
+	}
+	if len(args) != 1 {
+		usage(cli)
+	}
+	generate, exists := schemas[args[0]]
+	if !exists {
+		return fmt.Errorf("invalid kind (%q). Must be one of %q", args[0], Kinds())
+	}
+	content, err := generate()
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(content))
+	return nil
+}
+
+// Kinds returns the policy kinds, sorted.
+func Kinds() []string {
+	kinds := make([]string, 0, len(schemas))
+	for kind := range schemas {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Write writes the schemas of all the policy kinds to a directory.
+func Write(dir string) error {
+	for _, kind := range Kinds() {
+		content, err := schemas[kind]()
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, kind+".schema.json")
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return fmt.Errorf("failed to write %q: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Write(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := Write(dir); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("failed to read file: %v", err)
+		}
+		var schema struct {
+			Schema string `json:"$schema"`
+			Title  string `json:"title"`
+		}
+		if err := json.Unmarshal(content, &schema); err != nil {
+			t.Fatalf("failed to unmarshal %q: %v", entry.Name(), err)
+		}
+		if schema.Schema == "" || schema.Title == "" {
+			t.Fatalf("unexpected schema for %q: %+v", entry.Name(), schema)
+		}
+	}
+	expected := []string{
+		"deployment-org.schema.json",
+		"deployment-project.schema.json",
+		"publish-org.schema.json",
+		"publish-project.schema.json",
+	}
+	if diff := cmp.Diff(expected, names); diff != "" {
+		t.Fatalf("unexpected files (-want +got): \n%s", diff)
+	}
+}
@@ -5,33 +5,127 @@ import (
 	"fmt"
 
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 	"github.com/slsa-framework/slsa-verifier/v2/options"
 	"github.com/slsa-framework/slsa-verifier/v2/verifiers"
 )
 
 type buildVerifier struct {
+	image             *utils.Image
+	indexVerification string
+	// verified contains the results for the immutable images already verified,
+	// so that the provenance of an index is verified once for all its platforms.
+	// Results are only valid for the builder ID and source URI they were verified
+	// against, so they are part of the key.
+	verified map[verificationKey]publish.BuildVerificationResult
 }
 
-func newBuildVerifier() *buildVerifier {
-	return &buildVerifier{}
+type verificationKey struct {
+	immutableImage string
+	builderID      string
+	sourceURI      string
 }
 
-func (v *buildVerifier) VerifyBuildAttestation(digests intoto.DigestSet, imageName, builderID, sourceURI string) error {
+func newBuildVerifier(image *utils.Image, indexVerification string) *buildVerifier {
+	return &buildVerifier{
+		image:             image,
+		indexVerification: indexVerification,
+		verified:          make(map[verificationKey]publish.BuildVerificationResult),
+	}
+}
+
+// targets returns the digests whose provenance must be verified for
+// the subject's digests, according to the index verification mode.
+func (v *buildVerifier) targets(digests intoto.DigestSet) ([]intoto.DigestSet, error) {
+	if v.image == nil || !v.image.IsIndex() {
+		return []intoto.DigestSet{digests}, nil
+	}
+	indexDigests, isPlatform := v.image.IndexDigests(digests)
+	switch v.indexVerification {
+	case utils.IndexVerificationIndex:
+		// Platform manifests are covered by the provenance of their index.
+		if isPlatform {
+			return []intoto.DigestSet{indexDigests}, nil
+		}
+		return []intoto.DigestSet{digests}, nil
+	case utils.IndexVerificationManifests:
+		// The index is covered by the provenance of all its platform manifests.
+		if isPlatform {
+			return []intoto.DigestSet{digests}, nil
+		}
+		var targets []intoto.DigestSet
+		for i := range v.image.Platforms {
+			targets = append(targets, v.image.Platforms[i].Digests)
+		}
+		return targets, nil
+	case utils.IndexVerificationAll:
+		return []intoto.DigestSet{digests}, nil
+	}
+	return nil, fmt.Errorf("invalid index verification (%q)", v.indexVerification)
+}
+
+// VerifyBuildAttestation verifies the provenance of the targets for the digests.
+// If there are several targets, it returns the result common to all of them.
+func (v *buildVerifier) VerifyBuildAttestation(digests intoto.DigestSet, imageName, builderID, sourceURI string) (publish.BuildVerificationResult, error) {
+	targets, err := v.targets(digests)
+	if err != nil {
+		return publish.BuildVerificationResult{}, fmt.Errorf("VerifyBuildAttestation: %w", err)
+	}
+	var result publish.BuildVerificationResult
+	for i, target := range targets {
+		immutableImage, err := utils.ImmutableImage(imageName, target)
+		if err != nil {
+			return publish.BuildVerificationResult{}, fmt.Errorf("VerifyBuildAttestation: %w", err)
+		}
+		key := verificationKey{
+			immutableImage: immutableImage,
+			builderID:      builderID,
+			sourceURI:      sourceURI,
+		}
+		targetResult, verified := v.verified[key]
+		if !verified {
+			targetResult, err = verifyImage(target, imageName, builderID, sourceURI)
+			if err != nil {
+				return publish.BuildVerificationResult{}, err
+			}
+			v.verified[key] = targetResult
+		}
+		if i == 0 {
+			result = targetResult
+		} else {
+			result = mergeResults(result, targetResult)
+		}
+	}
+	return result, nil
+}
+
+func verifyImage(digests intoto.DigestSet, imageName, builderID, sourceURI string) (publish.BuildVerificationResult, error) {
+	// NOTE: slsa-verifier only verifies sha256 image digests.
+	_, digest, err := digests.Select(intoto.AlgorithmSha256)
+	if err != nil {
+		return publish.BuildVerificationResult{}, fmt.Errorf("VerifyBuildAttestation: %w", err)
+	}
 	provenanceOpts := &options.ProvenanceOpts{
 		ExpectedSourceURI: sourceURI,
-		ExpectedDigest:    digests["sha256"],
+		ExpectedDigest:    digest,
 	}
 
 	builderOpts := &options.BuilderOpts{
 		ExpectedID: &builderID,
 	}
 	// NOTE: the API expects an immutable image.
-	immutableImage := utils.ImmutableImage(imageName, digests)
-	_, fullBuilderID, err := verifiers.VerifyImage(context.Background(), immutableImage, nil, provenanceOpts, builderOpts)
+	immutableImage := fmt.Sprintf("%v@%v:%v", imageName, intoto.AlgorithmSha256, digest)
+	provenanceBytes, fullBuilderID, err := verifiers.VerifyImage(context.Background(), immutableImage, nil, provenanceOpts, builderOpts)
+	if err != nil {
+		return publish.BuildVerificationResult{}, fmt.Errorf("VerifyBuildAttestation: %w", err)
+	}
+	utils.Log("Image (%q) verified with builder ID (%q) and sourceURI (%q)\n", immutableImage, fullBuilderID.String(), sourceURI)
+	result, err := parseProvenance(provenanceBytes)
 	if err != nil {
-		return fmt.Errorf("VerifyBuildAttestation: %w", err)
+		return publish.BuildVerificationResult{}, fmt.Errorf("VerifyBuildAttestation: %w", err)
 	}
-	utils.Log("Image (%q) verified with builder ID (%q) and sourceURI (%q)\n", imageName, fullBuilderID.String(), sourceURI)
-	return nil
+	// NOTE: the builder ID verified by slsa-verifier takes precedence.
+	result.Provenance.BuilderID = fullBuilderID.String()
+	return result, nil
 }
@@ -1,9 +1,9 @@
 package evaluate
 
 import (
+	"flag"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/publish/validate"
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
@@ -15,7 +15,10 @@ import (
 
 func usage(cli string) {
 	msg := "" +
-		"Usage: %s publish evaluate orgPath projectsPath packageName [optional:environment]\n" +
+		"Usage: %s publish evaluate orgPath projectsPath packageName [optional:environment] [--index-verification index|manifests|all]\n" +
+		"\n" +
+		"For an image index, --index-verification selects whose provenance is verified: the index (default),\n" +
+		"each platform manifest, or all of them. The attestation covers the index and all its platform manifests.\n" +
 		"\n" +
 		"Example:\n" +
 		"%s publish evaluate ./path/to/policy/org ./path/to/policy/projects slsa-framework/echo-server@sha256:xxxx prod\n" +
@@ -25,13 +28,22 @@ func usage(cli string) {
 }
 
 func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("evaluate", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	indexVerification := fs.String("index-verification", utils.IndexVerificationIndex, "provenance to verify for an image index")
+	args, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
 	// Argument count is 3 or 4.
 	if len(args) < 3 || len(args) > 4 {
 		usage(cli)
 	}
+	if err := utils.ValidateIndexVerification(*indexVerification); err != nil {
+		return err
+	}
 	// Extract inputs.
-	orgPath := args[0]
-	projectsPath, err := utils.ReadFiles(args[1], orgPath)
+	pol, err := PolicyNew(args[0], args[1])
 	if err != nil {
 		return err
 	}
@@ -45,46 +57,78 @@ func Run(cli string, args []string) error {
 		env = new(string)
 		*env = args[3]
 	}
-	digestsArr := strings.Split(digest, ":")
-	if len(digestsArr) != 2 {
-		return fmt.Errorf("invalid digest (%q)", digest)
+	digests, err := intoto.DigestSetFromString(digest)
+	if err != nil {
+		return err
+	}
+
+	// Evaluate the policy and create an attestation.
+	att, image, err := Evaluate(pol, imageURI, digests, env, *indexVerification)
+	if err != nil {
+		return err
+	}
+	attBytes, err := att.ToBytes()
+	if err != nil {
+		return fmt.Errorf("failed to get attestation bytes: %w\n", err)
+	}
+	fmt.Println(string(attBytes))
+
+	// NOTE: the attestation is attached to the index and all its platform manifests,
+	// so that it is found regardless of which digest is pulled.
+	immutableImages, err := image.ImmutableImages()
+	if err != nil {
+		return err
+	}
+	return crypto.Sign(att, immutableImages...)
+}
+
+// PolicyNew creates a policy from the org policy path and the projects directory.
+func PolicyNew(orgPath, projectsDir string) (*publish.Policy, error) {
+	projectsPath, err := utils.ReadFiles(projectsDir, orgPath)
+	if err != nil {
+		return nil, err
 	}
-	// Create a policy.
 	projectsReader := files_reader.FromPaths(projectsPath)
 	organizationReader, err := os.Open(orgPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read org path: %w", err)
+	}
 	pol, err := publish.PolicyNew(organizationReader, projectsReader, &utils.PackageHelper{}, publish.SetValidator(&validate.PolicyValidator{}))
 	if err != nil {
-		return fmt.Errorf("failed to create policy: %w", err)
+		return nil, fmt.Errorf("failed to create policy: %w", err)
 	}
+	return pol, nil
+}
 
-	// Evaluate the policy.
+// Evaluate evaluates the policy for an image and returns a publish attestation
+// along with the resolved image. It is safe to call concurrently.
+func Evaluate(pol *publish.Policy, imageURI string, digests intoto.DigestSet, env *string,
+	indexVerification string) (*publish.Creation, *utils.Image, error) {
+	image, err := utils.ResolveImage(imageURI, digests)
+	if err != nil {
+		return nil, nil, err
+	}
 	opts := publish.AttestationVerificationOption{
-		Verifier: newBuildVerifier(),
+		Verifier: newBuildVerifier(image, indexVerification),
 	}
 	reqOpts := publish.RequestOption{
 		Environment: env,
 	}
-	digests := intoto.DigestSet{
-		digestsArr[0]: digestsArr[1],
-	}
 	// NOTE: imageURI must be the same as set in the policy's package name.
-	result := pol.Evaluate(digests, imageURI, reqOpts, opts)
+	result := pol.EvaluateSubjects(image.Subjects(), imageURI, reqOpts, opts)
 	if result.Error() != nil {
-		return result.Error()
+		return nil, nil, result.Error()
+	}
+	for _, verification := range result.BuildVerifications() {
+		utils.Log("Image (%q) verified with builder (%q)\n", imageURI, verification.BuilderName)
 	}
 
-	// Create a publish attestation and sign it.
+	// Create a publish attestation.
 	// TODO(#3): do not attach the attestation, so that caller can do it however they want.
 	// TODO(#2): add policy.
 	att, err := result.AttestationNew()
 	if err != nil {
-		return fmt.Errorf("failed to create attestation: %w", err)
+		return nil, nil, fmt.Errorf("failed to create attestation: %w", err)
 	}
-	attBytes, err := att.ToBytes()
-	if err != nil {
-		return fmt.Errorf("failed to get attestation bytes: %w\n", err)
-	}
-	fmt.Println(string(attBytes))
-
-	return crypto.Sign(att, utils.ImmutableImage(imageURI, digests))
+	return att, image, nil
 }
@@ -0,0 +1,165 @@
+package evaluate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
+
+const (
+	provenanceV02 = "https://slsa.dev/provenance/v0.2"
+	provenanceV1  = "https://slsa.dev/provenance/v1"
+)
+
+// provenanceParsers parses the SLSA provenance predicates
+// into build verification results.
+var provenanceParsers = newProvenanceParsers()
+
+func newProvenanceParsers() *intoto.PredicateRegistry {
+	registry := intoto.PredicateRegistryNew()
+	// NOTE: registration only fails for duplicate or empty types.
+	if err := registry.Register(provenanceV02, parseProvenanceV02); err != nil {
+		panic(err)
+	}
+	if err := registry.Register(provenanceV1, parseProvenanceV1); err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+type provenanceV02Predicate struct {
+	Builder struct {
+		ID string `json:"id"`
+	} `json:"builder"`
+	BuildType  string `json:"buildType"`
+	Invocation struct {
+		ConfigSource struct {
+			URI        string            `json:"uri"`
+			Digest     map[string]string `json:"digest"`
+			EntryPoint string            `json:"entryPoint"`
+		} `json:"configSource"`
+	} `json:"invocation"`
+	Metadata struct {
+		BuildInvocationID string `json:"buildInvocationId"`
+	} `json:"metadata"`
+	Materials []intoto.ResourceDescriptor `json:"materials"`
+}
+
+func parseProvenanceV02(content json.RawMessage) (interface{}, error) {
+	var predicate provenanceV02Predicate
+	if err := json.Unmarshal(content, &predicate); err != nil {
+		return nil, err
+	}
+	sourceURI, sourceRef := parseSourceURI(predicate.Invocation.ConfigSource.URI)
+	return publish.BuildVerificationResult{
+		Provenance: publish.BuildProvenance{
+			BuilderID:    predicate.Builder.ID,
+			SourceURI:    sourceURI,
+			SourceCommit: predicate.Invocation.ConfigSource.Digest["sha1"],
+			SourceRef:    sourceRef,
+			BuildType:    predicate.BuildType,
+			InvocationID: predicate.Metadata.BuildInvocationID,
+			WorkflowPath: predicate.Invocation.ConfigSource.EntryPoint,
+		},
+		Materials: predicate.Materials,
+	}, nil
+}
+
+type provenanceV1Predicate struct {
+	BuildDefinition struct {
+		BuildType          string `json:"buildType"`
+		ExternalParameters struct {
+			Workflow struct {
+				Path string `json:"path"`
+			} `json:"workflow"`
+		} `json:"externalParameters"`
+		ResolvedDependencies []intoto.ResourceDescriptor `json:"resolvedDependencies"`
+	} `json:"buildDefinition"`
+	RunDetails struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		Metadata struct {
+			InvocationID string `json:"invocationId"`
+		} `json:"metadata"`
+	} `json:"runDetails"`
+}
+
+func parseProvenanceV1(content json.RawMessage) (interface{}, error) {
+	var predicate provenanceV1Predicate
+	if err := json.Unmarshal(content, &predicate); err != nil {
+		return nil, err
+	}
+	result := publish.BuildVerificationResult{
+		Provenance: publish.BuildProvenance{
+			BuilderID:    predicate.RunDetails.Builder.ID,
+			BuildType:    predicate.BuildDefinition.BuildType,
+			InvocationID: predicate.RunDetails.Metadata.InvocationID,
+			WorkflowPath: predicate.BuildDefinition.ExternalParameters.Workflow.Path,
+		},
+		Materials: predicate.BuildDefinition.ResolvedDependencies,
+	}
+	// NOTE: builders record the source as the first resolved dependency.
+	if len(predicate.BuildDefinition.ResolvedDependencies) > 0 {
+		source := predicate.BuildDefinition.ResolvedDependencies[0]
+		provenance := &result.Provenance
+		provenance.SourceURI, provenance.SourceRef = parseSourceURI(source.URI)
+		provenance.SourceCommit = source.Digest[intoto.AlgorithmGitCommit]
+		if provenance.SourceCommit == "" {
+			provenance.SourceCommit = source.Digest["sha1"]
+		}
+	}
+	return result, nil
+}
+
+// parseSourceURI splits a source URI of the form git+https://github.com/org/repo@refs/heads/main
+// into the repository URI and the ref.
+func parseSourceURI(uri string) (string, string) {
+	uri = strings.TrimPrefix(uri, "git+")
+	repository, ref, _ := strings.Cut(uri, "@")
+	return repository, ref
+}
+
+// parseProvenance returns the verification result for a verified provenance statement.
+func parseProvenance(content []byte) (publish.BuildVerificationResult, error) {
+	statement, err := intoto.StatementFromBytes(content)
+	if err != nil {
+		return publish.BuildVerificationResult{}, fmt.Errorf("failed to parse provenance: %w", err)
+	}
+	predicate, err := provenanceParsers.Parse(statement)
+	if err != nil {
+		return publish.BuildVerificationResult{}, fmt.Errorf("failed to parse provenance: %w", err)
+	}
+	result := predicate.(publish.BuildVerificationResult)
+	result.PredicateType = statement.PredicateType
+	result.Predicate = statement.Predicate
+	result.AttestationDigests = utils.AttestationDigests(content)
+	return result, nil
+}
+
+// mergeResults returns the verification result common to both results.
+func mergeResults(a, b publish.BuildVerificationResult) publish.BuildVerificationResult {
+	result := publish.BuildVerificationResult{
+		Provenance: a.Provenance.Intersect(b.Provenance),
+	}
+	if reflect.DeepEqual(a.Materials, b.Materials) {
+		result.Materials = a.Materials
+	}
+	if a.PredicateType == b.PredicateType {
+		result.PredicateType = a.PredicateType
+	}
+	if bytes.Equal(a.Predicate, b.Predicate) {
+		result.Predicate = a.Predicate
+	}
+	if maps.Equal(a.AttestationDigests, b.AttestationDigests) {
+		result.AttestationDigests = a.AttestationDigests
+	}
+	return result
+}
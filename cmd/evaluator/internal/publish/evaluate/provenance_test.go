@@ -0,0 +1,113 @@
+package evaluate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
+
+func Test_parseProvenance(t *testing.T) {
+	t.Parallel()
+
+	subject := `"subject":[{"name":"image","digest":{"sha256":"5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51"}}]`
+	tests := []struct {
+		name          string
+		content       string
+		provenance    publish.BuildProvenance
+		predicateType string
+		materials     []intoto.ResourceDescriptor
+		expected      error
+	}{
+		{
+			name: "slsa v0.2",
+			content: `{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://slsa.dev/provenance/v0.2",` + subject + `,` +
+				`"predicate":{"builder":{"id":"https://github.com/builder@v1"},"buildType":"https://github.com/build-type",` +
+				`"invocation":{"configSource":{"uri":"git+https://github.com/org/repo@refs/heads/main","digest":{"sha1":"a3623e630f9d01bdda426723ca7ec17a8146f25c"},"entryPoint":".github/workflows/release.yml"}},` +
+				`"metadata":{"buildInvocationId":"123-1"},"materials":[{"uri":"git+https://github.com/org/repo@refs/heads/main","digest":{"sha1":"a3623e630f9d01bdda426723ca7ec17a8146f25c"}}]}}`,
+			provenance: publish.BuildProvenance{
+				BuilderID:    "https://github.com/builder@v1",
+				SourceURI:    "https://github.com/org/repo",
+				SourceCommit: "a3623e630f9d01bdda426723ca7ec17a8146f25c",
+				SourceRef:    "refs/heads/main",
+				BuildType:    "https://github.com/build-type",
+				InvocationID: "123-1",
+				WorkflowPath: ".github/workflows/release.yml",
+			},
+			predicateType: "https://slsa.dev/provenance/v0.2",
+			materials: []intoto.ResourceDescriptor{
+				{
+					URI:    "git+https://github.com/org/repo@refs/heads/main",
+					Digest: intoto.DigestSet{"sha1": "a3623e630f9d01bdda426723ca7ec17a8146f25c"},
+				},
+			},
+		},
+		{
+			name: "slsa v1",
+			content: `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://slsa.dev/provenance/v1",` + subject + `,` +
+				`"predicate":{"buildDefinition":{"buildType":"https://github.com/build-type","externalParameters":{"workflow":{"path":".github/workflows/release.yml"}},` +
+				`"resolvedDependencies":[{"uri":"git+https://github.com/org/repo@refs/tags/v1.2.3","digest":{"gitCommit":"a3623e630f9d01bdda426723ca7ec17a8146f25c"}}]},` +
+				`"runDetails":{"builder":{"id":"https://github.com/builder@v2"},"metadata":{"invocationId":"123-2"}}}}`,
+			provenance: publish.BuildProvenance{
+				BuilderID:    "https://github.com/builder@v2",
+				SourceURI:    "https://github.com/org/repo",
+				SourceCommit: "a3623e630f9d01bdda426723ca7ec17a8146f25c",
+				SourceRef:    "refs/tags/v1.2.3",
+				BuildType:    "https://github.com/build-type",
+				InvocationID: "123-2",
+				WorkflowPath: ".github/workflows/release.yml",
+			},
+			predicateType: "https://slsa.dev/provenance/v1",
+			materials: []intoto.ResourceDescriptor{
+				{
+					URI:    "git+https://github.com/org/repo@refs/tags/v1.2.3",
+					Digest: intoto.DigestSet{"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c"},
+				},
+			},
+		},
+		{
+			name:     "unsupported predicate type",
+			content:  `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://slsa.dev/provenance/v2",` + subject + `,"predicate":{}}`,
+			expected: errs.ErrorNotFound,
+		},
+		{
+			name:     "invalid predicate",
+			content:  `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://slsa.dev/provenance/v1",` + subject + `,"predicate":{"runDetails":[]}}`,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "invalid statement",
+			content:  `{"_type":`,
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := parseProvenance([]byte(tt.content))
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.provenance, result.Provenance); diff != "" {
+				t.Fatalf("unexpected provenance (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.predicateType, result.PredicateType); diff != "" {
+				t.Fatalf("unexpected predicate type (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.materials, result.Materials); diff != "" {
+				t.Fatalf("unexpected materials (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(utils.AttestationDigests([]byte(tt.content)), result.AttestationDigests); diff != "" {
+				t.Fatalf("unexpected attestation digests (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package verify
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils/crypto"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
+
+func usage(cli string) {
+	msg := "" +
+		"Usage: %s publish verify packageURI (--publisher-id id | --publisher-id-regex regex) [--env environment] [--version version] [--min-level level] [--builder-id id] [--source-uri uri] [--attestation path [--insecure-skip-signature]]\n" +
+		"\n" +
+		"Example:\n" +
+		"%s publish verify slsa-framework/echo-server@sha256:xxxx --env prod --min-level 3 --publisher-id https://github.com/org/repo/.github/workflows/image-publisher.yml@refs/heads/main\n" +
+		"\n"
+	fmt.Fprintf(os.Stderr, msg, cli, cli)
+	os.Exit(1)
+}
+
+func Run(cli string, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.Usage = func() { usage(cli) }
+	publisherID := fs.String("publisher-id", "", "identity of the publisher that signed the attestation")
+	publisherIDRegex := fs.String("publisher-id-regex", "", "regex for the identity of the publisher that signed the attestation")
+	env := fs.String("env", "", "environment the package must be published to")
+	version := fs.String("version", "", "version the package must be published with")
+	minLevel := fs.Int("min-level", 0, "minimum SLSA build level the package must be published with")
+	builderID := fs.String("builder-id", "", "builder ID the package must be built by")
+	sourceURI := fs.String("source-uri", "", "source repository the package must be built from")
+	attestationPath := fs.String("attestation", "", "path to an attestation bundle to use instead of fetching it from the registry")
+	insecureSkipSignature := fs.Bool("insecure-skip-signature", false, "do not verify the signature of the --attestation file (insecure)")
+	positional, err := utils.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		usage(cli)
+	}
+	// Extract inputs.
+	imageURI, digest, err := utils.ParseImageReference(positional[0])
+	if err != nil {
+		return err
+	}
+	digests, err := intoto.DigestSetFromString(digest)
+	if err != nil {
+		return err
+	}
+
+	// Retrieve the attestation.
+	immutableImage, err := utils.ImmutableImage(imageURI, digests)
+	if err != nil {
+		return err
+	}
+	attBytes, err := crypto.ReadAttestation(immutableImage, publish.PredicateType(),
+		*publisherID, *publisherIDRegex, *attestationPath, *insecureSkipSignature)
+	if err != nil {
+		return err
+	}
+
+	// Create the verification options. Only the flags set by the caller are verified.
+	var opts []publish.VerificationOption
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "env":
+			opts = append(opts, publish.IsPackageEnvironment(*env))
+		case "version":
+			opts = append(opts, publish.IsPackageVersion(*version))
+		case "min-level":
+			opts = append(opts, publish.IsSlsaBuildLevelOrAbove(*minLevel))
+		case "builder-id":
+			opts = append(opts, publish.IsBuilderID(*builderID))
+		case "source-uri":
+			opts = append(opts, publish.IsSourceURI(*sourceURI))
+		}
+	})
+
+	// Verify the attestation content.
+	verification, err := publish.VerificationNew(io.NopCloser(bytes.NewReader(attBytes)), &utils.PackageHelper{})
+	if err != nil {
+		return fmt.Errorf("failed to create verifier for image (%q): %w", imageURI, err)
+	}
+	// NOTE: imageURI must be the same as set in the policy's package name.
+	if err := verification.Verify(digests, imageURI, opts...); err != nil {
+		return fmt.Errorf("failed to verify image (%q): %w", imageURI, err)
+	}
+	utils.Log("Image (%q) verified\n", immutableImage)
+	return nil
+}
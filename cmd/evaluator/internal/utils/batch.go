@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ManifestEntry defines an artifact to evaluate in batch mode.
+type ManifestEntry struct {
+	// Package is the package name, e.g. docker.io/org/image.
+	Package string `json:"package"`
+	// Digest is the package digest, e.g. sha256:xxxx.
+	Digest string `json:"digest"`
+	// Environment is used for publish policy evaluation.
+	Environment string `json:"environment,omitempty"`
+	// PolicyID is used for deployment policy evaluation.
+	PolicyID string `json:"policy_id,omitempty"`
+}
+
+// ImageReference returns the immutable reference of the entry.
+func (e ManifestEntry) ImageReference() string {
+	return e.Package + "@" + e.Digest
+}
+
+// BatchResult defines the result of evaluating a manifest entry.
+type BatchResult struct {
+	ManifestEntry
+	Attestation json.RawMessage `json:"attestation,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// ReadManifest reads a JSON or YAML list of manifest entries.
+func ReadManifest(path string) ([]ManifestEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest (%q): %w", path, err)
+	}
+	// NOTE: JSON is a subset of YAML, so this handles both formats.
+	var entries []ManifestEntry
+	if err := yaml.UnmarshalStrict(content, &entries); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal manifest (%q): %w", errorManifest, path, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: manifest (%q) is empty", errorManifest, path)
+	}
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Package == "" || entry.Digest == "" {
+			return nil, fmt.Errorf("%w: entry #%d has an empty package or digest", errorManifest, i)
+		}
+	}
+	return entries, nil
+}
+
+// RunParallel calls fn for every index in [0, count) with at most
+// parallelism concurrent calls.
+func RunParallel(count, parallelism int, fn func(i int)) {
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// ReportBatchResults prints the results and returns an error if
+// the evaluation of any entry failed.
+func ReportBatchResults(results []BatchResult) error {
+	content, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal results: %w", err)
+	}
+	fmt.Println(string(content))
+	var failed int
+	for i := range results {
+		result := &results[i]
+		if result.Error != "" {
+			Log("Failed to evaluate (%q): %s\n", result.ImageReference(), result.Error)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d/%d artifacts failed evaluation", failed, len(results))
+	}
+	Log("%d/%d artifacts passed evaluation\n", len(results), len(results))
+	return nil
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_ReadManifest(t *testing.T) {
+	t.Parallel()
+	digest := "sha256:f8bc336da3030b431b985652438661f17c0dc8eb9ab75a998c86e4b1387ee501"
+	tests := []struct {
+		name     string
+		content  string
+		entries  []ManifestEntry
+		expected error
+	}{
+		{
+			name: "json manifest",
+			content: `[{"package":"docker.io/repo/image","digest":"` + digest + `","environment":"prod"},
+				{"package":"docker.io/repo/image2","digest":"` + digest + `"}]`,
+			entries: []ManifestEntry{
+				{Package: "docker.io/repo/image", Digest: digest, Environment: "prod"},
+				{Package: "docker.io/repo/image2", Digest: digest},
+			},
+		},
+		{
+			name: "yaml manifest",
+			content: "- package: docker.io/repo/image\n" +
+				"  digest: " + digest + "\n" +
+				"  policy_id: servers-prod.json\n",
+			entries: []ManifestEntry{
+				{Package: "docker.io/repo/image", Digest: digest, PolicyID: "servers-prod.json"},
+			},
+		},
+		{
+			name:     "empty manifest",
+			content:  "[]",
+			expected: errorManifest,
+		},
+		{
+			name:     "unknown field",
+			content:  `[{"package":"docker.io/repo/image","digest":"` + digest + `","env":"prod"}]`,
+			expected: errorManifest,
+		},
+		{
+			name:     "empty digest",
+			content:  `[{"package":"docker.io/repo/image"}]`,
+			expected: errorManifest,
+		},
+		{
+			name:     "empty package",
+			content:  `[{"digest":"` + digest + `"}]`,
+			expected: errorManifest,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "manifest")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			entries, err := ReadManifest(path)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.entries, entries); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_RunParallel(t *testing.T) {
+	t.Parallel()
+	var running, maxRunning, calls int32
+	done := make([]bool, 20)
+	RunParallel(len(done), 3, func(i int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		done[i] = true
+		atomic.AddInt32(&calls, 1)
+		atomic.AddInt32(&running, -1)
+	})
+	if calls != int32(len(done)) {
+		t.Fatalf("unexpected calls: %d", calls)
+	}
+	if maxRunning > 3 {
+		t.Fatalf("unexpected parallelism: %d", maxRunning)
+	}
+	for i := range done {
+		if !done[i] {
+			t.Fatalf("index %d not called", i)
+		}
+	}
+}
@@ -3,25 +3,30 @@ package crypto
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"os"
+	"sync"
 	"time"
 
-	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
-	"github.com/slsa-framework/slsa-policy/pkg/publish"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/rekor"
 	clisign "github.com/sigstore/cosign/v2/cmd/cosign/cli/sign"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/fulcio"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
 	cbundle "github.com/sigstore/cosign/v2/pkg/cosign/bundle"
 	cremote "github.com/sigstore/cosign/v2/pkg/cosign/remote"
+	"github.com/sigstore/cosign/v2/pkg/oci"
 	"github.com/sigstore/cosign/v2/pkg/oci/mutate"
 	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
 	"github.com/sigstore/cosign/v2/pkg/oci/static"
 	cpolicy "github.com/sigstore/cosign/v2/pkg/policy"
 	"github.com/sigstore/cosign/v2/pkg/types"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature/dsse"
 	signatureoptions "github.com/sigstore/sigstore/pkg/signature/options"
 )
@@ -60,38 +65,105 @@ type Attestation interface {
 	PredicateType() string
 }
 
-func Sign(att Attestation, immutableImage string) error {
+// Sign signs the attestation once and attaches it to each of the immutable images,
+// e.g. an image index and its platform manifests.
+func Sign(att Attestation, immutableImages ...string) error {
+	signer := SignerNew()
+	defer signer.Close()
+	return signer.Sign(att, immutableImages...)
+}
+
+// certificateMargin is the minimum remaining validity of the signer's
+// certificate. Fulcio certificates are only valid for a few minutes,
+// so a signer shared by a long batch is renewed before it expires.
+const certificateMargin = 2 * time.Minute
+
+// Signer signs attestations with a keyless signer created once and
+// shared by all the attestations, e.g. those of a batch.
+// It is safe to call concurrently.
+type Signer struct {
+	mu      sync.Mutex
+	current *clisign.SignerVerifier
+	expiry  time.Time
+	// err is the error creating the signer, returned to
+	// all the callers instead of retrying.
+	err error
+	// all contains the signers created, closed by Close().
+	all []*clisign.SignerVerifier
+}
+
+// SignerNew creates a signer. The keyless signer is created
+// when the first attestation is signed.
+func SignerNew() *Signer {
+	return &Signer{}
+}
+
+// signerVerifier returns the current keyless signer, and creates
+// a new one if there is none or its certificate is about to expire.
+func (s *Signer) signerVerifier() (*clisign.SignerVerifier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.current != nil && time.Now().Add(certificateMargin).Before(s.expiry) {
+		return s.current, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(30*time.Second))
+	defer cancel()
+	sv, err := clisign.SignerFromKeyOpts(ctx, "", "", ko)
+	if err != nil {
+		s.err = fmt.Errorf("failed to get signer: %w", err)
+		return nil, s.err
+	}
+	s.all = append(s.all, sv)
+	if sv.Cert == nil {
+		s.err = fmt.Errorf("signer cert is nil")
+		return nil, s.err
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(sv.Cert)
+	if err != nil || len(certs) == 0 {
+		s.err = fmt.Errorf("failed to parse signer cert: %v", err)
+		return nil, s.err
+	}
+	s.current = sv
+	s.expiry = certs[0].NotAfter
+	return sv, nil
+}
+
+// Close releases the keyless signers.
+func (s *Signer) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sv := range s.all {
+		sv.Close()
+	}
+	s.all = nil
+	s.current = nil
+}
+
+// Sign signs the attestation once and attaches it to each of the immutable images,
+// e.g. an image index and its platform manifests.
+func (s *Signer) Sign(att Attestation, immutableImages ...string) error {
+	if len(immutableImages) == 0 {
+		return fmt.Errorf("no image to attach the attestation to")
+	}
 	// Retrieve the attestation bytes.
 	attBytes, err := att.ToBytes()
 	if err != nil {
 		return fmt.Errorf("failed to get attestation bytes: %w", err)
 	}
 
+	// Get the signer.
+	sv, err := s.signerVerifier()
+	if err != nil {
+		return err
+	}
+
 	// Set up the context.
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(30*time.Second))
 	defer cancel()
 
-	// registryOpts := options.RegistryOptions{}
-	// ociremoteOpts, err := registryOpts.ClientOpts(ctx)
-	// if err != nil {
-	// 	return err
-	// }
-	// ref, err := name.ParseReference(immutableImage)
-	// if err != nil {
-	// 	return fmt.Errorf("parsing reference: %w", err)
-	// }
-	// digest, err := ociremote.ResolveDigest(ref, ociremoteOpts...)
-	// if err != nil {
-	// 	return err
-	// }
-
-	// Create the signer.
-	sv, err := clisign.SignerFromKeyOpts(ctx, "", "", ko)
-	if err != nil {
-		return fmt.Errorf("failed to get signer: %w", err)
-	}
-	defer sv.Close()
-
 	// Create the DSSE signer wrapper.
 	wrapped := dsse.WrapSigner(sv, types.IntotoPayloadType)
 	signedPayload, err := wrapped.SignMessage(bytes.NewReader(attBytes), signatureoptions.WithContext(ctx))
@@ -104,7 +176,12 @@ func Sign(att Attestation, immutableImage string) error {
 		return err
 	}
 
-	return attach(immutableImage, att, bundle, signedPayload, sv)
+	for _, immutableImage := range immutableImages {
+		if err := attach(immutableImage, att, bundle, signedPayload, sv); err != nil {
+			return fmt.Errorf("failed to attach attestation to (%q): %w", immutableImage, err)
+		}
+	}
+	return nil
 }
 
 func attach(immutableImage string, att Attestation, bundle *cbundle.RekorBundle, signedPayload []byte, sv *clisign.SignerVerifier) error {
@@ -133,7 +210,7 @@ func attach(immutableImage string, att Attestation, bundle *cbundle.RekorBundle,
 	if err != nil {
 		return fmt.Errorf("failed to create new digest: %w", err)
 	}
-	fmt.Printf("digest: %T: %v", digest, digest)
+	utils.Log("digest: %T: %v\n", digest, digest)
 	// We don't actually need to access the remote entity to attach things to it
 	// so we use a placeholder here.
 	se := ociremote.SignedUnknown(digest, ociremoteOpts...)
@@ -178,15 +255,49 @@ func getIdentity(publishrID, publishrIDRegex string) (*cosign.Identity, error) {
 	}, nil
 }
 
-// VerifySignature verifies the signature of an attestation.
-func VerifySignature(immutableImage string, publishrID, publishrIDRegex string) (string, []byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(30*time.Second))
-	defer cancel()
+// ReadAttestation returns the attestation of type predicateType for an image.
+// If path is set, the attestation is read from a bundle file. Otherwise, it is
+// fetched from the registry. In both cases, its signature is verified, unless
+// insecureSkipSignature is set, which is only allowed for a local file.
+func ReadAttestation(immutableImage, predicateType, signerID, signerIDRegex, path string, insecureSkipSignature bool) ([]byte, error) {
+	if path == "" {
+		if insecureSkipSignature {
+			return nil, fmt.Errorf("signature verification can only be skipped for a local attestation")
+		}
+		_, attBytes, err := VerifySignature(immutableImage, predicateType, signerID, signerIDRegex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify image (%q) with signer ID (%q) signer ID regex (%q): %w",
+				immutableImage, signerID, signerIDRegex, err)
+		}
+		return attBytes, nil
+	}
+	if !insecureSkipSignature {
+		attBytes, err := VerifyLocalSignature(path, immutableImage, predicateType, signerID, signerIDRegex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify attestation (%q) for image (%q) with signer ID (%q) signer ID regex (%q): %w",
+				path, immutableImage, signerID, signerIDRegex, err)
+		}
+		return attBytes, nil
+	}
+	// NOTE: the signature is not verified, so there is no identity to verify.
+	if signerID != "" || signerIDRegex != "" {
+		return nil, fmt.Errorf("signer ID (%q) and signer ID regex (%q) cannot be used when the signature is not verified",
+			signerID, signerIDRegex)
+	}
+	attBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attestation (%q): %w", path, err)
+	}
+	utils.Log("WARNING: signature verification skipped for local attestation (%q)\n", path)
+	return attBytes, nil
+}
 
+// checkOpts returns the options to verify a keyless signature by the signer.
+func checkOpts(ctx context.Context, signerID, signerIDRegex string) (*cosign.CheckOpts, error) {
 	var err error
-	identity, err := getIdentity(publishrID, publishrIDRegex)
+	identity, err := getIdentity(signerID, signerIDRegex)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 	co := &cosign.CheckOpts{
 		// TODO: verify this empty option works properly.
@@ -201,29 +312,119 @@ func VerifySignature(immutableImage string, publishrID, publishrIDRegex string)
 	// Set CT log keys.
 	co.CTLogPubKeys, err = cosign.GetCTLogPubs(ctx)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to get ctlog public keys: %w", err)
+		return nil, fmt.Errorf("failed to get ctlog public keys: %w", err)
 	}
 	// Set up rekor client.
 	co.RekorClient, err = rekor.NewClient(ko.RekorURL)
 	if err != nil {
-		return "", nil, fmt.Errorf("failted to create Rekor client: %w", err)
+		return nil, fmt.Errorf("failted to create Rekor client: %w", err)
 	}
 	// This performs an online fetch of the Rekor public keys, but this is needed
 	// for verifying tlog entries (both online and offline).
 	co.RekorPubKeys, err = cosign.GetRekorPubs(ctx)
 	if err != nil {
-		return "", nil, fmt.Errorf("getting Rekor public keys: %w", err)
+		return nil, fmt.Errorf("getting Rekor public keys: %w", err)
 	}
 	// Set up fulcio.
 	// This performs an online fetch of the Fulcio roots. This is needed
 	// for verifying keyless certificates (both online and offline).
 	co.RootCerts, err = fulcio.GetRoots()
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to get Fulcio roots: %w", err)
+		return nil, fmt.Errorf("failed to get Fulcio roots: %w", err)
 	}
 	co.IntermediateCerts, err = fulcio.GetIntermediates()
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to get Fulcio intermediates: %w", err)
+		return nil, fmt.Errorf("failed to get Fulcio intermediates: %w", err)
+	}
+	return co, nil
+}
+
+// VerifyLocalSignature verifies the signature of an attestation of type predicateType
+// stored in a bundle file, as written by `cosign attest-blob --bundle`. The bundle
+// contains the DSSE envelope, the signer's certificate and the transparency log entry.
+// The attestation's subject must contain the image digest.
+func VerifyLocalSignature(path, immutableImage, predicateType, signerID, signerIDRegex string) ([]byte, error) {
+	// NOTE: check the identity before any network access.
+	if err := ValidateIdentity(signerID, signerIDRegex); err != nil {
+		return nil, err
+	}
+	sig, err := readBundle(path)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := name.NewDigest(immutableImage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new digest: %w", err)
+	}
+	hash, err := v1.NewHash(digest.DigestStr())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(30*time.Second))
+	defer cancel()
+
+	co, err := checkOpts(ctx, signerID, signerIDRegex)
+	if err != nil {
+		return nil, err
+	}
+	bundleVerified, err := cosign.VerifyBlobAttestation(ctx, sig, hash, co)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify: %w", err)
+	}
+	if !bundleVerified {
+		return nil, fmt.Errorf("failed to verify bundle")
+	}
+	payload, attPredicateType, err := cpolicy.AttestationToPayloadJSON(ctx, predicateType, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to consumable policy validation: %w", err)
+	}
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("predicate type (%q) is not the expected type (%q)", attPredicateType, predicateType)
+	}
+	return payload, nil
+}
+
+// readBundle reads a bundle file. The bundle must contain the signer's certificate
+// and the transparency log entry, since a keyless signature cannot be verified without them.
+func readBundle(path string) (oci.Signature, error) {
+	b, err := cosign.FetchLocalSignedPayloadFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle (%q): %w", path, err)
+	}
+	if b.Base64Signature == "" {
+		return nil, fmt.Errorf("bundle (%q) has no signature", path)
+	}
+	if b.Cert == "" {
+		return nil, fmt.Errorf("bundle (%q) has no certificate", path)
+	}
+	if b.Bundle == nil {
+		return nil, fmt.Errorf("bundle (%q) has no transparency log entry", path)
+	}
+	envelope, err := base64.StdEncoding.DecodeString(b.Base64Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature of bundle (%q): %w", path, err)
+	}
+	// NOTE: the certificate is either PEM or base64-encoded PEM.
+	certPEM := []byte(b.Cert)
+	if decoded, err := base64.StdEncoding.DecodeString(b.Cert); err == nil {
+		certPEM = decoded
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(certPEM)
+	if err != nil || len(certs) != 1 {
+		return nil, fmt.Errorf("bundle (%q) has an invalid certificate", path)
+	}
+	return static.NewAttestation(envelope, static.WithCertChain(certPEM, nil), static.WithBundle(b.Bundle))
+}
+
+// VerifySignature verifies the signature of an attestation of type predicateType.
+func VerifySignature(immutableImage, predicateType string, signerID, signerIDRegex string) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(30*time.Second))
+	defer cancel()
+
+	co, err := checkOpts(ctx, signerID, signerIDRegex)
+	if err != nil {
+		return "", nil, err
 	}
 	digest, err := name.NewDigest(immutableImage)
 	if err != nil {
@@ -238,7 +439,7 @@ func VerifySignature(immutableImage string, publishrID, publishrIDRegex string)
 	}
 	var errList []error
 	for _, vp := range verified {
-		payload, predicateType, err := cpolicy.AttestationToPayloadJSON(ctx, publish.PredicateType(), vp)
+		payload, attPredicateType, err := cpolicy.AttestationToPayloadJSON(ctx, predicateType, vp)
 		if err != nil {
 			errList = append(errList, fmt.Errorf("failed to convert to consumable policy validation: %w", err))
 			continue
@@ -247,12 +448,12 @@ func VerifySignature(immutableImage string, publishrID, publishrIDRegex string)
 			// This is not the predicate type we're looking for.
 			continue
 		}
-		if publish.PredicateType() != predicateType {
+		if predicateType != attPredicateType {
 			errList = append(errList, fmt.Errorf("internal error. predicate ype (%q) != attestation type (%q)",
-				predicateType, publish.PredicateType()))
+				attPredicateType, predicateType))
 			continue
 		}
-		return publishrID, payload, nil
+		return signerID, payload, nil
 	}
 	return "", nil, fmt.Errorf("failed to verify: %v", errList)
 }
@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ReadAttestation(t *testing.T) {
+	t.Parallel()
+	immutableImage := "ghcr.io/repo/image@sha256:f8bc336da3030b431b985652438661f17c0dc8eb9ab75a998c86e4b1387ee501"
+	statement := `{"_type":"https://in-toto.io/Statement/v1"}`
+	envelope := `{"payloadType":"application/vnd.in-toto+json","payload":"e30=","signatures":[{"sig":"c2ln"}]}`
+	tests := []struct {
+		name                  string
+		content               string
+		noPath                bool
+		signerID              string
+		insecureSkipSignature bool
+		expected              []byte
+		err                   bool
+	}{
+		{
+			name:                  "skip signature without path",
+			noPath:                true,
+			signerID:              "signer_id",
+			insecureSkipSignature: true,
+			err:                   true,
+		},
+		{
+			name:                  "skip signature with signer ID",
+			content:               statement,
+			signerID:              "signer_id",
+			insecureSkipSignature: true,
+			err:                   true,
+		},
+		{
+			name:                  "skip signature",
+			content:               statement,
+			insecureSkipSignature: true,
+			expected:              []byte(statement),
+		},
+		{
+			name:    "no signer ID",
+			content: statement,
+			err:     true,
+		},
+		{
+			name:     "unsigned statement",
+			content:  statement,
+			signerID: "signer_id",
+			err:      true,
+		},
+		{
+			name:     "dsse envelope without certificate",
+			content:  envelope,
+			signerID: "signer_id",
+			err:      true,
+		},
+		{
+			name:     "bundle without certificate",
+			content:  `{"base64Signature":"e30=","rekorBundle":{"SignedEntryTimestamp":"","Payload":{"body":"","integratedTime":0,"logIndex":0,"logID":""}}}`,
+			signerID: "signer_id",
+			err:      true,
+		},
+		{
+			name:     "bundle without transparency log entry",
+			content:  `{"base64Signature":"e30=","cert":"LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tCg=="}`,
+			signerID: "signer_id",
+			err:      true,
+		},
+		{
+			name:     "bundle with invalid certificate",
+			content:  `{"base64Signature":"e30=","cert":"Y2VydA==","rekorBundle":{"SignedEntryTimestamp":"","Payload":{"body":"","integratedTime":0,"logIndex":0,"logID":""}}}`,
+			signerID: "signer_id",
+			err:      true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := ""
+			if !tt.noPath {
+				path = filepath.Join(t.TempDir(), "attestation.json")
+				if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+					t.Fatalf("failed to write file: %v", err)
+				}
+			}
+			attBytes, err := ReadAttestation(immutableImage, "predicate_type", tt.signerID, "", path, tt.insecureSkipSignature)
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, attBytes); diff != "" {
+				t.Fatalf("unexpected attestation (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
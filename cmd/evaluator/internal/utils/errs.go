@@ -5,4 +5,6 @@ import "errors"
 var (
 	errorImageParsing = errors.New("failed to parse image reference")
 	errorPackageName  = errors.New("invalid package name")
+	errorFlag         = errors.New("invalid flag")
+	errorManifest     = errors.New("invalid manifest")
 )
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseFlags parses the flags in args. Unlike flag.FlagSet.Parse(),
+// flags may be interleaved with positional arguments,
+// e.g. `image@sha256:xxx --scope k=v`. It returns the positional arguments.
+func ParseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			// NOTE: the flag package does not wrap errors.
+			return nil, fmt.Errorf("%w: %v", errorFlag, err)
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		// A "--" terminator means all remaining arguments are positional.
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// KeyValueFlag is a repeatable flag of the form key=value.
+type KeyValueFlag map[string]string
+
+func (f KeyValueFlag) String() string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(f))
+	for _, k := range keys {
+		values = append(values, k+"="+f[k])
+	}
+	return strings.Join(values, ",")
+}
+
+func (f KeyValueFlag) Set(value string) error {
+	key, val, found := strings.Cut(value, "=")
+	if !found {
+		return fmt.Errorf("%w: (%q) is not of the form key=value", errorFlag, value)
+	}
+	if key == "" || val == "" {
+		return fmt.Errorf("%w: (%q) has an empty key or value", errorFlag, value)
+	}
+	if _, exists := f[key]; exists {
+		return fmt.Errorf("%w: key (%q) is set more than once", errorFlag, key)
+	}
+	f[key] = val
+	return nil
+}
+
+// ListFlag is a repeatable flag.
+type ListFlag []string
+
+func (f *ListFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *ListFlag) Set(value string) error {
+	if value == "" {
+		return fmt.Errorf("%w: empty value", errorFlag)
+	}
+	*f = append(*f, value)
+	return nil
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_ParseFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		args       []string
+		positional []string
+		scopes     KeyValueFlag
+		id         string
+		expected   error
+	}{
+		{
+			name:       "positional only",
+			args:       []string{"a", "b"},
+			positional: []string{"a", "b"},
+			scopes:     KeyValueFlag{},
+		},
+		{
+			name:       "flags after positional",
+			args:       []string{"a", "--scope", "k1=v1", "--id=id", "--scope=k2=v2"},
+			positional: []string{"a"},
+			scopes:     KeyValueFlag{"k1": "v1", "k2": "v2"},
+			id:         "id",
+		},
+		{
+			name:       "interleaved flags",
+			args:       []string{"--id", "id", "a", "--scope", "k1=v1", "b"},
+			positional: []string{"a", "b"},
+			scopes:     KeyValueFlag{"k1": "v1"},
+			id:         "id",
+		},
+		{
+			name:       "terminator",
+			args:       []string{"a", "--", "--scope", "k1=v1"},
+			positional: []string{"a", "--scope", "k1=v1"},
+			scopes:     KeyValueFlag{},
+		},
+		{
+			name:     "scope without value",
+			args:     []string{"a", "--scope", "k1"},
+			expected: errorFlag,
+		},
+		{
+			name:     "scope with empty key",
+			args:     []string{"a", "--scope", "=v1"},
+			expected: errorFlag,
+		},
+		{
+			name:     "scope set twice",
+			args:     []string{"a", "--scope", "k1=v1", "--scope", "k1=v2"},
+			expected: errorFlag,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scopes := KeyValueFlag{}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(scopes, "scope", "")
+			id := fs.String("id", "", "")
+			positional, err := ParseFlags(fs, tt.args)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.positional, positional); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.scopes, scopes); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.id, *id); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
+
+// Index verification modes select which provenance is verified for
+// an OCI image index.
+const (
+	// IndexVerificationIndex verifies the provenance of the index only.
+	IndexVerificationIndex = "index"
+	// IndexVerificationManifests verifies the provenance of each platform manifest.
+	IndexVerificationManifests = "manifests"
+	// IndexVerificationAll verifies the provenance of the index and of each platform manifest.
+	IndexVerificationAll = "all"
+)
+
+// ValidateIndexVerification validates an index verification mode.
+func ValidateIndexVerification(mode string) error {
+	switch mode {
+	case IndexVerificationIndex, IndexVerificationManifests, IndexVerificationAll:
+		return nil
+	}
+	return fmt.Errorf("%w: invalid index verification (%q). Must be one of %q", errorFlag, mode,
+		[]string{IndexVerificationIndex, IndexVerificationManifests, IndexVerificationAll})
+}
+
+const (
+	buildkitReferenceType       = "vnd.docker.reference.type"
+	buildkitAttestationManifest = "attestation-manifest"
+)
+
+// Image defines an image resolved from its registry.
+type Image struct {
+	// Name is the image name, without identifier.
+	Name string
+	// Digests is the digest of the image manifest or index.
+	Digests intoto.DigestSet
+	// Platforms contains the platform manifests of an image index.
+	// It is empty if the image is not an index.
+	Platforms []intoto.Subject
+}
+
+// IsIndex returns true if the image is an image index.
+func (i *Image) IsIndex() bool {
+	return len(i.Platforms) > 0
+}
+
+// Subjects returns the subjects for the image, i.e. the image and
+// all its platform manifests.
+func (i *Image) Subjects() []intoto.Subject {
+	return append([]intoto.Subject{{Digests: i.Digests}}, i.Platforms...)
+}
+
+// ImmutableImages returns the immutable references of the image and
+// all its platform manifests.
+func (i *Image) ImmutableImages() ([]string, error) {
+	var images []string
+	for _, subject := range i.Subjects() {
+		image, err := ImmutableImage(i.Name, subject.Digests)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// IndexDigests returns the digests of the index, if the digests
+// are those of a platform manifest.
+func (i *Image) IndexDigests(digests intoto.DigestSet) (intoto.DigestSet, bool) {
+	for j := range i.Platforms {
+		if digestsMatch(i.Platforms[j].Digests, digests) {
+			return i.Digests, true
+		}
+	}
+	return nil, false
+}
+
+// digestsMatch returns true if all the digests in ds are present in digests.
+func digestsMatch(ds, digests intoto.DigestSet) bool {
+	for name, value := range ds {
+		if val, exists := digests[name]; !exists || val != value {
+			return false
+		}
+	}
+	return len(ds) > 0
+}
+
+// ResolveImage fetches the image manifest from the registry. If the image
+// is an index, the platform manifests it references are resolved.
+func ResolveImage(imageName string, digests intoto.DigestSet) (*Image, error) {
+	image := &Image{
+		Name:    imageName,
+		Digests: digests,
+	}
+	immutableImage, err := ImmutableImage(imageName, digests)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorImageParsing, err)
+	}
+	ref, err := name.NewDigest(immutableImage)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse image (%q): %w", errorImageParsing, immutableImage, err)
+	}
+	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image (%q): %w", immutableImage, err)
+	}
+	if !desc.MediaType.IsIndex() {
+		return image, nil
+	}
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image index (%q): %w", immutableImage, err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image index manifest (%q): %w", immutableImage, err)
+	}
+	for _, m := range manifest.Manifests {
+		// Skip the attestation manifests created by BuildKit, since they are not runnable images.
+		if m.Annotations[buildkitReferenceType] == buildkitAttestationManifest {
+			continue
+		}
+		subject := intoto.Subject{
+			Digests: intoto.DigestSet{
+				m.Digest.Algorithm: m.Digest.Hex,
+			},
+		}
+		if m.Platform != nil {
+			subject.Name = m.Platform.String()
+		}
+		image.Platforms = append(image.Platforms, subject)
+	}
+	return image, nil
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
+
+func Test_Image(t *testing.T) {
+	t.Parallel()
+	index := intoto.DigestSet{"sha256": "aaaa"}
+	amd64 := intoto.DigestSet{"sha256": "bbbb"}
+	arm64 := intoto.DigestSet{"sha256": "cccc"}
+	tests := []struct {
+		name            string
+		image           Image
+		digests         intoto.DigestSet
+		isIndex         bool
+		subjects        []intoto.Subject
+		immutableImages []string
+		indexDigests    intoto.DigestSet
+		isPlatform      bool
+	}{
+		{
+			name:            "single manifest",
+			image:           Image{Name: "docker.io/repo/image", Digests: index},
+			digests:         index,
+			subjects:        []intoto.Subject{{Digests: index}},
+			immutableImages: []string{"docker.io/repo/image@sha256:aaaa"},
+		},
+		{
+			name: "index digests",
+			image: Image{
+				Name:    "docker.io/repo/image",
+				Digests: index,
+				Platforms: []intoto.Subject{
+					{Name: "linux/amd64", Digests: amd64},
+					{Name: "linux/arm64", Digests: arm64},
+				},
+			},
+			digests: index,
+			isIndex: true,
+			subjects: []intoto.Subject{
+				{Digests: index},
+				{Name: "linux/amd64", Digests: amd64},
+				{Name: "linux/arm64", Digests: arm64},
+			},
+			immutableImages: []string{
+				"docker.io/repo/image@sha256:aaaa",
+				"docker.io/repo/image@sha256:bbbb",
+				"docker.io/repo/image@sha256:cccc",
+			},
+		},
+		{
+			name: "platform digests",
+			image: Image{
+				Name:    "docker.io/repo/image",
+				Digests: index,
+				Platforms: []intoto.Subject{
+					{Name: "linux/amd64", Digests: amd64},
+					{Name: "linux/arm64", Digests: arm64},
+				},
+			},
+			digests: arm64,
+			isIndex: true,
+			subjects: []intoto.Subject{
+				{Digests: index},
+				{Name: "linux/amd64", Digests: amd64},
+				{Name: "linux/arm64", Digests: arm64},
+			},
+			immutableImages: []string{
+				"docker.io/repo/image@sha256:aaaa",
+				"docker.io/repo/image@sha256:bbbb",
+				"docker.io/repo/image@sha256:cccc",
+			},
+			indexDigests: index,
+			isPlatform:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.isIndex, tt.image.IsIndex()); diff != "" {
+				t.Fatalf("unexpected is index (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.subjects, tt.image.Subjects()); diff != "" {
+				t.Fatalf("unexpected subjects (-want +got): \n%s", diff)
+			}
+			immutableImages, err := tt.image.ImmutableImages()
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if diff := cmp.Diff(tt.immutableImages, immutableImages); diff != "" {
+				t.Fatalf("unexpected immutable images (-want +got): \n%s", diff)
+			}
+			indexDigests, isPlatform := tt.image.IndexDigests(tt.digests)
+			if diff := cmp.Diff(tt.isPlatform, isPlatform); diff != "" {
+				t.Fatalf("unexpected is platform (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.indexDigests, indexDigests); diff != "" {
+				t.Fatalf("unexpected index digests (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ValidateIndexVerification(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		mode     string
+		expected error
+	}{
+		{name: "index", mode: IndexVerificationIndex},
+		{name: "manifests", mode: IndexVerificationManifests},
+		{name: "all", mode: IndexVerificationAll},
+		{name: "empty", mode: "", expected: errorFlag},
+		{name: "invalid", mode: "platforms", expected: errorFlag},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateIndexVerification(tt.mode)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -32,11 +32,18 @@ func (h *PackageHelper) PackageDescriptor(policyPackageName string) (intoto.Pack
 }
 
 // ValidatePolicyPackage validates the package name in the policy.
+// The name may be a prefix pattern ending with a '*', e.g. ghcr.io/team-x/*.
 func ValidatePolicyPackage(policyPackageName string, environment []string) error {
 	// Environment is allowed to be set, so nothing to validate.
 	// Package name needs to contain both a registry and a name.
 	// It must not container an identifier (tag, digest).
-	ref, err := name.ParseReference(policyPackageName, name.WithDefaultTag(""), name.WithDefaultRegistry(""))
+	// For a pattern, the prefix must contain both a registry and a name,
+	// so that a pattern cannot claim all the packages of a registry.
+	image := policyPackageName
+	if strings.HasSuffix(image, "*") {
+		image = strings.TrimRight(strings.TrimSuffix(image, "*"), "/-_.")
+	}
+	ref, err := name.ParseReference(image, name.WithDefaultTag(""), name.WithDefaultRegistry(""))
 	if err != nil {
 		return fmt.Errorf("%w: failed to parse image (%q): %w", errorImageParsing, policyPackageName, err)
 	}
@@ -72,23 +79,55 @@ func canonicalizeRegistry(registry string) string {
 	return registry
 }
 
-// ParseImageReference parses the image reference.
+// OCIDigestAlgorithms contains the digest algorithms supported for OCI references,
+// in order of preference.
+// NOTE: the registry client (name.NewDigest) only supports sha256, which is
+// used to resolve, verify and attach to images, so other algorithms are rejected.
+// This only applies to image references: subjects that are not OCI artifacts
+// are validated against the full intoto registry by the publish and deployment packages.
+var OCIDigestAlgorithms = []string{intoto.AlgorithmSha256}
+
+// ParseImageReference parses the image reference. It returns the image name
+// and its digest of the form algorithm:value.
 func ParseImageReference(image string) (string, string, error) {
+	// NOTE: name.ParseReference() only supports sha256 digests, so we parse
+	// the digest ourselves.
+	base, digest, found := cutLast(image, "@")
+	if !found {
+		return "", "", fmt.Errorf("%w: no digest in image (%q)", errorImageParsing, image)
+	}
+	digests, err := intoto.DigestSetFromString(digest)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: invalid digest in image (%q): %w", errorImageParsing, image, err)
+	}
+	if _, _, err := digests.Select(OCIDigestAlgorithms...); err != nil {
+		return "", "", fmt.Errorf("%w: invalid digest in image (%q): %w", errorImageParsing, image, err)
+	}
 	// NOTE: disable "latest" default tag.
-	ref, err := name.ParseReference(image, name.WithDefaultTag(""))
+	ref, err := name.ParseReference(base, name.WithDefaultTag(""))
 	if err != nil {
 		return "", "", fmt.Errorf("%w: failed to parse image (%q): %w", errorImageParsing, image, err)
 	}
 	// NOTE: WithDefaultRegistry("docker.io") does not seem to work, it
 	// resets the value to index.docker.io
 	registry := canonicalizeRegistry(ref.Context().RegistryStr())
-	if !strings.HasPrefix(ref.Identifier(), "sha256:") {
-		return "", "", fmt.Errorf("%w: no digest in image (%q)", errorImageParsing, image)
-	}
+	return registry + "/" + ref.Context().RepositoryStr(), digest, nil
+}
 
-	return registry + "/" + ref.Context().RepositoryStr(), ref.Identifier(), nil
+func cutLast(s, sep string) (string, string, bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
 }
 
-func ImmutableImage(image string, digests intoto.DigestSet) string {
-	return fmt.Sprintf("%v@sha256:%v", image, digests["sha256"])
+// ImmutableImage returns the image reference for the preferred
+// OCI digest algorithm present in digests.
+func ImmutableImage(image string, digests intoto.DigestSet) (string, error) {
+	alg, value, err := digests.Select(OCIDigestAlgorithms...)
+	if err != nil {
+		return "", fmt.Errorf("image (%q): %w", image, err)
+	}
+	return fmt.Sprintf("%v@%v:%v", image, alg, value), nil
 }
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -85,6 +86,7 @@ func Test_PackageDescriptor(t *testing.T) {
 func Test_ParseImageReference(t *testing.T) {
 	t.Parallel()
 	digest := "sha256:f8bc336da3030b431b985652438661f17c0dc8eb9ab75a998c86e4b1387ee501"
+	sha512Digest := "sha512:" + strings.Repeat("f8bc336da3030b43", 8)
 	tests := []struct {
 		name      string
 		image     string
@@ -176,6 +178,21 @@ func Test_ParseImageReference(t *testing.T) {
 			expected: errorImageParsing,
 			image:    "ghcr.io/repo/image:tag@" + digest + "-",
 		},
+		{
+			name:     "sha512 digest",
+			expected: errorImageParsing,
+			image:    "ghcr.io/repo/image:tag@" + sha512Digest,
+		},
+		{
+			name:     "invalid sha512 digest",
+			expected: errorImageParsing,
+			image:    "ghcr.io/repo/image@sha512:f8bc336da3030b431b985652438661f17c0dc8eb9ab75a998c86e4b1387ee501",
+		},
+		{
+			name:     "non oci digest",
+			expected: errorImageParsing,
+			image:    "ghcr.io/repo/image@gitCommit:a12b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -238,6 +255,29 @@ func Test_ValidatePolicyPackage(t *testing.T) {
 			expected: errorPackageName,
 			image:    "docker.io/repo/image:tag@sha256:f8bc336da3030b431b985652438661f17c0dc8eb9ab75a998c86e4b1387ee501",
 		},
+		{
+			name:  "pattern",
+			image: "ghcr.io/repo/*",
+		},
+		{
+			name:  "pattern name prefix",
+			image: "ghcr.io/repo/image-*",
+		},
+		{
+			name:     "pattern registry only",
+			expected: errorPackageName,
+			image:    "ghcr.io/*",
+		},
+		{
+			name:     "pattern registry not allowed",
+			expected: errorPackageName,
+			image:    "registry.io/repo/*",
+		},
+		{
+			name:     "pattern not at the end",
+			expected: errorImageParsing,
+			image:    "ghcr.io/*/image",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"flag"
+	"fmt"
+)
+
+// PolicyPaths contains the paths to the publish and deployment policies
+// of a policy repository.
+type PolicyPaths struct {
+	PublishOrg         string
+	PublishProjects    string
+	DeploymentOrg      string
+	DeploymentProjects string
+}
+
+// SetFlags defines the flags to set the paths.
+func (p *PolicyPaths) SetFlags(fs *flag.FlagSet) {
+	fs.StringVar(&p.PublishOrg, "publish-org", "", "path to the publish org policy")
+	fs.StringVar(&p.PublishProjects, "publish-projects", "", "path to the publish project policies")
+	fs.StringVar(&p.DeploymentOrg, "deployment-org", "", "path to the deployment org policy")
+	fs.StringVar(&p.DeploymentProjects, "deployment-projects", "", "path to the deployment project policies")
+}
+
+// HasPublish returns true if the publish policy paths are set.
+func (p *PolicyPaths) HasPublish() bool {
+	return p.PublishOrg != ""
+}
+
+// HasDeployment returns true if the deployment policy paths are set.
+func (p *PolicyPaths) HasDeployment() bool {
+	return p.DeploymentOrg != ""
+}
+
+// Validate validates the paths. The org and projects paths
+// must be set together, and at least one policy must be set.
+func (p *PolicyPaths) Validate() error {
+	if (p.PublishOrg == "") != (p.PublishProjects == "") {
+		return fmt.Errorf("%w: --publish-org and --publish-projects must be set together", errorFlag)
+	}
+	if (p.DeploymentOrg == "") != (p.DeploymentProjects == "") {
+		return fmt.Errorf("%w: --deployment-org and --deployment-projects must be set together", errorFlag)
+	}
+	if !p.HasPublish() && !p.HasDeployment() {
+		return fmt.Errorf("%w: no publish or deployment policy set", errorFlag)
+	}
+	return nil
+}
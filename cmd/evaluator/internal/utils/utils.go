@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 )
 
 func ReadFiles(dir string, ignore string) ([]string, error) {
@@ -38,3 +42,12 @@ func ReadFiles(dir string, ignore string) ([]string, error) {
 func Log(format string, a ...any) {
 	fmt.Fprintf(os.Stderr, format, a...)
 }
+
+// AttestationDigests returns the digests of a verified attestation,
+// recorded as evidence in the attestations created.
+func AttestationDigests(content []byte) intoto.DigestSet {
+	digest := sha256.Sum256(content)
+	return intoto.DigestSet{
+		intoto.AlgorithmSha256: hex.EncodeToString(digest[:]),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/deployment"
+	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/policy"
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/publish"
 	"github.com/slsa-framework/slsa-policy/cli/evaluator/internal/utils"
 )
@@ -15,6 +16,7 @@ func usage(prog string) {
 		"Available commands:\n" +
 		"publish \t\tOperation on publish policy\n" +
 		"deployment \t\tOperation on deployment policy\n" +
+		"policy \t\t\tOperation on the policy repository\n" +
 		"\n"
 	utils.Log(msg, prog)
 	os.Exit(1)
@@ -43,6 +45,11 @@ func main() {
 			utils.Log(err.Error() + "\n")
 			os.Exit(3)
 		}
+	case "policy":
+		if err := policy.Run(os.Args[0], arguments[1:]); err != nil {
+			utils.Log(err.Error() + "\n")
+			os.Exit(4)
+		}
 	}
 	os.Exit(0)
 }
@@ -0,0 +1,194 @@
+package consistency
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+// Rules of the consistency check between the publish and deployment policies.
+var (
+	ruleUnpublishedPackage = lint.Rule{
+		ID:          "XPL001",
+		Name:        "unpublished-package",
+		Description: "A deployment policy deploys a package that the publish policy does not allow to publish.",
+		Severity:    lint.SeverityError,
+	}
+	ruleEnvironmentMismatch = lint.Rule{
+		ID:          "XPL002",
+		Name:        "environment-mismatch",
+		Description: "A deployment policy requires an environment the package is never published to.",
+		Severity:    lint.SeverityError,
+	}
+	ruleUnsatisfiableLevel = lint.Rule{
+		ID:          "XPL003",
+		Name:        "unsatisfiable-level",
+		Description: "A deployment policy requires a SLSA level higher than the level of the builders allowed to publish the package, or than the max level of the publisher.",
+		Severity:    lint.SeverityError,
+	}
+	ruleUndeployedPackage = lint.Rule{
+		ID:          "XPL004",
+		Name:        "undeployed-package",
+		Description: "The publish policy allows to publish a package that no deployment policy deploys.",
+		Severity:    lint.SeverityWarning,
+	}
+	ruleUntrustedPublisher = lint.Rule{
+		ID:          "XPL005",
+		Name:        "untrusted-publisher",
+		Description: "The publisher of the publish policy is not trusted by the deployment org policy.",
+		Severity:    lint.SeverityError,
+	}
+)
+
+// Rules returns the rules of the consistency check.
+func Rules() []lint.Rule {
+	return []lint.Rule{
+		ruleUnpublishedPackage,
+		ruleEnvironmentMismatch,
+		ruleUnsatisfiableLevel,
+		ruleUndeployedPackage,
+		ruleUntrustedPublisher,
+	}
+}
+
+// Option defines an option of the consistency check.
+type Option func(*options)
+
+type options struct {
+	publisherID string
+}
+
+// SetPublisherID sets the ID of the publisher that signs the publish attestations.
+// The SLSA levels of the publish policy are capped by the max_slsa_level of this
+// publisher in the deployment org policy. By default, they are capped by the
+// highest max_slsa_level of the publishers.
+func SetPublisherID(id string) Option {
+	return func(o *options) {
+		o.publisherID = id
+	}
+}
+
+func newFinding(rule lint.Rule, location, format string, a ...any) lint.Finding {
+	return lint.Finding{
+		RuleID:   rule.ID,
+		Severity: rule.Severity,
+		Location: location,
+		Message:  fmt.Sprintf(format, a...),
+	}
+}
+
+// Check returns the deployment rules that the publish policy cannot satisfy
+// and the packages that are published or deployed only, sorted by location.
+// Findings for deployment policies have the policy ID as location. Findings for
+// publish policies have an empty location, since publish policies have no ID.
+func Check(publishPolicy *publish.Policy, deploymentPolicy *deployment.Policy, opts ...Option) []lint.Finding {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var findings []lint.Finding
+	maxLevel, trusted := publisherLevel(deploymentPolicy.Publishers(), o.publisherID)
+	if !trusted {
+		findings = append(findings, newFinding(ruleUntrustedPublisher, "",
+			"publisher (%q) is not present in the deployment org policy", o.publisherID))
+	}
+	deployed := make(map[string]bool)
+	for _, dpkg := range deploymentPolicy.DeployedPackages() {
+		ppkg, exists := publishPolicy.FindPackage(dpkg.Name)
+		if !exists {
+			findings = append(findings, newFinding(ruleUnpublishedPackage, dpkg.PolicyID,
+				"package (%q) is not present in the publish policy", dpkg.Name))
+			continue
+		}
+		deployed[ppkg.Name] = true
+		findings = append(findings, checkPackage(publishPolicy, dpkg, ppkg, maxLevel, trusted)...)
+	}
+	for _, ppkg := range publishPolicy.PublishedPackages() {
+		if !deployed[ppkg.Name] {
+			findings = append(findings, newFinding(ruleUndeployedPackage, "",
+				"package (%q) is not present in the deployment policy", ppkg.Name))
+		}
+	}
+	lint.Sort(findings)
+	return findings
+}
+
+// publisherLevel returns the max SLSA level the deployment org policy trusts the
+// publisher for, or the highest level of the publishers if the ID is empty.
+// It returns false if the publisher is not trusted.
+func publisherLevel(publishers []deployment.Publisher, id string) (int, bool) {
+	level := -1
+	for _, publisher := range publishers {
+		if id != "" && publisher.ID != id {
+			continue
+		}
+		if publisher.MaxSlsaLevel > level {
+			level = publisher.MaxSlsaLevel
+		}
+	}
+	return level, level >= 0
+}
+
+// checkPackage returns the findings for a deployed package
+// and the package that allows to publish it. The SLSA levels of the
+// publish policy are capped by maxLevel, unless the publisher is not trusted.
+func checkPackage(publishPolicy *publish.Policy, dpkg deployment.DeployedPackage, ppkg publish.PublishedPackage,
+	maxLevel int, trusted bool) []lint.Finding {
+	var findings []lint.Finding
+	// The environments for which the package can be deployed.
+	var environments []*string
+	switch {
+	case len(dpkg.Environments) == 0 && len(ppkg.Environments) == 0:
+		environments = []*string{nil}
+	case len(dpkg.Environments) == 0:
+		// NOTE: a package deployed without environment accepts the publish
+		// attestation of any environment, so the level must be satisfiable
+		// by the builders of every published environment.
+		for i := range ppkg.Environments {
+			environments = append(environments, &ppkg.Environments[i])
+		}
+	case len(ppkg.Environments) == 0:
+		findings = append(findings, newFinding(ruleEnvironmentMismatch, dpkg.PolicyID,
+			"package (%q) is deployed for environments (%q) but published without environment", dpkg.Name, dpkg.Environments))
+	default:
+		for i := range dpkg.Environments {
+			env := &dpkg.Environments[i]
+			if !slices.Contains(ppkg.Environments, *env) {
+				findings = append(findings, newFinding(ruleEnvironmentMismatch, dpkg.PolicyID,
+					"package (%q) is deployed for environment (%q) but published for environments (%q)", dpkg.Name, *env, ppkg.Environments))
+				continue
+			}
+			environments = append(environments, env)
+		}
+	}
+	if !trusted {
+		// NOTE: the untrusted publisher is reported once by the caller.
+		return findings
+	}
+	for _, env := range environments {
+		// NOTE: the environment is one of the package's environments, so there is no error.
+		level, _ := publishPolicy.MaxSlsaLevel(dpkg.Name, env)
+		// NOTE: the deployment org does not trust the publisher above its max_slsa_level.
+		capped := ""
+		if level > maxLevel {
+			level = maxLevel
+			capped = " (capped by the publisher's max_slsa_level)"
+		}
+		if level >= dpkg.RequireSlsaLevel {
+			continue
+		}
+		if env == nil {
+			findings = append(findings, newFinding(ruleUnsatisfiableLevel, dpkg.PolicyID,
+				"package (%q) requires SLSA level %d but is published with level %d at most%s",
+				dpkg.Name, dpkg.RequireSlsaLevel, level, capped))
+			continue
+		}
+		findings = append(findings, newFinding(ruleUnsatisfiableLevel, dpkg.PolicyID,
+			"package (%q) requires SLSA level %d but is published to environment (%q) with level %d at most%s",
+			dpkg.Name, dpkg.RequireSlsaLevel, *env, level, capped))
+	}
+	return findings
+}
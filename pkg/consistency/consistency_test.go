@@ -0,0 +1,339 @@
+package consistency
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator/files_reader"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator/named_files_reader"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+const publishOrg = `{
+	"format": 1,
+	"roots": {
+		"build": [
+			{"id": "builder_id1", "name": "builder_name1", "slsa_level": 2},
+			{"id": "builder_id2", "name": "builder_name2", "slsa_level": 3}
+		]
+	}
+}`
+
+const deploymentOrg = `{
+	"format": 1,
+	"roots": {
+		"publish": [
+			{"id": "publisher_id", "build": {"max_slsa_level": 3}}
+		]
+	}
+}`
+
+const deploymentOrgPublishers = `{
+	"format": 1,
+	"roots": {
+		"publish": [
+			{"id": "publisher_id1", "build": {"max_slsa_level": 3}},
+			{"id": "publisher_id2", "build": {"max_slsa_level": 2}}
+		]
+	}
+}`
+
+func Test_Check(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name               string
+		deploymentOrg      string
+		opts               []Option
+		publishProjects    []string
+		deploymentProjects []string
+		expected           []lint.Finding
+	}{
+		{
+			name: "consistent policies",
+			publishProjects: []string{
+				`{
+					"format": 1,
+					"package": {"name": "package_name1", "environment": {"any_of": ["dev", "prod"]}},
+					"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name1"}}
+				}`,
+				`{
+					"format": 1,
+					"package": {"name": "org/*"},
+					"build": {"require_slsa_builder": "builder_name1", "repository": {"uri": "source_name2"}}
+				}`,
+			},
+			deploymentProjects: []string{
+				`{
+					"format": 1,
+					"protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+					"packages": [
+						{"name": "package_name1", "environment": {"any_of": ["prod"]}}
+					],
+					"build": {"require_slsa_level": 3}
+				}`,
+				`{
+					"format": 1,
+					"protection": {"google_service_account": "name2@project.iam.gserviceaccount.com"},
+					"packages": [
+						{"name": "org/package_name2"}
+					],
+					"build": {"require_slsa_level": 2}
+				}`,
+			},
+		},
+		{
+			name: "inconsistent policies",
+			publishProjects: []string{
+				`{
+					"format": 1,
+					"package": {"name": "package_name1", "environment": {"any_of": ["dev", "prod"]}},
+					"build": {
+						"require_slsa_builder": "builder_name2",
+						"repository": {"uri": "source_name1"},
+						"environments": {"dev": {"require_slsa_builder": "builder_name1"}}
+					}
+				}`,
+				`{
+					"format": 1,
+					"package": {"name": "package_name2"},
+					"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name2"}}
+				}`,
+				`{
+					"format": 1,
+					"package": {"name": "package_name3", "environment": {"any_of": ["prod"]}},
+					"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name3"}}
+				}`,
+				`{
+					"format": 1,
+					"package": {"name": "package_name4"},
+					"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name4"}}
+				}`,
+			},
+			deploymentProjects: []string{
+				`{
+					"format": 1,
+					"protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+					"packages": [
+						{"name": "package_name1", "environment": {"any_of": ["dev", "prod", "staging"]}},
+						{"name": "package_name2", "environment": {"any_of": ["prod"]}},
+						{"name": "package_name3"},
+						{"name": "package_name5"}
+					],
+					"build": {"require_slsa_level": 3}
+				}`,
+			},
+			expected: []lint.Finding{
+				{
+					RuleID:   ruleUndeployedPackage.ID,
+					Severity: lint.SeverityWarning,
+					Message:  `package ("package_name4") is not present in the deployment policy`,
+				},
+				{
+					RuleID:   ruleUnpublishedPackage.ID,
+					Severity: lint.SeverityError,
+					Location: "policy0.json",
+					Message:  `package ("package_name5") is not present in the publish policy`,
+				},
+				{
+					RuleID:   ruleEnvironmentMismatch.ID,
+					Severity: lint.SeverityError,
+					Location: "policy0.json",
+					Message:  `package ("package_name1") is deployed for environment ("staging") but published for environments (["dev" "prod"])`,
+				},
+				{
+					RuleID:   ruleEnvironmentMismatch.ID,
+					Severity: lint.SeverityError,
+					Location: "policy0.json",
+					Message:  `package ("package_name2") is deployed for environments (["prod"]) but published without environment`,
+				},
+				{
+					RuleID:   ruleUnsatisfiableLevel.ID,
+					Severity: lint.SeverityError,
+					Location: "policy0.json",
+					Message:  `package ("package_name1") requires SLSA level 3 but is published to environment ("dev") with level 2 at most`,
+				},
+			},
+		},
+		{
+			name: "deployed without environment",
+			publishProjects: []string{
+				`{
+					"format": 1,
+					"package": {"name": "package_name1", "environment": {"any_of": ["dev", "prod"]}},
+					"build": {
+						"require_slsa_builder": "builder_name2",
+						"repository": {"uri": "source_name1"},
+						"environments": {"dev": {"require_slsa_builder": "builder_name1"}}
+					}
+				}`,
+				`{
+					"format": 1,
+					"package": {"name": "package_name2", "environment": {"any_of": ["prod"]}},
+					"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name2"}}
+				}`,
+			},
+			deploymentProjects: []string{
+				`{
+					"format": 1,
+					"protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+					"packages": [
+						{"name": "package_name1"},
+						{"name": "package_name2"}
+					],
+					"build": {"require_slsa_level": 3}
+				}`,
+			},
+			expected: []lint.Finding{
+				{
+					RuleID:   ruleUnsatisfiableLevel.ID,
+					Severity: lint.SeverityError,
+					Location: "policy0.json",
+					Message:  `package ("package_name1") requires SLSA level 3 but is published to environment ("dev") with level 2 at most`,
+				},
+			},
+		},
+		{
+			name:          "level capped by publisher",
+			deploymentOrg: deploymentOrgPublishers,
+			opts:          []Option{SetPublisherID("publisher_id2")},
+			publishProjects: []string{
+				`{
+					"format": 1,
+					"package": {"name": "package_name1"},
+					"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name1"}}
+				}`,
+			},
+			deploymentProjects: []string{
+				`{
+					"format": 1,
+					"protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+					"packages": [
+						{"name": "package_name1"}
+					],
+					"build": {"require_slsa_level": 3}
+				}`,
+			},
+			expected: []lint.Finding{
+				{
+					RuleID:   ruleUnsatisfiableLevel.ID,
+					Severity: lint.SeverityError,
+					Location: "policy0.json",
+					Message:  `package ("package_name1") requires SLSA level 3 but is published with level 2 at most (capped by the publisher's max_slsa_level)`,
+				},
+			},
+		},
+		{
+			name:          "level not capped by publisher",
+			deploymentOrg: deploymentOrgPublishers,
+			opts:          []Option{SetPublisherID("publisher_id1")},
+			publishProjects: []string{
+				`{
+					"format": 1,
+					"package": {"name": "package_name1"},
+					"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name1"}}
+				}`,
+			},
+			deploymentProjects: []string{
+				`{
+					"format": 1,
+					"protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+					"packages": [
+						{"name": "package_name1"}
+					],
+					"build": {"require_slsa_level": 3}
+				}`,
+			},
+		},
+		{
+			name:          "untrusted publisher",
+			deploymentOrg: deploymentOrgPublishers,
+			opts:          []Option{SetPublisherID("publisher_id3")},
+			publishProjects: []string{
+				`{
+					"format": 1,
+					"package": {"name": "package_name1"},
+					"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name1"}}
+				}`,
+			},
+			deploymentProjects: []string{
+				`{
+					"format": 1,
+					"protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+					"packages": [
+						{"name": "package_name1"}
+					],
+					"build": {"require_slsa_level": 3}
+				}`,
+			},
+			expected: []lint.Finding{
+				{
+					RuleID:   ruleUntrustedPublisher.ID,
+					Severity: lint.SeverityError,
+					Message:  `publisher ("publisher_id3") is not present in the deployment org policy`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			publishPolicy, err := publish.PolicyNew(io.NopCloser(strings.NewReader(publishOrg)),
+				files_reader.FromPaths(writeFiles(t, tt.publishProjects)), &packageHelper{})
+			if err != nil {
+				t.Fatalf("failed to create publish policy: %v", err)
+			}
+			org := tt.deploymentOrg
+			if org == "" {
+				org = deploymentOrg
+			}
+			dir := t.TempDir()
+			deploymentPolicy, err := deployment.PolicyNew(io.NopCloser(strings.NewReader(org)),
+				named_files_reader.FromPaths(dir, writeFilesIn(t, dir, tt.deploymentProjects)))
+			if err != nil {
+				t.Fatalf("failed to create deployment policy: %v", err)
+			}
+			findings := Check(publishPolicy, deploymentPolicy, tt.opts...)
+			if diff := cmp.Diff(tt.expected, findings); diff != "" {
+				t.Fatalf("unexpected findings (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func writeFiles(t *testing.T, contents []string) []string {
+	return writeFilesIn(t, t.TempDir(), contents)
+}
+
+func writeFilesIn(t *testing.T, dir string, contents []string) []string {
+	paths := make([]string, len(contents))
+	for i, content := range contents {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("policy%d.json", i))
+		if err := os.WriteFile(paths[i], []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	return paths
+}
+
+type packageHelper struct{}
+
+func (p *packageHelper) PolicyPackageName(desc intoto.PackageDescriptor) (string, error) {
+	return desc.Name, nil
+}
+
+func (p *packageHelper) PackageDescriptor(name string) (intoto.PackageDescriptor, error) {
+	return intoto.PackageDescriptor{
+		Name: name,
+	}, nil
+}
@@ -23,7 +23,9 @@ type attestation struct {
 }
 
 const (
-	statementType             = "https://in-toto.io/Statement/v1"
+	statementType             = intoto.StatementTypeV1
 	predicateType             = "https://slsa.dev/deployment/v0.1"
 	scopeGoogleServiceAccount = "cloud.google.com/service_account/v1"
+	// Annotation of the evidence in the decision details.
+	predicateTypeAnnotation = "predicateType"
 )
@@ -0,0 +1,179 @@
+package deployment
+
+import (
+	"io"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/format"
+)
+
+// policyFormat is the format of the policies created programmatically.
+const policyFormat = 1
+
+// OrgPolicyBuilder builds an org policy programmatically.
+// Errors are reported by Validate(), JSON() and YAML().
+type OrgPolicyBuilder struct {
+	policy organization.Policy
+}
+
+// NewOrgPolicy returns a builder for an empty org policy.
+func NewOrgPolicy() *OrgPolicyBuilder {
+	return &OrgPolicyBuilder{
+		policy: organization.Policy{
+			Format: policyFormat,
+		},
+	}
+}
+
+// OrgPolicyFromReader returns a builder for an existing org policy.
+func OrgPolicyFromReader(reader io.ReadCloser) (*OrgPolicyBuilder, error) {
+	policy, err := organization.FromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &OrgPolicyBuilder{policy: *policy}, nil
+}
+
+// AddPublisher adds a trusted publisher.
+func (b *OrgPolicyBuilder) AddPublisher(id string, maxSlsaLevel int) *OrgPolicyBuilder {
+	b.policy.Roots.Publish = append(b.policy.Roots.Publish, organization.Root{
+		ID: id,
+		Build: organization.Build{
+			MaxSlsaLevel: &maxSlsaLevel,
+		},
+	})
+	return b
+}
+
+// SetDefaultRequireSlsaLevel sets the level required by the project policies that do not set one.
+func (b *OrgPolicyBuilder) SetDefaultRequireSlsaLevel(slsaLevel int) *OrgPolicyBuilder {
+	b.defaults().Build = &organization.BuildDefaults{
+		RequireSlsaLevel: &slsaLevel,
+	}
+	return b
+}
+
+// SetDefaultEnvironments sets the environments of the packages that do not set any.
+func (b *OrgPolicyBuilder) SetDefaultEnvironments(environments ...string) *OrgPolicyBuilder {
+	b.defaults().Environment = &organization.EnvironmentDefaults{
+		// NOTE: make a copy of the array.
+		AnyOf: append([]string{}, environments...),
+	}
+	return b
+}
+
+// SetMinRequireSlsaLevel sets the minimum level the project policies may require.
+func (b *OrgPolicyBuilder) SetMinRequireSlsaLevel(slsaLevel int) *OrgPolicyBuilder {
+	b.policy.Minimums = &organization.Minimums{
+		Build: &organization.BuildMinimums{
+			RequireSlsaLevel: &slsaLevel,
+		},
+	}
+	return b
+}
+
+func (b *OrgPolicyBuilder) defaults() *organization.Defaults {
+	if b.policy.Defaults == nil {
+		b.policy.Defaults = &organization.Defaults{}
+	}
+	return b.policy.Defaults
+}
+
+// Validate validates the policy like PolicyNew() does.
+func (b *OrgPolicyBuilder) Validate() error {
+	return b.policy.Validate()
+}
+
+// JSON validates the policy and returns its canonical JSON encoding.
+func (b *OrgPolicyBuilder) JSON() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return format.JSON(b.policy)
+}
+
+// YAML validates the policy and returns its canonical YAML encoding.
+func (b *OrgPolicyBuilder) YAML() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return format.YAML(b.policy)
+}
+
+// ProjectPolicyBuilder builds a project policy programmatically.
+// Errors are reported by Validate(), JSON() and YAML().
+type ProjectPolicyBuilder struct {
+	policy project.Policy
+}
+
+// NewProjectPolicy returns a builder for a project policy that deploys
+// its packages under a Google service account, if their publish
+// attestation has at least the required SLSA level.
+func NewProjectPolicy(googleServiceAccount string, requireSlsaLevel int) *ProjectPolicyBuilder {
+	return &ProjectPolicyBuilder{
+		policy: project.Policy{
+			Format: policyFormat,
+			Protection: project.Protection{
+				GoogleServiceAccount: googleServiceAccount,
+			},
+			BuildRequirements: project.BuildRequirements{
+				RequireSlsaLevel: &requireSlsaLevel,
+			},
+		},
+	}
+}
+
+// AddPackage adds a package to the policy.
+func (b *ProjectPolicyBuilder) AddPackage(packageName string, environments ...string) *ProjectPolicyBuilder {
+	b.policy.Packages = append(b.policy.Packages, project.Package{
+		Name: packageName,
+		Environment: project.Environment{
+			AnyOf: environments,
+		},
+	})
+	return b
+}
+
+// SetOwners sets the owners of the policy.
+func (b *ProjectPolicyBuilder) SetOwners(owners Owners) *ProjectPolicyBuilder {
+	b.policy.Owners = &project.Owners{
+		Team:         owners.Team,
+		Contacts:     owners.Contacts,
+		Editors:      owners.Editors,
+		Repositories: owners.Repositories,
+	}
+	return b
+}
+
+// Validate validates the policy against the org policy, like PolicyNew() does.
+func (b *ProjectPolicyBuilder) Validate(org *OrgPolicyBuilder, opts ...PolicyOption) error {
+	if err := org.Validate(); err != nil {
+		return err
+	}
+	// Initialize a policy with caller options.
+	p := new(Policy)
+	for _, option := range opts {
+		err := option(p)
+		if err != nil {
+			return err
+		}
+	}
+	return b.policy.Validate(org.policy, p.validator)
+}
+
+// JSON validates the policy against the org policy and returns its canonical JSON encoding.
+func (b *ProjectPolicyBuilder) JSON(org *OrgPolicyBuilder, opts ...PolicyOption) ([]byte, error) {
+	if err := b.Validate(org, opts...); err != nil {
+		return nil, err
+	}
+	return format.JSON(b.policy)
+}
+
+// YAML validates the policy against the org policy and returns its canonical YAML encoding.
+func (b *ProjectPolicyBuilder) YAML(org *OrgPolicyBuilder, opts ...PolicyOption) ([]byte, error) {
+	if err := b.Validate(org, opts...); err != nil {
+		return nil, err
+	}
+	return format.YAML(b.policy)
+}
@@ -0,0 +1,228 @@
+package deployment
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_OrgPolicyBuilder(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		org      *OrgPolicyBuilder
+		content  string
+		expected error
+	}{
+		{
+			name: "valid policy",
+			org:  NewOrgPolicy().AddPublisher("publisher_id", 3),
+			content: "" +
+				"{\n" +
+				"  \"format\": 1,\n" +
+				"  \"roots\": {\n" +
+				"    \"publish\": [\n" +
+				"      {\n" +
+				"        \"build\": {\n" +
+				"          \"max_slsa_level\": 3\n" +
+				"        },\n" +
+				"        \"id\": \"publisher_id\"\n" +
+				"      }\n" +
+				"    ]\n" +
+				"  }\n" +
+				"}\n",
+		},
+		{
+			name: "defaults and minimums",
+			org: NewOrgPolicy().AddPublisher("publisher_id", 3).
+				SetDefaultRequireSlsaLevel(3).
+				SetDefaultEnvironments("prod").
+				SetMinRequireSlsaLevel(2),
+			content: "" +
+				"{\n" +
+				"  \"defaults\": {\n" +
+				"    \"build\": {\n" +
+				"      \"require_slsa_level\": 3\n" +
+				"    },\n" +
+				"    \"environment\": {\n" +
+				"      \"any_of\": [\n" +
+				"        \"prod\"\n" +
+				"      ]\n" +
+				"    }\n" +
+				"  },\n" +
+				"  \"format\": 1,\n" +
+				"  \"minimums\": {\n" +
+				"    \"build\": {\n" +
+				"      \"require_slsa_level\": 2\n" +
+				"    }\n" +
+				"  },\n" +
+				"  \"roots\": {\n" +
+				"    \"publish\": [\n" +
+				"      {\n" +
+				"        \"build\": {\n" +
+				"          \"max_slsa_level\": 3\n" +
+				"        },\n" +
+				"        \"id\": \"publisher_id\"\n" +
+				"      }\n" +
+				"    ]\n" +
+				"  }\n" +
+				"}\n",
+		},
+		{
+			name:     "default level below minimum",
+			org:      NewOrgPolicy().AddPublisher("publisher_id", 3).SetDefaultRequireSlsaLevel(1).SetMinRequireSlsaLevel(2),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "no publishers",
+			org:      NewOrgPolicy(),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "invalid level",
+			org:      NewOrgPolicy().AddPublisher("publisher_id", -1),
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := tt.org.JSON()
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.content, string(content)); diff != "" {
+				t.Fatalf("unexpected content (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ProjectPolicyBuilder(t *testing.T) {
+	t.Parallel()
+	org := NewOrgPolicy().AddPublisher("publisher_id", 3).SetMinRequireSlsaLevel(2)
+	tests := []struct {
+		name     string
+		project  *ProjectPolicyBuilder
+		content  string
+		expected error
+	}{
+		{
+			name: "valid policy",
+			project: NewProjectPolicy("name@project.iam.gserviceaccount.com", 3).
+				AddPackage("package_name1", "prod").
+				AddPackage("package_name2").
+				SetOwners(Owners{Team: "team1", Editors: []string{"@org/team1"}}),
+			content: "" +
+				"{\n" +
+				"  \"build\": {\n" +
+				"    \"require_slsa_level\": 3\n" +
+				"  },\n" +
+				"  \"format\": 1,\n" +
+				"  \"owners\": {\n" +
+				"    \"editors\": [\n" +
+				"      \"@org/team1\"\n" +
+				"    ],\n" +
+				"    \"team\": \"team1\"\n" +
+				"  },\n" +
+				"  \"packages\": [\n" +
+				"    {\n" +
+				"      \"environment\": {\n" +
+				"        \"any_of\": [\n" +
+				"          \"prod\"\n" +
+				"        ]\n" +
+				"      },\n" +
+				"      \"name\": \"package_name1\"\n" +
+				"    },\n" +
+				"    {\n" +
+				"      \"name\": \"package_name2\"\n" +
+				"    }\n" +
+				"  ],\n" +
+				"  \"protection\": {\n" +
+				"    \"google_service_account\": \"name@project.iam.gserviceaccount.com\"\n" +
+				"  }\n" +
+				"}\n",
+		},
+		{
+			name:     "no packages",
+			project:  NewProjectPolicy("name@project.iam.gserviceaccount.com", 3),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "level below org minimum",
+			project:  NewProjectPolicy("name@project.iam.gserviceaccount.com", 1).AddPackage("package_name1"),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "level above org max",
+			project:  NewProjectPolicy("name@project.iam.gserviceaccount.com", 4).AddPackage("package_name1"),
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := tt.project.JSON(org)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.content, string(content)); diff != "" {
+				t.Fatalf("unexpected content (-want +got): \n%s", diff)
+			}
+
+			// The generated files must be accepted by PolicyNew().
+			orgContent, err := org.JSON()
+			if err != nil {
+				t.Fatalf("failed to marshal org: %v", err)
+			}
+			_, err = PolicyNew(io.NopCloser(bytes.NewReader(orgContent)), common.NewNamedBytesIterator([][]byte{content}, true))
+			if err != nil {
+				t.Fatalf("failed to create policy: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ProjectPolicyBuilderYAML(t *testing.T) {
+	t.Parallel()
+	org := NewOrgPolicy().AddPublisher("publisher_id", 3)
+	content, err := NewProjectPolicy("name@project.iam.gserviceaccount.com", 3).
+		AddPackage("package_name2").
+		AddPackage("package_name1", "prod", "dev").
+		YAML(org)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expected := "" +
+		"build:\n" +
+		"  require_slsa_level: 3\n" +
+		"format: 1\n" +
+		"packages:\n" +
+		"- environment:\n" +
+		"    any_of:\n" +
+		"    - dev\n" +
+		"    - prod\n" +
+		"  name: package_name1\n" +
+		"- name: package_name2\n" +
+		"protection:\n" +
+		"  google_service_account: name@project.iam.gserviceaccount.com\n"
+	if diff := cmp.Diff(expected, string(content)); diff != "" {
+		t.Fatalf("unexpected content (-want +got): \n%s", diff)
+	}
+}
@@ -4,18 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 )
 
 type Creation struct {
 	attestation
-	safeMode bool
+	safeMode         bool
+	digestValidation intoto.DigestValidation
 }
 
 type AttestationCreationOption func(*Creation) error
 
 func CreationNew(subject intoto.Subject, scopes map[string]string, options ...AttestationCreationOption) (*Creation, error) {
-	if err := subject.Validate(); err != nil {
+	subject, err := subject.Normalize(intoto.DigestValidationPermissive)
+	if err != nil {
 		return nil, err
 	}
 
@@ -50,7 +53,86 @@ func (a *Creation) ToBytes() ([]byte, error) {
 	return content, nil
 }
 
-// TODO: Add support for decision details.
+// AddSubjects adds subjects to the attestation, e.g. the platform
+// manifests of a multi-arch image index. A subject must not share
+// a digest with another subject.
+func AddSubjects(subjects ...intoto.Subject) AttestationCreationOption {
+	return func(a *Creation) error {
+		return a.addSubjects(subjects...)
+	}
+}
+
+func (a *Creation) addSubjects(subjects ...intoto.Subject) error {
+	if a.isSafeMode() {
+		return fmt.Errorf("%w: safe mode enabled, cannot add subjects", errs.ErrorInternal)
+	}
+	for i := range subjects {
+		subject, err := subjects[i].Normalize(a.digestValidation)
+		if err != nil {
+			return err
+		}
+		if err := validateUniqueDigests(a.attestation.Header.Subjects, subject); err != nil {
+			return err
+		}
+		a.attestation.Header.Subjects = append(a.attestation.Header.Subjects, subject)
+	}
+	return nil
+}
+
+// SetStrictDigests requires the digests of all subjects to be valid
+// in strict mode. See intoto.DigestValidationStrict.
+func SetStrictDigests() AttestationCreationOption {
+	return func(a *Creation) error {
+		return a.setStrictDigests()
+	}
+}
+
+func (a *Creation) setStrictDigests() error {
+	// NOTE: this is allowed in safe mode, since it only makes validation stricter.
+	for i := range a.attestation.Header.Subjects {
+		if err := a.attestation.Header.Subjects[i].ValidateWithMode(intoto.DigestValidationStrict); err != nil {
+			return err
+		}
+	}
+	a.digestValidation = intoto.DigestValidationStrict
+	return nil
+}
+
+func validateUniqueDigests(subjects []intoto.Subject, subject intoto.Subject) error {
+	for i := range subjects {
+		for name, value := range subjects[i].Digests {
+			if val, exists := subject.Digests[name]; exists && val == value {
+				return fmt.Errorf("%w: subject with digest (%q:%q) is present more than once", errs.ErrorInvalidInput,
+					name, value)
+			}
+		}
+	}
+	return nil
+}
+
+// AddEvidence records the attestations verified during policy
+// evaluation in the decision details.
+func AddEvidence(evidence ...intoto.ResourceDescriptor) AttestationCreationOption {
+	return func(a *Creation) error {
+		return a.addEvidence(evidence...)
+	}
+}
+
+func (a *Creation) addEvidence(evidence ...intoto.ResourceDescriptor) error {
+	if a.isSafeMode() {
+		return fmt.Errorf("%w: safe mode enabled, cannot add evidence", errs.ErrorInternal)
+	}
+	for i := range evidence {
+		if err := evidence[i].Validate(); err != nil {
+			return err
+		}
+		if a.attestation.Predicate.DecisionDetails == nil {
+			a.attestation.Predicate.DecisionDetails = &decisionDetails{}
+		}
+		a.attestation.Predicate.DecisionDetails.Evidence = append(a.attestation.Predicate.DecisionDetails.Evidence, evidence[i])
+	}
+	return nil
+}
 
 func EnterSafeMode() AttestationCreationOption {
 	return func(a *Creation) error {
@@ -14,8 +14,8 @@ func Test_CreationNew(t *testing.T) {
 	t.Parallel()
 	subject := intoto.Subject{
 		Digests: intoto.DigestSet{
-			"sha256":    "some_value",
-			"gitCommit": "another_value",
+			"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+			"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
 		},
 	}
 	scopes := map[string]string{
@@ -26,7 +26,6 @@ func Test_CreationNew(t *testing.T) {
 		name     string
 		subject  intoto.Subject
 		scopes   map[string]string
-		policy   map[string]intoto.Policy
 		expected error
 	}{
 		{
@@ -48,7 +47,7 @@ func Test_CreationNew(t *testing.T) {
 			name: "result with empty digest value",
 			subject: intoto.Subject{
 				Digests: intoto.DigestSet{
-					"sha256":    "some_value",
+					"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
 					"gitCommit": "",
 				},
 			},
@@ -59,7 +58,7 @@ func Test_CreationNew(t *testing.T) {
 			name: "result with empty digest key",
 			subject: intoto.Subject{
 				Digests: intoto.DigestSet{
-					"sha256": "some_value",
+					"sha256": "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
 					"":       "another_value",
 				},
 			},
@@ -103,3 +102,74 @@ func Test_CreationNew(t *testing.T) {
 		})
 	}
 }
+
+func Test_AddEvidence(t *testing.T) {
+	t.Parallel()
+	subject := intoto.Subject{
+		Digests: intoto.DigestSet{
+			"sha256": "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+		},
+	}
+	scopes := map[string]string{
+		"cloud.google.com/service_account/v1": "name@project-id.iam.gserviceaccount.com",
+	}
+	evidence := intoto.ResourceDescriptor{
+		Digest: intoto.DigestSet{
+			"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51",
+		},
+		Annotations: map[string]interface{}{
+			"predicateType": "https://slsa.dev/provenance/v1",
+		},
+	}
+	tests := []struct {
+		name            string
+		options         []AttestationCreationOption
+		decisionDetails *decisionDetails
+		expected        error
+	}{
+		{
+			name:    "single evidence",
+			options: []AttestationCreationOption{AddEvidence(evidence)},
+			decisionDetails: &decisionDetails{
+				Evidence: []intoto.ResourceDescriptor{evidence},
+			},
+		},
+		{
+			name:    "multiple options",
+			options: []AttestationCreationOption{AddEvidence(evidence), AddEvidence(intoto.ResourceDescriptor{URI: "https://example.com/att"})},
+			decisionDetails: &decisionDetails{
+				Evidence: []intoto.ResourceDescriptor{evidence, {URI: "https://example.com/att"}},
+			},
+		},
+		{
+			name:    "no evidence",
+			options: []AttestationCreationOption{AddEvidence()},
+		},
+		{
+			name:     "empty evidence",
+			options:  []AttestationCreationOption{AddEvidence(intoto.ResourceDescriptor{Name: "name"})},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "safe mode",
+			options:  []AttestationCreationOption{EnterSafeMode(), AddEvidence(evidence)},
+			expected: errs.ErrorInternal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			att, err := CreationNew(subject, scopes, tt.options...)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.decisionDetails, att.Predicate.DecisionDetails); diff != "" {
+				t.Fatalf("unexpected decision details (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package deployment
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 
 	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal"
 	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/options"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
 	"github.com/slsa-framework/slsa-policy/pkg/errs"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator"
@@ -21,20 +23,144 @@ type AttestationVerifierPublishOptions struct {
 
 // AttestationVerifier defines an interface to verify attestations.
 type AttestationVerifier interface {
+	// Publish attestation verification. It returns the result
+	// of the verification of the publish attestation.
+	VerifyPublishAttestation(digests intoto.DigestSet, packageURI string, environment []string, opts AttestationVerifierPublishOptions) (PublishVerificationResult, error)
+}
+
+// LegacyAttestationVerifier defines the interface of verifiers
+// that only return the environment.
+type LegacyAttestationVerifier interface {
 	// Publish attestation verification. The string returned contains the value of the environment, if present.
 	VerifyPublishAttestation(digests intoto.DigestSet, packageURI string, environment []string, opts AttestationVerifierPublishOptions) (*string, error)
 }
 
+// AttestationVerifierFromLegacy returns an AttestationVerifier for a LegacyAttestationVerifier.
+// The results only contain the environment.
+func AttestationVerifierFromLegacy(verifier LegacyAttestationVerifier) AttestationVerifier {
+	return &legacyVerifier{verifier: verifier}
+}
+
+type legacyVerifier struct {
+	verifier LegacyAttestationVerifier
+}
+
+func (v *legacyVerifier) VerifyPublishAttestation(digests intoto.DigestSet, packageURI string, environment []string, opts AttestationVerifierPublishOptions) (PublishVerificationResult, error) {
+	if v.verifier == nil {
+		return PublishVerificationResult{}, fmt.Errorf("%w: verifier is nil", errs.ErrorInvalidInput)
+	}
+	env, err := v.verifier.VerifyPublishAttestation(digests, packageURI, environment, opts)
+	if err != nil {
+		return PublishVerificationResult{}, err
+	}
+	return PublishVerificationResult{
+		Environment: env,
+	}, nil
+}
+
+// PublishVerificationResult defines the result of a publish attestation verification.
+type PublishVerificationResult struct {
+	// Environment contains the value of the environment, if present.
+	Environment *string
+	// PredicateType is the publish attestation's predicate type.
+	PredicateType string
+	// Predicate is the publish attestation's predicate.
+	Predicate json.RawMessage
+	// AttestationDigests are the digests of the verified publish attestation.
+	// If set, the attestation is recorded as evidence in the deployment attestation's
+	// decision details.
+	AttestationDigests intoto.DigestSet
+}
+
+// evidence returns the descriptor of the publish attestation
+// recorded in the decision details.
+func (r PublishVerificationResult) evidence() *intoto.ResourceDescriptor {
+	if len(r.AttestationDigests) == 0 {
+		return nil
+	}
+	evidence := intoto.ResourceDescriptor{
+		// NOTE: make a copy of the map.
+		Digest: intoto.DigestSet{},
+	}
+	for k, v := range r.AttestationDigests {
+		evidence.Digest[k] = v
+	}
+	if r.PredicateType != "" {
+		evidence.Annotations = map[string]interface{}{
+			predicateTypeAnnotation: r.PredicateType,
+		}
+	}
+	return &evidence
+}
+
 // AttestationVerificationOption defines the configuration to verify
 // publish attestations.
 type AttestationVerificationOption struct {
 	Verifier AttestationVerifier
 }
 
+// PackageOverlapMode defines how packages defined
+// in multiple project policies are reported.
+type PackageOverlapMode int
+
+const (
+	// PackageOverlapWarning reports the overlaps without failing.
+	// They are available via Policy.PackageOverlaps().
+	PackageOverlapWarning PackageOverlapMode = PackageOverlapMode(options.PackageOverlapWarning)
+	// PackageOverlapError fails the creation of the policy
+	// with an error listing all the overlaps.
+	PackageOverlapError PackageOverlapMode = PackageOverlapMode(options.PackageOverlapError)
+)
+
+// Validate validates the mode.
+func (m PackageOverlapMode) Validate() error {
+	switch m {
+	case PackageOverlapError, PackageOverlapWarning:
+		return nil
+	}
+	return fmt.Errorf("%w: invalid package overlap (%d)", errs.ErrorInvalidInput, m)
+}
+
+// PackageOverlap defines a package defined in multiple project policies
+// for the same environment. A nil environment means the package
+// is defined without environment.
+type PackageOverlap struct {
+	PackageName string
+	Environment *string
+	PolicyIDs   []string
+}
+
+// String returns a description of the overlap, as reported
+// by PolicyNew() with PackageOverlapError.
+func (o PackageOverlap) String() string {
+	return options.PackageOverlap{
+		PackageName: o.PackageName,
+		Environment: o.Environment,
+		PolicyIDs:   o.PolicyIDs,
+	}.String()
+}
+
+// DeployedPackage defines a package a project policy allows to deploy.
+type DeployedPackage struct {
+	PolicyID             string
+	Name                 string
+	Environments         []string
+	RequireSlsaLevel     int
+	GoogleServiceAccount string
+}
+
+// Publisher defines a publisher trusted by the org policy.
+type Publisher struct {
+	ID           string
+	MaxSlsaLevel int
+}
+
 // Policy defines the deployment policy.
 type Policy struct {
-	policy    *internal.Policy
-	validator options.PolicyValidator
+	policy           *internal.Policy
+	validator        options.PolicyValidator
+	digestValidation intoto.DigestValidation
+	overlapMode      PackageOverlapMode
 }
 
 // PolicyOption defines a policy option.
@@ -47,15 +173,19 @@ type internal_verifier struct {
 }
 
 func (i *internal_verifier) VerifyPublishAttestation(digests intoto.DigestSet, packageURI string,
-	environment []string, publishrID string, buildLevel int) (*string, error) {
+	environment []string, publishrID string, buildLevel int) (options.PublishVerificationResult, error) {
 	if i.opts.Verifier == nil {
-		return nil, fmt.Errorf("%w: verifier is nil", errs.ErrorInvalidInput)
+		return options.PublishVerificationResult{}, fmt.Errorf("%w: verifier is nil", errs.ErrorInvalidInput)
 	}
 	opts := AttestationVerifierPublishOptions{
 		PublishrID: publishrID,
 		BuildLevel: buildLevel,
 	}
-	return i.opts.Verifier.VerifyPublishAttestation(digests, packageURI, environment, opts)
+	result, err := i.opts.Verifier.VerifyPublishAttestation(digests, packageURI, environment, opts)
+	if err != nil {
+		return options.PublishVerificationResult{}, err
+	}
+	return options.PublishVerificationResult(result), nil
 }
 
 // This is a class to forward calls between internal
@@ -87,7 +217,7 @@ func PolicyNew(org io.ReadCloser, projects iterator.NamedReadCloserIterator, opt
 			return nil, err
 		}
 	}
-	policy, err := internal.PolicyNew(org, projects, p.validator)
+	policy, err := internal.PolicyNew(org, projects, p.validator, options.PackageOverlapMode(p.overlapMode))
 	if err != nil {
 		return nil, err
 	}
@@ -111,19 +241,110 @@ func (p *Policy) setValidator(validator PolicyValidator) error {
 	return nil
 }
 
+// SetDigestValidation sets how strictly the digests of evaluated
+// subjects are validated. The default is intoto.DigestValidationPermissive.
+func SetDigestValidation(mode intoto.DigestValidation) PolicyOption {
+	return func(p *Policy) error {
+		return p.setDigestValidation(mode)
+	}
+}
+
+func (p *Policy) setDigestValidation(mode intoto.DigestValidation) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+	p.digestValidation = mode
+	return nil
+}
+
+// SetPackageOverlap sets how packages defined in multiple project policies
+// for the same environment are reported. The default is PackageOverlapWarning.
+func SetPackageOverlap(mode PackageOverlapMode) PolicyOption {
+	return func(p *Policy) error {
+		return p.setPackageOverlap(mode)
+	}
+}
+
+func (p *Policy) setPackageOverlap(mode PackageOverlapMode) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+	p.overlapMode = mode
+	return nil
+}
+
+// PackageOverlaps returns the packages defined in multiple project policies
+// for the same environment. With PackageOverlapError, PolicyNew() fails instead.
+func (p *Policy) PackageOverlaps() []PackageOverlap {
+	overlaps := p.policy.PackageOverlaps()
+	if len(overlaps) == 0 {
+		return nil
+	}
+	res := make([]PackageOverlap, len(overlaps))
+	for i := range overlaps {
+		overlap := overlaps[i]
+		res[i] = PackageOverlap{
+			PackageName: overlap.PackageName,
+			// NOTE: make a copy of the array.
+			PolicyIDs: append([]string{}, overlap.PolicyIDs...),
+		}
+		if overlap.Environment != nil {
+			env := *overlap.Environment
+			res[i].Environment = &env
+		}
+	}
+	return res
+}
+
 // Evaluate evalues the deployment policy.
 func (p *Policy) Evaluate(digests intoto.DigestSet, policyPackageName string, policyID string, opts AttestationVerificationOption) PolicyEvaluationResult {
-	protection, err := p.policy.Evaluate(digests, policyPackageName, policyID,
-		options.PublishVerification{
-			Verifier: &internal_verifier{
-				opts: opts,
+	return p.EvaluateSubjects([]intoto.Subject{{Digests: digests}}, policyPackageName, policyID, opts)
+}
+
+// EvaluateSubjects evaluates the deployment policy for a set of subjects
+// of the same package, e.g. a multi-arch image index and its platform
+// manifests. Each subject is evaluated independently and the attestation
+// covers all subjects.
+func (p *Policy) EvaluateSubjects(subjects []intoto.Subject, policyPackageName string, policyID string, opts AttestationVerificationOption) PolicyEvaluationResult {
+	if len(subjects) == 0 {
+		return PolicyEvaluationResult{
+			err: fmt.Errorf("%w: no subjects", errs.ErrorInvalidInput),
+		}
+	}
+	// Validate and normalize the digests.
+	subjects, err := intoto.NormalizeSubjects(subjects, p.digestValidation)
+	if err != nil {
+		return PolicyEvaluationResult{
+			err: err,
+		}
+	}
+	var protection *project.Protection
+	verifications := make([]PublishVerificationResult, len(subjects))
+	for i := range subjects {
+		subject := &subjects[i]
+		subjectProtection, subjectResult, err := p.policy.Evaluate(subject.Digests, policyPackageName, policyID,
+			options.PublishVerification{
+				Verifier: &internal_verifier{
+					opts: opts,
+				},
 			},
-		},
-	)
+		)
+		if err != nil {
+			return PolicyEvaluationResult{
+				err:     err,
+				digests: subject.Digests,
+			}
+		}
+		// NOTE: all subjects are evaluated against the same policy ID.
+		protection = subjectProtection
+		verifications[i] = PublishVerificationResult(subjectResult)
+	}
 	return PolicyEvaluationResult{
-		err:        err,
-		digests:    digests,
-		protection: protection,
+		digests:          subjects[0].Digests,
+		subjects:         subjects,
+		protection:       protection,
+		verifications:    verifications,
+		digestValidation: p.digestValidation,
 	}
 }
 
@@ -131,3 +352,35 @@ func (p *Policy) Evaluate(digests intoto.DigestSet, policyPackageName string, po
 func PredicateType() string {
 	return predicateType
 }
+
+// Publishers returns the publishers trusted by the org policy.
+func (p *Policy) Publishers() []Publisher {
+	publishers := p.policy.Publishers()
+	res := make([]Publisher, len(publishers))
+	for i := range publishers {
+		res[i] = Publisher(publishers[i])
+	}
+	return res
+}
+
+// DeployedPackages returns the packages of the project policies,
+// sorted by policy ID and package name.
+func (p *Policy) DeployedPackages() []DeployedPackage {
+	packages := p.policy.DeployedPackages()
+	if len(packages) == 0 {
+		return nil
+	}
+	res := make([]DeployedPackage, len(packages))
+	for i := range packages {
+		pkg := packages[i]
+		res[i] = DeployedPackage{
+			PolicyID: pkg.PolicyID,
+			Name:     pkg.Name,
+			// NOTE: make a copy of the array.
+			Environments:         append([]string{}, pkg.Environments...),
+			RequireSlsaLevel:     pkg.RequireSlsaLevel,
+			GoogleServiceAccount: pkg.GoogleServiceAccount,
+		}
+	}
+	return res
+}
@@ -20,18 +20,18 @@ import (
 func Test_AttestationNew(t *testing.T) {
 	t.Parallel()
 	digests := intoto.DigestSet{
-		"sha256":    "some_value",
-		"gitCommit": "another_value",
+		"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+		"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
 	}
 	subject := intoto.Subject{
 		Digests: digests,
 	}
-	principal := project.Principal{
-		URI: "principal_uri",
+	protection := project.Protection{
+		GoogleServiceAccount: "name@project-id.iam.gserviceaccount.com",
 	}
 	result := PolicyEvaluationResult{
-		digests:   digests,
-		principal: &principal,
+		digests:    digests,
+		protection: &protection,
 	}
 	opts := []AttestationCreationOption{}
 	tests := []struct {
@@ -82,7 +82,7 @@ func Test_AttestationNew(t *testing.T) {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
 			c := map[string]string{
-				scopeKubernetesServiceAccount: tt.result.principal.URI,
+				scopeGoogleServiceAccount: tt.result.protection.GoogleServiceAccount,
 			}
 			if diff := cmp.Diff(c, att.attestation.Predicate.Scopes); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
@@ -104,17 +104,17 @@ type attestationVerifier struct {
 	digests     intoto.DigestSet
 }
 
-func (v *attestationVerifier) VerifyPublishAttestation(digests intoto.DigestSet, packageName string, env []string, opts AttestationVerifierPublishOptions) (*string, error) {
+func (v *attestationVerifier) VerifyPublishAttestation(digests intoto.DigestSet, packageName string, env []string, opts AttestationVerifierPublishOptions) (PublishVerificationResult, error) {
 	if opts.BuildLevel == v.buildLevel && packageName == v.packageName && opts.PublishrID == v.publishrID &&
 		common.MapEq(digests, v.digests) &&
 		((v.env != "" && len(env) > 0 && slices.Contains(env, v.env)) ||
 			(v.env == "" && len(env) == 0)) {
 		if v.env == "" {
-			return nil, nil
+			return PublishVerificationResult{}, nil
 		}
-		return &v.env, nil
+		return PublishVerificationResult{Environment: &v.env}, nil
 	}
-	return nil, fmt.Errorf("%w: cannot verify package Name (%q) publishr ID (%q) env (%q) buildLevel (%d)", errs.ErrorVerification, packageName, opts.PublishrID, env, opts.BuildLevel)
+	return PublishVerificationResult{}, fmt.Errorf("%w: cannot verify package Name (%q) publishr ID (%q) env (%q) buildLevel (%d)", errs.ErrorVerification, packageName, opts.PublishrID, env, opts.BuildLevel)
 }
 
 func newPolicyValidator(pass bool) PolicyValidator {
@@ -135,8 +135,8 @@ func (v *policyValidator) ValidatePackage(pkg ValidationPackage) error {
 func Test_e2e(t *testing.T) {
 	t.Parallel()
 	digests := intoto.DigestSet{
-		"sha256": "val256",
-		"sha512": "val512",
+		"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
+		"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
 	}
 	publishrID1 := "publishr_id1"
 	publishrID2 := "publishr_id2"
@@ -144,8 +144,8 @@ func Test_e2e(t *testing.T) {
 	packageName2 := "package_uri2"
 	packageName3 := "package_uri3"
 	packageName4 := "package_uri4"
-	pricipalURI1 := "principal_uri1"
-	pricipalURI2 := "principal_uri2"
+	serviceAccount1 := "name1@project-id.iam.gserviceaccount.com"
+	serviceAccount2 := "name2@project-id.iam.gserviceaccount.com"
 	// NOTE: the test iterator indexes policies starting at 0.
 	policyID2 := "policy_id1"
 	org := organization.Policy{
@@ -173,8 +173,8 @@ func Test_e2e(t *testing.T) {
 			BuildRequirements: project.BuildRequirements{
 				RequireSlsaLevel: common.AsPointer(2),
 			},
-			Principal: project.Principal{
-				URI: pricipalURI1,
+			Protection: project.Protection{
+				GoogleServiceAccount: serviceAccount1,
 			},
 			Packages: []project.Package{
 				{
@@ -193,8 +193,8 @@ func Test_e2e(t *testing.T) {
 		},
 		{
 			Format: 1,
-			Principal: project.Principal{
-				URI: pricipalURI2,
+			Protection: project.Protection{
+				GoogleServiceAccount: serviceAccount2,
 			},
 			BuildRequirements: project.BuildRequirements{
 				RequireSlsaLevel: common.AsPointer(3),
@@ -228,7 +228,7 @@ func Test_e2e(t *testing.T) {
 		env              string
 		buildLevel       int
 		publishrID       string
-		principalURI     string
+		serviceAccount   string
 		expected         error
 		errorEvaluate    error
 		errorAttestation error
@@ -244,9 +244,9 @@ func Test_e2e(t *testing.T) {
 			options: opts,
 			env:     "prod",
 			// Fields to validate the created attestation.
-			digests:      digests,
-			packageName:  packageName1,
-			principalURI: pricipalURI2,
+			digests:        digests,
+			packageName:    packageName1,
+			serviceAccount: serviceAccount2,
 			// Data that the verifier will use.
 			publishrID: publishrID2,
 			buildLevel: buildLevel3,
@@ -260,9 +260,9 @@ func Test_e2e(t *testing.T) {
 			// Options to create the attestation.
 			options: opts,
 			// Fields to validate the created attestation.
-			digests:      digests,
-			packageName:  packageName1,
-			principalURI: pricipalURI2,
+			digests:        digests,
+			packageName:    packageName1,
+			serviceAccount: serviceAccount2,
 			// Data that the verifier will use.
 			publishrID:       publishrID2,
 			buildLevel:       buildLevel3,
@@ -279,8 +279,8 @@ func Test_e2e(t *testing.T) {
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(2),
 					},
-					Principal: project.Principal{
-						URI: pricipalURI1,
+					Protection: project.Protection{
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -299,8 +299,8 @@ func Test_e2e(t *testing.T) {
 				},
 				{
 					Format: 1,
-					Principal: project.Principal{
-						URI: pricipalURI2,
+					Protection: project.Protection{
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -324,9 +324,9 @@ func Test_e2e(t *testing.T) {
 			options: opts,
 			env:     "prod",
 			// Fields to validate the created attestation.
-			digests:      digests,
-			packageName:  packageName1,
-			principalURI: pricipalURI2,
+			digests:        digests,
+			packageName:    packageName1,
+			serviceAccount: serviceAccount2,
 			// Data that the verifier will use.
 			publishrID:       publishrID2,
 			buildLevel:       buildLevel3,
@@ -343,9 +343,9 @@ func Test_e2e(t *testing.T) {
 			options: opts,
 			env:     "mismatch",
 			// Fields to validate the created attestation.
-			digests:      digests,
-			packageName:  packageName1,
-			principalURI: pricipalURI2,
+			digests:        digests,
+			packageName:    packageName1,
+			serviceAccount: serviceAccount2,
 			// Data that the verifier will use.
 			publishrID:       publishrID2,
 			buildLevel:       buildLevel3,
@@ -362,8 +362,8 @@ func Test_e2e(t *testing.T) {
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(2),
 					},
-					Principal: project.Principal{
-						URI: pricipalURI1,
+					Protection: project.Protection{
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -382,8 +382,8 @@ func Test_e2e(t *testing.T) {
 				},
 				{
 					Format: 1,
-					Principal: project.Principal{
-						URI: pricipalURI2,
+					Protection: project.Protection{
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -406,9 +406,9 @@ func Test_e2e(t *testing.T) {
 			// Options to create the attestation.
 			options: opts,
 			// Fields to validate the created attestation.
-			digests:      digests,
-			packageName:  packageName1,
-			principalURI: pricipalURI2,
+			digests:        digests,
+			packageName:    packageName1,
+			serviceAccount: serviceAccount2,
 			// Data that the verifier will use.
 			publishrID: publishrID2,
 			buildLevel: buildLevel3,
@@ -423,9 +423,9 @@ func Test_e2e(t *testing.T) {
 			options: opts,
 			env:     "prod",
 			// Fields to validate the created attestation.
-			digests:      digests,
-			packageName:  packageName1,
-			principalURI: pricipalURI2,
+			digests:        digests,
+			packageName:    packageName1,
+			serviceAccount: serviceAccount2,
 			// Data that the verifier will use.
 			publishrID:       publishrID1, // NOTE: mismatch publishr ID.
 			buildLevel:       buildLevel3,
@@ -505,7 +505,7 @@ func Test_e2e(t *testing.T) {
 			options := []VerificationOption{}
 			// Verify.
 			scopes := map[string]string{
-				scopeKubernetesServiceAccount: tt.principalURI,
+				scopeGoogleServiceAccount: tt.serviceAccount,
 			}
 			err = verification.Verify(tt.digests, scopes, options...)
 			if diff := cmp.Diff(tt.errorVerify, err, cmpopts.EquateErrors()); diff != "" {
@@ -514,3 +514,39 @@ func Test_e2e(t *testing.T) {
 		})
 	}
 }
+
+func Test_PackageOverlapString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		overlap  PackageOverlap
+		expected string
+	}{
+		{
+			name: "without environment",
+			overlap: PackageOverlap{
+				PackageName: "package_name",
+				PolicyIDs:   []string{"policy_id1", "policy_id2"},
+			},
+			expected: `package ("package_name") without environment is defined in policies (["policy_id1" "policy_id2"])`,
+		},
+		{
+			name: "with environment",
+			overlap: PackageOverlap{
+				PackageName: "package_name",
+				Environment: common.AsPointer("prod"),
+				PolicyIDs:   []string{"policy_id1", "policy_id2"},
+			},
+			expected: `package ("package_name") for environment ("prod") is defined in policies (["policy_id1" "policy_id2"])`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.expected, tt.overlap.String()); diff != "" {
+				t.Fatalf("unexpected string (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -63,17 +63,17 @@ type attestationVerifier struct {
 	digests     intoto.DigestSet
 }
 
-func (v *attestationVerifier) VerifyPublishAttestation(digests intoto.DigestSet, packageName string, env []string, publishrID string, buildLevel int) (*string, error) {
+func (v *attestationVerifier) VerifyPublishAttestation(digests intoto.DigestSet, packageName string, env []string, publishrID string, buildLevel int) (options.PublishVerificationResult, error) {
 	if buildLevel <= v.buildLevel && packageName == v.packageName && publishrID == v.publishrID &&
 		MapEq(digests, v.digests) &&
 		((v.env != "" && len(env) > 0 && slices.Contains(env, v.env)) ||
 			(v.env == "" && len(env) == 0)) {
 		if v.env == "" {
-			return nil, nil
+			return options.PublishVerificationResult{}, nil
 		}
-		return &v.env, nil
+		return options.PublishVerificationResult{Environment: &v.env}, nil
 	}
-	return nil, fmt.Errorf("%w: cannot verify package Name (%q) publishr ID (%q) env (%q) buildLevel (%d)", errs.ErrorVerification, packageName, publishrID, env, buildLevel)
+	return options.PublishVerificationResult{}, fmt.Errorf("%w: cannot verify package Name (%q) publishr ID (%q) env (%q) buildLevel (%d)", errs.ErrorVerification, packageName, publishrID, env, buildLevel)
 }
 
 func MapEq(m1, m2 map[string]string) bool {
@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/diff"
+)
+
+// Diff returns the semantic changes from the old policy to the new policy,
+// sorted by subject. Project changes have the policy ID as subject.
+func Diff(old, new *Policy) []diff.Change {
+	changes := diffOrg(old.orgPolicy, new.orgPolicy)
+	for id, oldProject := range old.projectPolicies {
+		newProject, exists := new.projectPolicies[id]
+		if !exists {
+			changes = append(changes, diff.Change{
+				Kind:    diff.KindRemoved,
+				Subject: id,
+				Field:   "policy",
+			})
+			continue
+		}
+		changes = append(changes, diffProject(id, oldProject, newProject)...)
+	}
+	for id := range new.projectPolicies {
+		if _, exists := old.projectPolicies[id]; !exists {
+			changes = append(changes, diff.Change{
+				Kind:    diff.KindAdded,
+				Subject: id,
+				Field:   "policy",
+			})
+		}
+	}
+	diff.Sort(changes)
+	return changes
+}
+
+func diffOrg(old, new organization.Policy) []diff.Change {
+	var changes []diff.Change
+	oldRoots := make(map[string]organization.Root, len(old.Roots.Publish))
+	for _, root := range old.Roots.Publish {
+		oldRoots[root.ID] = root
+	}
+	newRoots := make(map[string]organization.Root, len(new.Roots.Publish))
+	for _, root := range new.Roots.Publish {
+		newRoots[root.ID] = root
+		oldRoot, exists := oldRoots[root.ID]
+		if !exists {
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindAdded,
+				Field:     "publisher",
+				New:       rootString(root),
+				Weakening: true,
+			})
+			continue
+		}
+		if *oldRoot.Build.MaxSlsaLevel != *root.Build.MaxSlsaLevel {
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindModified,
+				Field:     fmt.Sprintf("publisher[%s].max_slsa_level", root.ID),
+				Old:       fmt.Sprint(*oldRoot.Build.MaxSlsaLevel),
+				New:       fmt.Sprint(*root.Build.MaxSlsaLevel),
+				Weakening: *root.Build.MaxSlsaLevel > *oldRoot.Build.MaxSlsaLevel,
+			})
+		}
+	}
+	for _, root := range old.Roots.Publish {
+		if _, exists := newRoots[root.ID]; !exists {
+			changes = append(changes, diff.Change{
+				Kind:  diff.KindRemoved,
+				Field: "publisher",
+				Old:   rootString(root),
+			})
+		}
+	}
+	if oldLevel, newLevel := old.MinRequireSlsaLevel(), new.MinRequireSlsaLevel(); oldLevel != newLevel {
+		changes = append(changes, diff.Change{
+			Kind:      diff.KindModified,
+			Field:     "minimums.require_slsa_level",
+			Old:       fmt.Sprint(oldLevel),
+			New:       fmt.Sprint(newLevel),
+			Weakening: newLevel < oldLevel,
+		})
+	}
+	return changes
+}
+
+func rootString(root organization.Root) string {
+	return fmt.Sprintf("%s (max level %d)", root.ID, *root.Build.MaxSlsaLevel)
+}
+
+func diffProject(id string, old, new project.Policy) []diff.Change {
+	var changes []diff.Change
+	if old.Protection.GoogleServiceAccount != new.Protection.GoogleServiceAccount {
+		// NOTE: a different service account may now deploy the packages.
+		changes = append(changes, diff.Change{
+			Kind:      diff.KindModified,
+			Subject:   id,
+			Field:     "protection.google_service_account",
+			Old:       old.Protection.GoogleServiceAccount,
+			New:       new.Protection.GoogleServiceAccount,
+			Weakening: true,
+		})
+	}
+	oldLevel, newLevel := *old.BuildRequirements.RequireSlsaLevel, *new.BuildRequirements.RequireSlsaLevel
+	if oldLevel != newLevel {
+		changes = append(changes, diff.Change{
+			Kind:      diff.KindModified,
+			Subject:   id,
+			Field:     "build.require_slsa_level",
+			Old:       fmt.Sprint(oldLevel),
+			New:       fmt.Sprint(newLevel),
+			Weakening: newLevel < oldLevel,
+		})
+	}
+
+	oldPackages := make(map[string]project.Package, len(old.Packages))
+	for _, pkg := range old.Packages {
+		oldPackages[pkg.Name] = pkg
+	}
+	newPackages := make(map[string]project.Package, len(new.Packages))
+	for _, pkg := range new.Packages {
+		newPackages[pkg.Name] = pkg
+		oldPkg, exists := oldPackages[pkg.Name]
+		if !exists {
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindAdded,
+				Subject:   id,
+				Field:     "package",
+				New:       pkg.Name,
+				Weakening: true,
+			})
+			continue
+		}
+		changes = append(changes, diffEnvironments(id, pkg.Name, oldPkg.Environment.AnyOf, pkg.Environment.AnyOf)...)
+	}
+	for _, pkg := range old.Packages {
+		if _, exists := newPackages[pkg.Name]; !exists {
+			changes = append(changes, diff.Change{
+				Kind:    diff.KindRemoved,
+				Subject: id,
+				Field:   "package",
+				Old:     pkg.Name,
+			})
+		}
+	}
+	return changes
+}
+
+func diffEnvironments(id, packageName string, oldEnvs, newEnvs []string) []diff.Change {
+	field := fmt.Sprintf("package[%s].environment", packageName)
+	if len(oldEnvs) > 0 && len(newEnvs) == 0 {
+		// The package is now deployed without environment.
+		return []diff.Change{
+			{
+				Kind:      diff.KindModified,
+				Subject:   id,
+				Field:     field,
+				Old:       strings.Join(oldEnvs, ","),
+				Weakening: true,
+			},
+		}
+	}
+	var changes []diff.Change
+	removed, added := diff.Strings(oldEnvs, newEnvs)
+	for _, env := range added {
+		changes = append(changes, diff.Change{
+			Kind:      diff.KindAdded,
+			Subject:   id,
+			Field:     field,
+			New:       env,
+			Weakening: len(oldEnvs) > 0,
+		})
+	}
+	for _, env := range removed {
+		changes = append(changes, diff.Change{
+			Kind:    diff.KindRemoved,
+			Subject: id,
+			Field:   field,
+			Old:     env,
+		})
+	}
+	return changes
+}
@@ -0,0 +1,391 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/options"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/diff"
+)
+
+func Test_Diff(t *testing.T) {
+	t.Parallel()
+	org := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Publish: []organization.Root{
+				{
+					ID: "publisher_id1",
+					Build: organization.Build{
+						MaxSlsaLevel: common.AsPointer(2),
+					},
+				},
+				{
+					ID: "publisher_id2",
+					Build: organization.Build{
+						MaxSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+		},
+	}
+	project1 := project.Policy{
+		Format: 1,
+		Protection: project.Protection{
+			GoogleServiceAccount: "name1@project.iam.gserviceaccount.com",
+		},
+		Packages: []project.Package{
+			{
+				Name: "package_name1",
+				Environment: project.Environment{
+					AnyOf: []string{"dev", "prod"},
+				},
+			},
+			{
+				Name: "package_name2",
+				Environment: project.Environment{
+					AnyOf: []string{"prod"},
+				},
+			},
+		},
+		BuildRequirements: project.BuildRequirements{
+			RequireSlsaLevel: common.AsPointer(3),
+		},
+	}
+	tests := []struct {
+		name        string
+		oldOrg      organization.Policy
+		newOrg      organization.Policy
+		oldProjects []project.Policy
+		newProjects []project.Policy
+		expected    []diff.Change
+	}{
+		{
+			name:        "no changes",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1},
+			newProjects: []project.Policy{project1},
+		},
+		{
+			name:   "org changes",
+			oldOrg: org,
+			newOrg: organization.Policy{
+				Format: 1,
+				Roots: organization.Roots{
+					Publish: []organization.Root{
+						{
+							ID: "publisher_id1",
+							Build: organization.Build{
+								MaxSlsaLevel: common.AsPointer(3),
+							},
+						},
+						{
+							ID: "publisher_id3",
+							Build: organization.Build{
+								MaxSlsaLevel: common.AsPointer(3),
+							},
+						},
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindAdded,
+					Field:     "publisher",
+					New:       "publisher_id3 (max level 3)",
+					Weakening: true,
+				},
+				{
+					Kind:  diff.KindRemoved,
+					Field: "publisher",
+					Old:   "publisher_id2 (max level 3)",
+				},
+				{
+					Kind:      diff.KindModified,
+					Field:     "publisher[publisher_id1].max_slsa_level",
+					Old:       "2",
+					New:       "3",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:   "minimum raised",
+			oldOrg: org,
+			newOrg: organization.Policy{
+				Format: 1,
+				Roots:  org.Roots,
+				Minimums: &organization.Minimums{
+					Build: &organization.BuildMinimums{
+						RequireSlsaLevel: common.AsPointer(2),
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:  diff.KindModified,
+					Field: "minimums.require_slsa_level",
+					Old:   "0",
+					New:   "2",
+				},
+			},
+		},
+		{
+			name:   "publisher added",
+			oldOrg: org,
+			newOrg: organization.Policy{
+				Format: 1,
+				Roots: organization.Roots{
+					Publish: append(append([]organization.Root{}, org.Roots.Publish...), organization.Root{
+						ID: "publisher_id3",
+						Build: organization.Build{
+							MaxSlsaLevel: common.AsPointer(3),
+						},
+					}),
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindAdded,
+					Field:     "publisher",
+					New:       "publisher_id3 (max level 3)",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:        "service account changed",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1},
+			newProjects: []project.Policy{
+				{
+					Format: 1,
+					Protection: project.Protection{
+						GoogleServiceAccount: "other@project.iam.gserviceaccount.com",
+					},
+					Packages:          project1.Packages,
+					BuildRequirements: project1.BuildRequirements,
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Subject:   "policy_id0",
+					Field:     "protection.google_service_account",
+					Old:       "name1@project.iam.gserviceaccount.com",
+					New:       "other@project.iam.gserviceaccount.com",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:        "package added",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1},
+			newProjects: []project.Policy{
+				{
+					Format:     1,
+					Protection: project1.Protection,
+					Packages: append(append([]project.Package{}, project1.Packages...), project.Package{
+						Name: "package_name3",
+					}),
+					BuildRequirements: project1.BuildRequirements,
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindAdded,
+					Subject:   "policy_id0",
+					Field:     "package",
+					New:       "package_name3",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:        "policies added and removed",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1},
+			newProjects: []project.Policy{
+				project1,
+				{
+					Format: 1,
+					Protection: project.Protection{
+						GoogleServiceAccount: "name2@project.iam.gserviceaccount.com",
+					},
+					Packages: []project.Package{
+						{
+							Name: "package_name3",
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:    diff.KindAdded,
+					Subject: "policy_id1",
+					Field:   "policy",
+				},
+			},
+		},
+		{
+			name:        "project changes",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1},
+			newProjects: []project.Policy{
+				{
+					Format: 1,
+					Protection: project.Protection{
+						GoogleServiceAccount: "other@project.iam.gserviceaccount.com",
+					},
+					Packages: []project.Package{
+						{
+							Name: "package_name1",
+							Environment: project.Environment{
+								AnyOf: []string{"prod", "staging"},
+							},
+						},
+						{
+							Name: "package_name3",
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(2),
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Subject:   "policy_id0",
+					Field:     "build.require_slsa_level",
+					Old:       "3",
+					New:       "2",
+					Weakening: true,
+				},
+				{
+					Kind:      diff.KindAdded,
+					Subject:   "policy_id0",
+					Field:     "package",
+					New:       "package_name3",
+					Weakening: true,
+				},
+				{
+					Kind:    diff.KindRemoved,
+					Subject: "policy_id0",
+					Field:   "package",
+					Old:     "package_name2",
+				},
+				{
+					Kind:      diff.KindAdded,
+					Subject:   "policy_id0",
+					Field:     "package[package_name1].environment",
+					New:       "staging",
+					Weakening: true,
+				},
+				{
+					Kind:    diff.KindRemoved,
+					Subject: "policy_id0",
+					Field:   "package[package_name1].environment",
+					Old:     "dev",
+				},
+				{
+					Kind:      diff.KindModified,
+					Subject:   "policy_id0",
+					Field:     "protection.google_service_account",
+					Old:       "name1@project.iam.gserviceaccount.com",
+					New:       "other@project.iam.gserviceaccount.com",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:        "environment removed",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1},
+			newProjects: []project.Policy{
+				{
+					Format: 1,
+					Protection: project.Protection{
+						GoogleServiceAccount: "name1@project.iam.gserviceaccount.com",
+					},
+					Packages: []project.Package{
+						{
+							Name: "package_name1",
+							Environment: project.Environment{
+								AnyOf: []string{"dev", "prod"},
+							},
+						},
+						{
+							Name: "package_name2",
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Subject:   "policy_id0",
+					Field:     "package[package_name2].environment",
+					Old:       "prod",
+					Weakening: true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			oldPolicy := newDiffPolicy(t, tt.oldOrg, tt.oldProjects)
+			newPolicy := newDiffPolicy(t, tt.newOrg, tt.newProjects)
+			changes := Diff(oldPolicy, newPolicy)
+			if diff := cmp.Diff(tt.expected, changes); diff != "" {
+				t.Fatalf("unexpected changes (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func newDiffPolicy(t *testing.T, org organization.Policy, projects []project.Policy) *Policy {
+	// Marshal the org policy.
+	content, err := json.Marshal(org)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	orgReader := io.NopCloser(bytes.NewReader(content))
+	// Marshal the project policies into bytes.
+	policies := make([][]byte, len(projects), len(projects))
+	for i := range projects {
+		content, err := json.Marshal(projects[i])
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		policies[i] = content
+	}
+	policy, err := PolicyNew(orgReader, common.NewNamedBytesIterator(policies, true), nil, options.PackageOverlapWarning)
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	return policy
+}
@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+// recommendedSlsaLevel is the SLSA build level below which publishers are reported.
+const recommendedSlsaLevel = 3
+
+// Lint rules of the deployment policy.
+var (
+	ruleLevelBelowOrgMax = lint.Rule{
+		ID:          "DEP001",
+		Name:        "level-below-org-max",
+		Description: "A project requires a SLSA level lower than the highest level of the org publishers.",
+		Severity:    lint.SeverityWarning,
+	}
+	ruleLowLevelPublisher = lint.Rule{
+		ID:          "DEP002",
+		Name:        "low-level-publisher",
+		Description: fmt.Sprintf("The org policy trusts a publisher whose max SLSA level is lower than %d.", recommendedSlsaLevel),
+		Severity:    lint.SeverityNote,
+	}
+	rulePackageWithoutEnvironment = lint.Rule{
+		ID:          "DEP003",
+		Name:        "package-without-environment",
+		Description: "A package is deployed without environment, so it is not restricted to an environment, e.g. prod.",
+		Severity:    lint.SeverityWarning,
+	}
+	ruleUnusedEnvironment = lint.Rule{
+		ID:          "DEP004",
+		Name:        "unused-environment",
+		Description: "An environment is used by a single project policy, which may be a typo.",
+		Severity:    lint.SeverityNote,
+	}
+	rulePackageOverlap = lint.Rule{
+		ID:          "DEP005",
+		Name:        "package-overlap",
+		Description: "A package is defined in several project policies for the same environment.",
+		Severity:    lint.SeverityWarning,
+	}
+)
+
+// LintRules returns the lint rules of the deployment policy.
+func LintRules() []lint.Rule {
+	return []lint.Rule{
+		ruleLevelBelowOrgMax,
+		ruleLowLevelPublisher,
+		rulePackageWithoutEnvironment,
+		ruleUnusedEnvironment,
+		rulePackageOverlap,
+	}
+}
+
+func newFinding(rule lint.Rule, location, format string, a ...any) lint.Finding {
+	return lint.Finding{
+		RuleID:   rule.ID,
+		Severity: rule.Severity,
+		Location: location,
+		Message:  fmt.Sprintf(format, a...),
+	}
+}
+
+// Lint returns the findings of the lint rules, sorted by location.
+func (p *Policy) Lint() []lint.Finding {
+	var findings []lint.Finding
+	// Org rules.
+	for _, publisher := range p.orgPolicy.Roots.Publish {
+		if *publisher.Build.MaxSlsaLevel < recommendedSlsaLevel {
+			findings = append(findings, newFinding(ruleLowLevelPublisher, "",
+				"publisher (%q) has max SLSA level %d", publisher.ID, *publisher.Build.MaxSlsaLevel))
+		}
+	}
+
+	// Project rules.
+	maxLevel := p.orgPolicy.MaxBuildSlsaLevel()
+	environments := make(map[string][]string)
+	for id, policy := range p.projectPolicies {
+		if level := *policy.BuildRequirements.RequireSlsaLevel; level < maxLevel {
+			findings = append(findings, newFinding(ruleLevelBelowOrgMax, id,
+				"required SLSA level %d is lower than the org's highest level %d", level, maxLevel))
+		}
+		for _, pkg := range policy.Packages {
+			if len(pkg.Environment.AnyOf) == 0 {
+				findings = append(findings, newFinding(rulePackageWithoutEnvironment, id,
+					"package (%q) has no environment", pkg.Name))
+			}
+			for _, env := range pkg.Environment.AnyOf {
+				if !slices.Contains(environments[env], id) {
+					environments[env] = append(environments[env], id)
+				}
+			}
+		}
+	}
+	// NOTE: with a single project policy, all environments are used once.
+	if len(p.projectPolicies) > 1 {
+		for env, ids := range environments {
+			if len(ids) == 1 {
+				findings = append(findings, newFinding(ruleUnusedEnvironment, ids[0],
+					"environment (%q) is not used by other project policies", env))
+			}
+		}
+	}
+	for _, overlap := range p.overlaps {
+		for _, id := range overlap.PolicyIDs {
+			others := slices.DeleteFunc(slices.Clone(overlap.PolicyIDs), func(other string) bool {
+				return other == id
+			})
+			if overlap.Environment == nil {
+				findings = append(findings, newFinding(rulePackageOverlap, id,
+					"package (%q) without environment is also defined in (%q)", overlap.PackageName, others))
+				continue
+			}
+			findings = append(findings, newFinding(rulePackageOverlap, id,
+				"package (%q) for environment (%q) is also defined in (%q)", overlap.PackageName, *overlap.Environment, others))
+		}
+	}
+	lint.Sort(findings)
+	return findings
+}
@@ -0,0 +1,207 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/options"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+func Test_Lint(t *testing.T) {
+	t.Parallel()
+	org := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Publish: []organization.Root{
+				{
+					ID: "publisher_id1",
+					Build: organization.Build{
+						MaxSlsaLevel: common.AsPointer(2),
+					},
+				},
+				{
+					ID: "publisher_id2",
+					Build: organization.Build{
+						MaxSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		projects []project.Policy
+		expected []lint.Finding
+	}{
+		{
+			name: "no project findings",
+			projects: []project.Policy{
+				{
+					Format: 1,
+					Protection: project.Protection{
+						GoogleServiceAccount: "service_account1",
+					},
+					Packages: []project.Package{
+						{
+							Name: "package_name1",
+							Environment: project.Environment{
+								AnyOf: []string{"dev", "prod"},
+							},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+				{
+					Format: 1,
+					Protection: project.Protection{
+						GoogleServiceAccount: "service_account2",
+					},
+					Packages: []project.Package{
+						{
+							Name: "package_name2",
+							Environment: project.Environment{
+								AnyOf: []string{"dev", "prod"},
+							},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+			expected: []lint.Finding{
+				{
+					RuleID:   ruleLowLevelPublisher.ID,
+					Severity: lint.SeverityNote,
+					Message:  `publisher ("publisher_id1") has max SLSA level 2`,
+				},
+			},
+		},
+		{
+			name: "project findings",
+			projects: []project.Policy{
+				{
+					Format: 1,
+					Protection: project.Protection{
+						GoogleServiceAccount: "service_account1",
+					},
+					Packages: []project.Package{
+						{
+							Name: "package_name1",
+						},
+						{
+							Name: "package_name2",
+							Environment: project.Environment{
+								AnyOf: []string{"dev", "prod"},
+							},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(2),
+					},
+				},
+				{
+					Format: 1,
+					Protection: project.Protection{
+						GoogleServiceAccount: "service_account2",
+					},
+					Packages: []project.Package{
+						{
+							Name: "package_name2",
+							Environment: project.Environment{
+								AnyOf: []string{"prod", "staging"},
+							},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+			expected: []lint.Finding{
+				{
+					RuleID:   ruleLowLevelPublisher.ID,
+					Severity: lint.SeverityNote,
+					Message:  `publisher ("publisher_id1") has max SLSA level 2`,
+				},
+				{
+					RuleID:   ruleLevelBelowOrgMax.ID,
+					Severity: lint.SeverityWarning,
+					Location: "policy_id0",
+					Message:  "required SLSA level 2 is lower than the org's highest level 3",
+				},
+				{
+					RuleID:   rulePackageWithoutEnvironment.ID,
+					Severity: lint.SeverityWarning,
+					Location: "policy_id0",
+					Message:  `package ("package_name1") has no environment`,
+				},
+				{
+					RuleID:   ruleUnusedEnvironment.ID,
+					Severity: lint.SeverityNote,
+					Location: "policy_id0",
+					Message:  `environment ("dev") is not used by other project policies`,
+				},
+				{
+					RuleID:   rulePackageOverlap.ID,
+					Severity: lint.SeverityWarning,
+					Location: "policy_id0",
+					Message:  `package ("package_name2") for environment ("prod") is also defined in (["policy_id1"])`,
+				},
+				{
+					RuleID:   ruleUnusedEnvironment.ID,
+					Severity: lint.SeverityNote,
+					Location: "policy_id1",
+					Message:  `environment ("staging") is not used by other project policies`,
+				},
+				{
+					RuleID:   rulePackageOverlap.ID,
+					Severity: lint.SeverityWarning,
+					Location: "policy_id1",
+					Message:  `package ("package_name2") for environment ("prod") is also defined in (["policy_id0"])`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Marshal the org policy.
+			content, err := json.Marshal(org)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			orgReader := io.NopCloser(bytes.NewReader(content))
+			// Marshal the project policies into bytes.
+			policies := make([][]byte, len(tt.projects), len(tt.projects))
+			for i := range tt.projects {
+				content, err := json.Marshal(tt.projects[i])
+				if err != nil {
+					t.Fatalf("failed to marshal: %v", err)
+				}
+				policies[i] = content
+			}
+			projectsReader := common.NewNamedBytesIterator(policies, true)
+			policy, err := PolicyNew(orgReader, projectsReader, nil, options.PackageOverlapWarning)
+			if err != nil {
+				t.Fatalf("failed to create policy: %v", err)
+			}
+			findings := policy.Lint()
+			if diff := cmp.Diff(tt.expected, findings); diff != "" {
+				t.Fatalf("unexpected findings (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -1,11 +1,24 @@
 package options
 
-import "github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
 
 // AttestationVerifier defines an interface to verify attestations.
 type AttestationVerifier interface {
-	// Publish attestations. The string returned contains the value of the environment, if present.
-	VerifyPublishAttestation(digests intoto.DigestSet, packageName string, environment []string, publishrID string, buildLevel int) (*string, error)
+	// Publish attestations.
+	VerifyPublishAttestation(digests intoto.DigestSet, packageName string, environment []string, publishrID string, buildLevel int) (PublishVerificationResult, error)
+}
+
+// PublishVerificationResult defines the result of a publish attestation verification.
+type PublishVerificationResult struct {
+	Environment        *string
+	PredicateType      string
+	Predicate          json.RawMessage
+	AttestationDigests intoto.DigestSet
 }
 
 // PublishVerification defines the configuration to verify
@@ -29,6 +42,51 @@ type ValidationEnvironment struct {
 	AnyOf []string
 }
 
+// PackageOverlapMode defines how packages defined
+// in multiple policies are reported.
+type PackageOverlapMode int
+
+const (
+	// PackageOverlapWarning reports the overlaps without failing.
+	PackageOverlapWarning PackageOverlapMode = iota
+	// PackageOverlapError fails the creation of the policy.
+	PackageOverlapError
+)
+
+// PackageOverlap defines a package defined in multiple policies
+// for the same environment. A nil environment means the package
+// is defined without environment.
+type PackageOverlap struct {
+	PackageName string
+	Environment *string
+	PolicyIDs   []string
+}
+
+// String returns a description of the overlap.
+func (o PackageOverlap) String() string {
+	if o.Environment == nil {
+		return fmt.Sprintf("package (%q) without environment is defined in policies (%q)",
+			o.PackageName, o.PolicyIDs)
+	}
+	return fmt.Sprintf("package (%q) for environment (%q) is defined in policies (%q)",
+		o.PackageName, *o.Environment, o.PolicyIDs)
+}
+
+// DeployedPackage defines a package a project policy allows to deploy.
+type DeployedPackage struct {
+	PolicyID             string
+	Name                 string
+	Environments         []string
+	RequireSlsaLevel     int
+	GoogleServiceAccount string
+}
+
+// Publisher defines a publisher trusted by the org policy.
+type Publisher struct {
+	ID           string
+	MaxSlsaLevel int
+}
+
 // PolicyValidator defines an interface to validate
 // certain fields in the policy.
 type PolicyValidator interface {
@@ -13,8 +13,8 @@ import (
 
 // Root defines a trusted root.
 type Root struct {
-	ID    string `json:"id"`
-	Build Build  `json:"build"`
+	ID    string `json:"id" schema:"required"`
+	Build Build  `json:"build" schema:"required"`
 	// TODO: Have a field to indicate which package Names the publishr is allowed to
 	// attest to. This assumes every organization has a central registry to make their
 	// publishs accessible.
@@ -22,18 +22,48 @@ type Root struct {
 
 // Build defines the build metadata.
 type Build struct {
-	MaxSlsaLevel *int `json:"max_slsa_level"`
+	MaxSlsaLevel *int `json:"max_slsa_level" schema:"required,enum=0|1|2|3|4"`
 }
 
 // Roots defines a set of truted roots.
 type Roots struct {
-	Publish []Root `json:"publish"`
+	Publish []Root `json:"publish" schema:"required"`
+}
+
+// Defaults defines the values of the project policies' fields
+// that the project policies do not set.
+type Defaults struct {
+	Build       *BuildDefaults       `json:"build,omitempty"`
+	Environment *EnvironmentDefaults `json:"environment,omitempty"`
+}
+
+// BuildDefaults defines the default build requirements.
+type BuildDefaults struct {
+	RequireSlsaLevel *int `json:"require_slsa_level" schema:"required,enum=0|1|2|3|4"`
+}
+
+// EnvironmentDefaults defines the environments of the packages
+// that do not set any.
+type EnvironmentDefaults struct {
+	AnyOf []string `json:"any_of" schema:"required"`
+}
+
+// Minimums defines the requirements the project policies cannot lower.
+type Minimums struct {
+	Build *BuildMinimums `json:"build,omitempty"`
+}
+
+// BuildMinimums defines the minimum build requirements.
+type BuildMinimums struct {
+	RequireSlsaLevel *int `json:"require_slsa_level" schema:"required,enum=0|1|2|3|4"`
 }
 
 // Policy defines the policy.
 type Policy struct {
-	Format int   `json:"format"`
-	Roots  Roots `json:"roots"`
+	Format   int       `json:"format" schema:"required,enum=1"`
+	Roots    Roots     `json:"roots" schema:"required"`
+	Defaults *Defaults `json:"defaults,omitempty"`
+	Minimums *Minimums `json:"minimums,omitempty"`
 }
 
 // FromReader creates a new instance of a Policy from an IO reader.
@@ -54,6 +84,11 @@ func FromReader(reader io.ReadCloser) (*Policy, error) {
 	return &org, nil
 }
 
+// Validate validates the policy, e.g. one created programmatically.
+func (p *Policy) Validate() error {
+	return p.validate()
+}
+
 // validate validates the format of the policy.
 func (p *Policy) validate() error {
 	if err := p.validateFormat(); err != nil {
@@ -62,6 +97,12 @@ func (p *Policy) validate() error {
 	if err := p.validatePublishRoots(); err != nil {
 		return err
 	}
+	if err := p.validateMinimums(); err != nil {
+		return err
+	}
+	if err := p.validateDefaults(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -106,6 +147,81 @@ func (p *Policy) validatePublishRoots() error {
 	return nil
 }
 
+func (p *Policy) validateMinimums() error {
+	if p.Minimums == nil || p.Minimums.Build == nil {
+		return nil
+	}
+	level := p.Minimums.Build.RequireSlsaLevel
+	// Level must be defined and in the correct range.
+	if level == nil {
+		return fmt.Errorf("[organization] %w: minimums's require_slsa_level is not defined", errs.ErrorInvalidField)
+	}
+	if *level < 0 || *level > 4 {
+		return fmt.Errorf("[organization] %w: minimums's require_slsa_level is invalid (%d). Must satisfy 0 <= slsa_level <= 4",
+			errs.ErrorInvalidField, *level)
+	}
+	// The minimum must be satisfiable by the publishers.
+	if *level > p.MaxBuildSlsaLevel() {
+		return fmt.Errorf("[organization] %w: minimums's require_slsa_level (%d) cannot be satisfied by publish's max level (%d)",
+			errs.ErrorInvalidField, *level, p.MaxBuildSlsaLevel())
+	}
+	return nil
+}
+
+func (p *Policy) validateDefaults() error {
+	if p.Defaults == nil {
+		return nil
+	}
+	if build := p.Defaults.Build; build != nil {
+		// Level must be defined, in the correct range and satisfy the minimum.
+		if build.RequireSlsaLevel == nil {
+			return fmt.Errorf("[organization] %w: defaults's require_slsa_level is not defined", errs.ErrorInvalidField)
+		}
+		level := *build.RequireSlsaLevel
+		if level < p.MinRequireSlsaLevel() || level > p.MaxBuildSlsaLevel() {
+			return fmt.Errorf("[organization] %w: defaults's require_slsa_level is invalid (%d). Must satisfy %d <= slsa_level <= %d",
+				errs.ErrorInvalidField, level, p.MinRequireSlsaLevel(), p.MaxBuildSlsaLevel())
+		}
+	}
+	if env := p.Defaults.Environment; env != nil {
+		// Environments must be defined and non-empty.
+		if len(env.AnyOf) == 0 {
+			return fmt.Errorf("[organization] %w: defaults's environment any_of is empty", errs.ErrorInvalidField)
+		}
+		for _, val := range env.AnyOf {
+			if val == "" {
+				return fmt.Errorf("[organization] %w: defaults's environment any_of has an empty field", errs.ErrorInvalidField)
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultRequireSlsaLevel returns the default required level, or nil if there is none.
+func (p *Policy) DefaultRequireSlsaLevel() *int {
+	if p.Defaults == nil || p.Defaults.Build == nil {
+		return nil
+	}
+	return p.Defaults.Build.RequireSlsaLevel
+}
+
+// DefaultEnvironments returns the default environments of the packages.
+func (p *Policy) DefaultEnvironments() []string {
+	if p.Defaults == nil || p.Defaults.Environment == nil {
+		return nil
+	}
+	return p.Defaults.Environment.AnyOf
+}
+
+// MinRequireSlsaLevel returns the minimum level the project policies
+// may require, or 0 if there is none.
+func (p *Policy) MinRequireSlsaLevel() int {
+	if p.Minimums == nil || p.Minimums.Build == nil || p.Minimums.Build.RequireSlsaLevel == nil {
+		return 0
+	}
+	return *p.Minimums.Build.RequireSlsaLevel
+}
+
 func (p *Policy) MaxBuildSlsaLevel() int {
 	max := -1
 	for i := range p.Roots.Publish {
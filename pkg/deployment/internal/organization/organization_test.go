@@ -304,3 +304,113 @@ func Test_Evaluate(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateDefaultsAndMinimums(t *testing.T) {
+	t.Parallel()
+
+	roots := Roots{
+		Publish: []Root{
+			{
+				ID: "publisher_id1",
+				Build: Build{
+					MaxSlsaLevel: common.AsPointer(2),
+				},
+			},
+			{
+				ID: "publisher_id2",
+				Build: Build{
+					MaxSlsaLevel: common.AsPointer(3),
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		defaults *Defaults
+		minimums *Minimums
+		expected error
+	}{
+		{
+			name: "no defaults or minimums",
+		},
+		{
+			name: "valid defaults and minimums",
+			defaults: &Defaults{
+				Build:       &BuildDefaults{RequireSlsaLevel: common.AsPointer(3)},
+				Environment: &EnvironmentDefaults{AnyOf: []string{"prod"}},
+			},
+			minimums: &Minimums{
+				Build: &BuildMinimums{RequireSlsaLevel: common.AsPointer(2)},
+			},
+		},
+		{
+			name: "default level not defined",
+			defaults: &Defaults{
+				Build: &BuildDefaults{},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "default level below minimum",
+			defaults: &Defaults{
+				Build: &BuildDefaults{RequireSlsaLevel: common.AsPointer(1)},
+			},
+			minimums: &Minimums{
+				Build: &BuildMinimums{RequireSlsaLevel: common.AsPointer(2)},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "default level above max level",
+			defaults: &Defaults{
+				Build: &BuildDefaults{RequireSlsaLevel: common.AsPointer(4)},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "empty default environments",
+			defaults: &Defaults{
+				Environment: &EnvironmentDefaults{},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "minimum not defined",
+			minimums: &Minimums{
+				Build: &BuildMinimums{},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "minimum out of range",
+			minimums: &Minimums{
+				Build: &BuildMinimums{RequireSlsaLevel: common.AsPointer(-1)},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "minimum above max level",
+			minimums: &Minimums{
+				Build: &BuildMinimums{RequireSlsaLevel: common.AsPointer(4)},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy := Policy{
+				Format:   1,
+				Roots:    roots,
+				Defaults: tt.defaults,
+				Minimums: tt.minimums,
+			}
+			err := policy.validate()
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
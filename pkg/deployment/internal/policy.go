@@ -3,6 +3,7 @@ package internal
 import (
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/options"
 	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/organization"
@@ -15,49 +16,110 @@ import (
 type Policy struct {
 	orgPolicy       organization.Policy
 	projectPolicies map[string]project.Policy
+	overlaps        []options.PackageOverlap
 }
 
-func PolicyNew(org io.ReadCloser, projects iterator.NamedReadCloserIterator, validator options.PolicyValidator) (*Policy, error) {
+func PolicyNew(org io.ReadCloser, projects iterator.NamedReadCloserIterator, validator options.PolicyValidator,
+	overlapMode options.PackageOverlapMode) (*Policy, error) {
 	orgPolicy, err := organization.FromReader(org)
 	if err != nil {
 		return nil, err
 	}
-	projectPolicies, err := project.FromReaders(projects, *orgPolicy, validator)
+	projectPolicies, overlaps, err := project.FromReaders(projects, *orgPolicy, validator, overlapMode)
 	if err != nil {
 		return nil, err
 	}
 	return &Policy{
 		orgPolicy:       *orgPolicy,
 		projectPolicies: projectPolicies,
+		overlaps:        overlaps,
 	}, nil
 }
 
-func (p *Policy) Evaluate(digests intoto.DigestSet, packageName, policyID string, publishOpts options.PublishVerification) (*project.Protection, error) {
+// PackageOverlaps returns the packages defined in multiple policies
+// for the same environment.
+func (p *Policy) PackageOverlaps() []options.PackageOverlap {
+	return p.overlaps
+}
+
+// ProjectPolicyIDs returns the IDs of the project policies, sorted.
+func (p *Policy) ProjectPolicyIDs() []string {
+	ids := make([]string, 0, len(p.projectPolicies))
+	for id := range p.projectPolicies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ProjectPolicy returns the project policy with the given ID.
+func (p *Policy) ProjectPolicy(id string) (project.Policy, bool) {
+	policy, exists := p.projectPolicies[id]
+	return policy, exists
+}
+
+// Publishers returns the publishers trusted by the org policy.
+func (p *Policy) Publishers() []options.Publisher {
+	publishers := make([]options.Publisher, len(p.orgPolicy.Roots.Publish))
+	for i, root := range p.orgPolicy.Roots.Publish {
+		publishers[i] = options.Publisher{
+			ID:           root.ID,
+			MaxSlsaLevel: *root.Build.MaxSlsaLevel,
+		}
+	}
+	return publishers
+}
+
+// DeployedPackages returns the packages of the project policies,
+// sorted by policy ID and package name.
+func (p *Policy) DeployedPackages() []options.DeployedPackage {
+	var packages []options.DeployedPackage
+	for id, policy := range p.projectPolicies {
+		for _, pkg := range policy.Packages {
+			packages = append(packages, options.DeployedPackage{
+				PolicyID:             id,
+				Name:                 pkg.Name,
+				Environments:         pkg.Environment.AnyOf,
+				RequireSlsaLevel:     *policy.BuildRequirements.RequireSlsaLevel,
+				GoogleServiceAccount: policy.Protection.GoogleServiceAccount,
+			})
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].PolicyID != packages[j].PolicyID {
+			return packages[i].PolicyID < packages[j].PolicyID
+		}
+		return packages[i].Name < packages[j].Name
+	})
+	return packages
+}
+
+func (p *Policy) Evaluate(digests intoto.DigestSet, packageName, policyID string, publishOpts options.PublishVerification) (*project.Protection, options.PublishVerificationResult, error) {
 	if packageName == "" {
-		return nil, fmt.Errorf("%w: package name is empty", errs.ErrorInvalidInput)
+		return nil, options.PublishVerificationResult{}, fmt.Errorf("%w: package name is empty", errs.ErrorInvalidInput)
 	}
 	if policyID == "" {
-		return nil, fmt.Errorf("%w: policy id is empty", errs.ErrorInvalidInput)
+		return nil, options.PublishVerificationResult{}, fmt.Errorf("%w: policy id is empty", errs.ErrorInvalidInput)
 	}
 	if err := digests.Validate(); err != nil {
-		return nil, err
+		return nil, options.PublishVerificationResult{}, err
 	}
 	// Get the project policy for the artifact.
 	projectPolicy, exists := p.projectPolicies[policyID]
 	if !exists {
-		return nil, fmt.Errorf("%w: policy id (%q) not present in project policies", errs.ErrorNotFound, policyID)
+		return nil, options.PublishVerificationResult{}, fmt.Errorf("%w: policy id (%q) not present in project policies", errs.ErrorNotFound, policyID)
 	}
 
 	// Evaluate the org policy.
 	err := p.orgPolicy.Evaluate(digests, packageName, publishOpts)
 	if err != nil {
-		return nil, err
+		return nil, options.PublishVerificationResult{}, err
 	}
 
 	// Evaluate the project policy.
-	protection, err := projectPolicy.Evaluate(digests, packageName, p.orgPolicy, publishOpts)
+	protection, result, err := projectPolicy.Evaluate(digests, packageName, p.orgPolicy, publishOpts)
 	if err != nil {
-		return nil, err
+		return nil, options.PublishVerificationResult{}, err
 	}
-	return protection, nil
+	return protection, result, nil
 }
@@ -52,7 +52,7 @@ func Test_PolicyNew(t *testing.T) {
 				RequireSlsaLevel: common.AsPointer(2),
 			},
 			Protection: project.Protection{
-				ServiceAccount: serviceAccount1,
+				GoogleServiceAccount: serviceAccount1,
 			},
 			Packages: []project.Package{
 				{
@@ -72,7 +72,7 @@ func Test_PolicyNew(t *testing.T) {
 		{
 			Format: 1,
 			Protection: project.Protection{
-				ServiceAccount: serviceAccount2,
+				GoogleServiceAccount: serviceAccount2,
 			},
 			BuildRequirements: project.BuildRequirements{
 				RequireSlsaLevel: common.AsPointer(3),
@@ -141,7 +141,7 @@ func Test_PolicyNew(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -161,7 +161,7 @@ func Test_PolicyNew(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -194,7 +194,7 @@ func Test_PolicyNew(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -214,7 +214,7 @@ func Test_PolicyNew(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -233,7 +233,7 @@ func Test_PolicyNew(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -253,7 +253,7 @@ func Test_PolicyNew(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -272,7 +272,7 @@ func Test_PolicyNew(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -292,7 +292,7 @@ func Test_PolicyNew(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -318,7 +318,7 @@ func Test_PolicyNew(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -338,7 +338,7 @@ func Test_PolicyNew(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -365,7 +365,7 @@ func Test_PolicyNew(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -513,14 +513,14 @@ func Test_PolicyNew(t *testing.T) {
 			}
 			// Create the project iterator.
 			projectsReader := common.NewNamedBytesIterator(projects, true)
-			_, err = PolicyNew(orgReader, projectsReader, nil)
+			_, err = PolicyNew(orgReader, projectsReader, nil, options.PackageOverlapWarning)
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
 			// Same policy with a passing validator.
 			orgReader = io.NopCloser(bytes.NewReader(content))
 			projectsReader = common.NewNamedBytesIterator(projects, true)
-			_, err = PolicyNew(orgReader, projectsReader, common.NewPolicyValidator(true))
+			_, err = PolicyNew(orgReader, projectsReader, common.NewPolicyValidator(true), options.PackageOverlapWarning)
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
@@ -530,7 +530,7 @@ func Test_PolicyNew(t *testing.T) {
 			// Same policy with a failing validator.
 			orgReader = io.NopCloser(bytes.NewReader(content))
 			projectsReader = common.NewNamedBytesIterator(projects, true)
-			_, err = PolicyNew(orgReader, projectsReader, common.NewPolicyValidator(false))
+			_, err = PolicyNew(orgReader, projectsReader, common.NewPolicyValidator(false), options.PackageOverlapWarning)
 			if diff := cmp.Diff(errs.ErrorInvalidField, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
@@ -548,8 +548,8 @@ func Test_Evaluate(t *testing.T) {
 		env         string
 	}
 	digests := intoto.DigestSet{
-		"sha256": "val256",
-		"sha512": "val512",
+		"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
+		"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
 	}
 	publishrID1 := "publishr_id1"
 	publishrID2 := "publishr_id2"
@@ -587,7 +587,7 @@ func Test_Evaluate(t *testing.T) {
 				RequireSlsaLevel: common.AsPointer(2),
 			},
 			Protection: project.Protection{
-				ServiceAccount: serviceAccount1,
+				GoogleServiceAccount: serviceAccount1,
 			},
 			Packages: []project.Package{
 				{
@@ -607,7 +607,7 @@ func Test_Evaluate(t *testing.T) {
 		{
 			Format: 1,
 			Protection: project.Protection{
-				ServiceAccount: serviceAccount2,
+				GoogleServiceAccount: serviceAccount2,
 			},
 			BuildRequirements: project.BuildRequirements{
 				RequireSlsaLevel: common.AsPointer(3),
@@ -669,7 +669,7 @@ func Test_Evaluate(t *testing.T) {
 			expected:    errs.ErrorInvalidField,
 			packageName: packageName2,
 			digests: intoto.DigestSet{
-				"sha256": "val256",
+				"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
 				"":       "val512",
 			},
 			policyID:     policyID2,
@@ -682,7 +682,7 @@ func Test_Evaluate(t *testing.T) {
 			expected:    errs.ErrorInvalidField,
 			packageName: packageName2,
 			digests: intoto.DigestSet{
-				"sha256": "val256",
+				"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
 				"sha512": "",
 			},
 			policyID:     policyID2,
@@ -695,8 +695,8 @@ func Test_Evaluate(t *testing.T) {
 			expected:    errs.ErrorVerification,
 			packageName: packageName2,
 			digests: intoto.DigestSet{
-				"sha256": "val256_different",
-				"sha512": "val512",
+				"sha256": "458270be419e48236a00808661ccc4c73a61103b9a54fb189175d1c4d1250902",
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
 			},
 			policyID:     policyID2,
 			verifierOpts: vopts,
@@ -708,7 +708,7 @@ func Test_Evaluate(t *testing.T) {
 			expected:    errs.ErrorVerification,
 			packageName: packageName2,
 			digests: intoto.DigestSet{
-				"sha512": "val512",
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
 			},
 			policyID:     policyID2,
 			verifierOpts: vopts,
@@ -734,7 +734,7 @@ func Test_Evaluate(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -754,7 +754,7 @@ func Test_Evaluate(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -803,7 +803,7 @@ func Test_Evaluate(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -823,7 +823,7 @@ func Test_Evaluate(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -877,7 +877,7 @@ func Test_Evaluate(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -897,7 +897,7 @@ func Test_Evaluate(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(1),
@@ -952,7 +952,7 @@ func Test_Evaluate(t *testing.T) {
 						RequireSlsaLevel: common.AsPointer(2),
 					},
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount1,
+						GoogleServiceAccount: serviceAccount1,
 					},
 					Packages: []project.Package{
 						{
@@ -972,7 +972,7 @@ func Test_Evaluate(t *testing.T) {
 				{
 					Format: 1,
 					Protection: project.Protection{
-						ServiceAccount: serviceAccount2,
+						GoogleServiceAccount: serviceAccount2,
 					},
 					BuildRequirements: project.BuildRequirements{
 						RequireSlsaLevel: common.AsPointer(3),
@@ -1017,7 +1017,7 @@ func Test_Evaluate(t *testing.T) {
 			}
 			// Create the project iterator.
 			projectsReader := common.NewNamedBytesIterator(projects, true)
-			policy, err := PolicyNew(orgReader, projectsReader, nil)
+			policy, err := PolicyNew(orgReader, projectsReader, nil, options.PackageOverlapWarning)
 			if err != nil {
 				t.Fatalf("failed to create policy: %v", err)
 			}
@@ -1030,7 +1030,7 @@ func Test_Evaluate(t *testing.T) {
 			opts := options.PublishVerification{
 				Verifier: verifier,
 			}
-			Protection, err := policy.Evaluate(tt.digests, tt.packageName, tt.policyID, opts)
+			Protection, _, err := policy.Evaluate(tt.digests, tt.packageName, tt.policyID, opts)
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
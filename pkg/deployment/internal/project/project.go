@@ -2,21 +2,24 @@ package project
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"slices"
+	"sort"
 
 	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/options"
 	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/organization"
 	"github.com/slsa-framework/slsa-policy/pkg/errs"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/owners"
 )
 
 // BuildRequirements defines the build requirements.
 type BuildRequirements struct {
-	RequireSlsaLevel *int `json:"require_slsa_level"`
+	RequireSlsaLevel *int `json:"require_slsa_level" schema:"enum=0|1|2|3|4"`
 }
 
 // Environment defines the target environment.
@@ -27,27 +30,59 @@ type Environment struct {
 // Package defines publication metadata, such as
 // the name, registry and the target environment.
 type Package struct {
-	Name        string      `json:"name"`
+	Name        string      `json:"name" schema:"required"`
 	Environment Environment `json:"environment"`
 }
 
 type Protection struct {
-	GoogleServiceAccount string `json:"google_service_account"`
+	GoogleServiceAccount string `json:"google_service_account" schema:"required"`
+}
+
+// Owners defines the team owning a policy.
+type Owners = owners.Owners
+
+// validateOwners validates the owners. Owners are optional.
+func validateOwners(o *Owners) error {
+	if err := o.Validate(); err != nil {
+		return fmt.Errorf("[project] %w", err)
+	}
+	return nil
+}
+
+// OwnersFromReader returns the owners of a policy, or nil if the policy has none.
+// It does not validate the other fields of the policy.
+func OwnersFromReader(reader io.ReadCloser) (*Owners, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("[project] failed to read: %w", err)
+	}
+	defer reader.Close()
+	var project struct {
+		Owners *Owners `json:"owners"`
+	}
+	if err := json.Unmarshal(content, &project); err != nil {
+		return nil, fmt.Errorf("[project] failed to unmarshal: %w", err)
+	}
+	if err := validateOwners(project.Owners); err != nil {
+		return nil, err
+	}
+	return project.Owners, nil
 }
 
 // Policy defines the policy.
 type Policy struct {
-	Format            int                     `json:"format"`
-	Protection        Protection              `json:"protection"`
-	Packages          []Package               `json:"packages"`
+	Format            int                     `json:"format" schema:"required,enum=1"`
+	Protection        Protection              `json:"protection" schema:"required"`
+	Packages          []Package               `json:"packages" schema:"required"`
 	BuildRequirements BuildRequirements       `json:"build"`
+	Owners            *Owners                 `json:"owners,omitempty"`
 	validator         options.PolicyValidator `json:"-"`
 }
 
 // PolicyOption defines a policy option.
 type PolicyOption func(*Policy) error
 
-func fromReader(reader io.ReadCloser, maxBuildLevel int, validator options.PolicyValidator) (*Policy, error) {
+func fromReader(reader io.ReadCloser, orgPolicy organization.Policy, validator options.PolicyValidator) (*Policy, error) {
 	// NOTE: see https://yourbasic.org/golang/io-reader-interface-explained.
 	content, err := ioutil.ReadAll(reader)
 	if err != nil {
@@ -59,14 +94,49 @@ func fromReader(reader io.ReadCloser, maxBuildLevel int, validator options.Polic
 		return nil, fmt.Errorf("[project] failed to unmarshal: %w", err)
 	}
 	project.validator = validator
-	if err := project.validate(maxBuildLevel); err != nil {
+	project.applyDefaults(orgPolicy)
+	if err := project.validate(orgPolicy); err != nil {
 		return nil, err
 	}
 	return &project, nil
 }
 
+// Validate validates the policy against the org policy, e.g. a policy created programmatically.
+// The org policy's defaults apply to the fields the policy does not set, but are not written to the policy.
+func (p *Policy) Validate(orgPolicy organization.Policy, validator options.PolicyValidator) error {
+	policy := *p
+	policy.validator = validator
+	policy.applyDefaults(orgPolicy)
+	return policy.validate(orgPolicy)
+}
+
+// applyDefaults sets the fields the policy does not set to the org policy's defaults.
+func (p *Policy) applyDefaults(orgPolicy organization.Policy) {
+	if p.BuildRequirements.RequireSlsaLevel == nil {
+		if level := orgPolicy.DefaultRequireSlsaLevel(); level != nil {
+			// NOTE: make a copy of the level.
+			level := *level
+			p.BuildRequirements.RequireSlsaLevel = &level
+		}
+	}
+	environments := orgPolicy.DefaultEnvironments()
+	if len(environments) == 0 {
+		return
+	}
+	// NOTE: make a copy of the packages, which may be shared with a caller.
+	packages := make([]Package, len(p.Packages))
+	for i := range p.Packages {
+		packages[i] = p.Packages[i]
+		if len(packages[i].Environment.AnyOf) == 0 {
+			// NOTE: make a copy of the array.
+			packages[i].Environment.AnyOf = append([]string{}, environments...)
+		}
+	}
+	p.Packages = packages
+}
+
 // validate validates the format of the policy.
-func (p *Policy) validate(maxBuildLevel int) error {
+func (p *Policy) validate(orgPolicy organization.Policy) error {
 	if err := p.validateFormat(); err != nil {
 		return err
 	}
@@ -76,9 +146,25 @@ func (p *Policy) validate(maxBuildLevel int) error {
 	if err := p.validatePackages(); err != nil {
 		return err
 	}
-	if err := p.validateBuildRequirements(maxBuildLevel); err != nil {
+	if err := p.validateBuildRequirements(orgPolicy.MaxBuildSlsaLevel()); err != nil {
 		return err
 	}
+	if err := p.validateMinimums(orgPolicy); err != nil {
+		return err
+	}
+	if err := validateOwners(p.Owners); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateMinimums validates that the required level satisfies the org policy's minimum level.
+func (p *Policy) validateMinimums(orgPolicy organization.Policy) error {
+	minLevel := orgPolicy.MinRequireSlsaLevel()
+	if *p.BuildRequirements.RequireSlsaLevel < minLevel {
+		return fmt.Errorf("[project] %w: build's require_slsa_level (%d) < org policy's minimum level (%d)",
+			errs.ErrorInvalidField, *p.BuildRequirements.RequireSlsaLevel, minLevel)
+	}
 	return nil
 }
 
@@ -119,8 +205,7 @@ func (p *Policy) validatePackages() error {
 				return fmt.Errorf("[project] %w: package's any_of value has an empty field", errs.ErrorInvalidField)
 			}
 		}
-		// TODO: validate the packages are defined in a non-overlapping way.
-
+		// NOTE: overlaps across policies are detected by FromReaders().
 		// Validate the package using the custom validator.
 		if p.validator != nil {
 			pkg := options.ValidationPackage{
@@ -159,51 +244,117 @@ func (p *Policy) validateBuildRequirements(maxBuildLevel int) error {
 }
 
 // FromReaders creates a set of policies indexed by their unique id.
-func FromReaders(readers iterator.NamedReadCloserIterator, orgPolicy organization.Policy, validator options.PolicyValidator) (map[string]Policy, error) {
+// It also returns the packages defined in multiple policies for the same environment.
+// If overlapMode is options.PackageOverlapError, such packages are an error
+// listing all the overlaps.
+func FromReaders(readers iterator.NamedReadCloserIterator, orgPolicy organization.Policy, validator options.PolicyValidator,
+	overlapMode options.PackageOverlapMode) (map[string]Policy, []options.PackageOverlap, error) {
 	policies := make(map[string]Policy)
 	protections := make(map[string]bool)
 	for readers.HasNext() {
 		id, reader := readers.Next()
-		// NOTE: fromReader()validates that the required levels is achievable.
-		policy, err := fromReader(reader, orgPolicy.MaxBuildSlsaLevel(), validator)
+		// NOTE: fromReader() validates that the required level is achievable
+		// and satisfies the org policy's minimum.
+		policy, err := fromReader(reader, orgPolicy, validator)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		// The policy ID must be unique across all projects.
 		if _, exists := policies[id]; exists {
-			return nil, fmt.Errorf("[project] %w: policy id (%q) is defined more than once", errs.ErrorInvalidField, id)
+			return nil, nil, fmt.Errorf("[project] %w: policy id (%q) is defined more than once", errs.ErrorInvalidField, id)
 		}
 		policies[id] = *policy
 
 		// The protection must be unique across all projects.
 		name := policy.Protection.GoogleServiceAccount
 		if _, exists := protections[name]; exists {
-			return nil, fmt.Errorf("[project] %w: protection's serivce_account (%q) is defined more than once", errs.ErrorInvalidField, name)
+			return nil, nil, fmt.Errorf("[project] %w: protection's serivce_account (%q) is defined more than once", errs.ErrorInvalidField, name)
 		}
 		protections[name] = true
 	}
 	//TODO: add test for this.
 	if readers.Error() != nil {
-		return nil, fmt.Errorf("[project] failed to read policy: %w", readers.Error())
+		return nil, nil, fmt.Errorf("[project] failed to read policy: %w", readers.Error())
+	}
+	overlaps := packageOverlaps(policies)
+	if len(overlaps) > 0 && overlapMode == options.PackageOverlapError {
+		allErrs := make([]error, len(overlaps))
+		for i := range overlaps {
+			allErrs[i] = errors.New(overlaps[i].String())
+		}
+		return nil, nil, fmt.Errorf("[project] %w: packages defined in multiple policies: %w",
+			errs.ErrorInvalidField, errors.Join(allErrs...))
 	}
-	return policies, nil
+	return policies, overlaps, nil
+}
+
+// packageOverlaps returns the packages defined in multiple policies for the same environment,
+// sorted by package name and environment.
+func packageOverlaps(policies map[string]Policy) []options.PackageOverlap {
+	type key struct {
+		name           string
+		environment    string
+		hasEnvironment bool
+	}
+	owners := make(map[key][]string)
+	for id, policy := range policies {
+		for i := range policy.Packages {
+			pkg := &policy.Packages[i]
+			if len(pkg.Environment.AnyOf) == 0 {
+				k := key{name: pkg.Name}
+				owners[k] = append(owners[k], id)
+				continue
+			}
+			for _, env := range pkg.Environment.AnyOf {
+				k := key{name: pkg.Name, environment: env, hasEnvironment: true}
+				owners[k] = append(owners[k], id)
+			}
+		}
+	}
+	var overlaps []options.PackageOverlap
+	for k, ids := range owners {
+		if len(ids) < 2 {
+			continue
+		}
+		sort.Strings(ids)
+		overlap := options.PackageOverlap{
+			PackageName: k.name,
+			PolicyIDs:   ids,
+		}
+		if k.hasEnvironment {
+			env := k.environment
+			overlap.Environment = &env
+		}
+		overlaps = append(overlaps, overlap)
+	}
+	sort.Slice(overlaps, func(i, j int) bool {
+		if overlaps[i].PackageName != overlaps[j].PackageName {
+			return overlaps[i].PackageName < overlaps[j].PackageName
+		}
+		// NOTE: a nil environment is sorted first.
+		if overlaps[i].Environment == nil || overlaps[j].Environment == nil {
+			return overlaps[j].Environment != nil
+		}
+		return *overlaps[i].Environment < *overlaps[j].Environment
+	})
+	return overlaps
 }
 
 // Evaluate evaluates a policy.
 func (p *Policy) Evaluate(digests intoto.DigestSet, packageName string,
-	orgPolicy organization.Policy, publishOpts options.PublishVerification) (*Protection, error) {
+	orgPolicy organization.Policy, publishOpts options.PublishVerification) (*Protection, options.PublishVerificationResult, error) {
 	if publishOpts.Verifier == nil {
-		return nil, fmt.Errorf("[project] %w: verifier is empty", errs.ErrorInvalidInput)
+		return nil, options.PublishVerificationResult{}, fmt.Errorf("[project] %w: verifier is empty", errs.ErrorInvalidInput)
 	}
 
 	// Validate the digest.
 	if err := digests.Validate(); err != nil {
-		return nil, err
+		return nil, options.PublishVerificationResult{}, err
 	}
 	// Get the package for protection Name.
 	pkg, err := p.getPackage(packageName)
 	if err != nil {
-		return nil, err
+		return nil, options.PublishVerificationResult{}, err
 	}
 
 	env := pkg.Environment.AnyOf
@@ -222,7 +373,7 @@ func (p *Policy) Evaluate(digests intoto.DigestSet, packageName string,
 			continue
 		}
 		// We have a candidate.
-		verifiedEnv, err := publishOpts.Verifier.VerifyPublishAttestation(digests, packageName, env, publishr.ID, *p.BuildRequirements.RequireSlsaLevel)
+		result, err := publishOpts.Verifier.VerifyPublishAttestation(digests, packageName, env, publishr.ID, *p.BuildRequirements.RequireSlsaLevel)
 		if err != nil {
 			// Verification failed, continue.
 			allErrs = append(allErrs, err)
@@ -232,14 +383,14 @@ func (p *Policy) Evaluate(digests intoto.DigestSet, packageName string,
 		// Verification of publish attestation succeeded.
 
 		// Sanity check.
-		if err := validateEnv(env, verifiedEnv); err != nil {
-			return nil, err
+		if err := validateEnv(env, result.Environment); err != nil {
+			return nil, options.PublishVerificationResult{}, err
 		}
 		// The target Name of the policy.
 		cpy := p.Protection
-		return &cpy, nil
+		return &cpy, result, nil
 	}
-	return nil, fmt.Errorf("[project] %w: cannot verify: %v", errs.ErrorVerification, allErrs)
+	return nil, options.PublishVerificationResult{}, fmt.Errorf("[project] %w: cannot verify: %v", errs.ErrorVerification, allErrs)
 }
 
 func validateEnv(env []string, verifiedEnv *string) error {
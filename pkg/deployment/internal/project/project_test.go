@@ -439,8 +439,8 @@ func Test_Evaluate(t *testing.T) {
 	packageName1 := "package_name1"
 	packageName2 := "package_name2"
 	digests := intoto.DigestSet{
-		"sha256": "val256",
-		"sha512": "val512",
+		"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
+		"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
 	}
 	org := organization.Policy{
 		Roots: organization.Roots{
@@ -522,7 +522,7 @@ func Test_Evaluate(t *testing.T) {
 			verifierOpts: vopts,
 			packageName:  packageName1,
 			digests: intoto.DigestSet{
-				"sha256": "val256",
+				"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
 				"":       "val512",
 			},
 			org:    org,
@@ -534,8 +534,8 @@ func Test_Evaluate(t *testing.T) {
 			verifierOpts: vopts,
 			packageName:  packageName1,
 			digests: intoto.DigestSet{
-				"sha256": "val256_different",
-				"sha512": "val512",
+				"sha256": "458270be419e48236a00808661ccc4c73a61103b9a54fb189175d1c4d1250902",
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
 			},
 			org:    org,
 			policy: project,
@@ -546,7 +546,7 @@ func Test_Evaluate(t *testing.T) {
 			verifierOpts: vopts,
 			packageName:  packageName1,
 			digests: intoto.DigestSet{
-				"sha512": "val512",
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
 			},
 			org:    org,
 			policy: project,
@@ -557,7 +557,7 @@ func Test_Evaluate(t *testing.T) {
 			verifierOpts: vopts,
 			packageName:  packageName1,
 			digests: intoto.DigestSet{
-				"sha256": "val256",
+				"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
 				"sha512": "",
 			},
 			org:    org,
@@ -635,7 +635,7 @@ func Test_Evaluate(t *testing.T) {
 			opts := options.PublishVerification{
 				Verifier: verifier,
 			}
-			protection, err := tt.policy.Evaluate(tt.digests, tt.packageName, tt.org, opts)
+			protection, _, err := tt.policy.Evaluate(tt.digests, tt.packageName, tt.org, opts)
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
@@ -657,11 +657,25 @@ func Test_FromReaders(t *testing.T) {
 		policies      []Policy
 		maxBuildLevel int
 		buggyIterator bool
+		overlapMode   options.PackageOverlapMode
+		overlaps      []options.PackageOverlap
 		expected      error
 	}{
 		{
 			name:          "two valid policies",
 			maxBuildLevel: 3,
+			overlaps: []options.PackageOverlap{
+				{
+					PackageName: "package_name",
+					Environment: common.AsPointer("dev"),
+					PolicyIDs:   []string{"policy_id0", "policy_id1"},
+				},
+				{
+					PackageName: "package_name",
+					Environment: common.AsPointer("prod"),
+					PolicyIDs:   []string{"policy_id0", "policy_id1"},
+				},
+			},
 			policies: []Policy{
 				{
 					Format: 1,
@@ -810,6 +824,227 @@ func Test_FromReaders(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "overlap error",
+			maxBuildLevel: 3,
+			overlapMode:   options.PackageOverlapError,
+			policies: []Policy{
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+							Environment: Environment{
+								AnyOf: []string{"dev", "prod"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name2",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+							Environment: Environment{
+								AnyOf: []string{"prod"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:          "overlap different environments",
+			maxBuildLevel: 3,
+			overlapMode:   options.PackageOverlapError,
+			policies: []Policy{
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+							Environment: Environment{
+								AnyOf: []string{"dev"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name2",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+							Environment: Environment{
+								AnyOf: []string{"prod"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+		},
+		{
+			name:          "overlap no environment",
+			maxBuildLevel: 3,
+			policies: []Policy{
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+						},
+						{
+							Name: "package_name2",
+							Environment: Environment{
+								AnyOf: []string{"dev"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name2",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+							Environment: Environment{
+								AnyOf: []string{"dev"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name3",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+						},
+						{
+							Name: "package_name2",
+							Environment: Environment{
+								AnyOf: []string{"dev"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+			overlaps: []options.PackageOverlap{
+				{
+					PackageName: "package_name",
+					PolicyIDs:   []string{"policy_id0", "policy_id2"},
+				},
+				{
+					PackageName: "package_name2",
+					Environment: common.AsPointer("dev"),
+					PolicyIDs:   []string{"policy_id0", "policy_id2"},
+				},
+			},
+		},
+		{
+			name:          "overlap multiple policies",
+			maxBuildLevel: 3,
+			policies: []Policy{
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+							Environment: Environment{
+								AnyOf: []string{"prod"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name2",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+							Environment: Environment{
+								AnyOf: []string{"prod"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+				{
+					Format: 1,
+					Protection: Protection{
+						GoogleServiceAccount: "protection_name3",
+					},
+					Packages: []Package{
+						{
+							Name: "package_name",
+							Environment: Environment{
+								AnyOf: []string{"dev", "prod"},
+							},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+			overlaps: []options.PackageOverlap{
+				{
+					PackageName: "package_name",
+					Environment: common.AsPointer("prod"),
+					PolicyIDs:   []string{"policy_id0", "policy_id1", "policy_id2"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -847,13 +1082,16 @@ func Test_FromReaders(t *testing.T) {
 			iter := common.NewNamedBytesIterator(policies, !tt.buggyIterator)
 
 			// Call the constructor.
-			_, err := FromReaders(iter, orgPolicy, nil)
+			_, overlaps, err := FromReaders(iter, orgPolicy, nil, tt.overlapMode)
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
+			if diff := cmp.Diff(tt.overlaps, overlaps); diff != "" {
+				t.Fatalf("unexpected overlaps (-want +got): \n%s", diff)
+			}
 			// Same policy with a passing validator.
 			iter = common.NewNamedBytesIterator(policies, !tt.buggyIterator)
-			_, err = FromReaders(iter, orgPolicy, common.NewPolicyValidator(true))
+			_, _, err = FromReaders(iter, orgPolicy, common.NewPolicyValidator(true), tt.overlapMode)
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
@@ -862,10 +1100,89 @@ func Test_FromReaders(t *testing.T) {
 			}
 			// Same policy with a failing validator.
 			iter = common.NewNamedBytesIterator(policies, !tt.buggyIterator)
-			_, err = FromReaders(iter, orgPolicy, common.NewPolicyValidator(false))
+			_, _, err = FromReaders(iter, orgPolicy, common.NewPolicyValidator(false), tt.overlapMode)
 			if diff := cmp.Diff(errs.ErrorInvalidField, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
 		})
 	}
 }
+
+func Test_FromReadersDefaults(t *testing.T) {
+	t.Parallel()
+
+	orgPolicy := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Publish: []organization.Root{
+				{
+					ID: "publisher_id1",
+					Build: organization.Build{
+						MaxSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+		},
+		Defaults: &organization.Defaults{
+			Build:       &organization.BuildDefaults{RequireSlsaLevel: common.AsPointer(3)},
+			Environment: &organization.EnvironmentDefaults{AnyOf: []string{"dev", "prod"}},
+		},
+		Minimums: &organization.Minimums{
+			Build: &organization.BuildMinimums{RequireSlsaLevel: common.AsPointer(2)},
+		},
+	}
+	tests := []struct {
+		name         string
+		content      string
+		level        int
+		environments [][]string
+		expected     error
+	}{
+		{
+			name: "defaults applied",
+			content: `{"format": 1, "protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+				"packages": [{"name": "package_name1"}, {"name": "package_name2", "environment": {"any_of": ["staging"]}}]}`,
+			level:        3,
+			environments: [][]string{{"dev", "prod"}, {"staging"}},
+		},
+		{
+			name: "defaults overridden",
+			content: `{"format": 1, "protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+				"packages": [{"name": "package_name1", "environment": {"any_of": ["staging"]}}], "build": {"require_slsa_level": 2}}`,
+			level:        2,
+			environments: [][]string{{"staging"}},
+		},
+		{
+			name: "level below minimum",
+			content: `{"format": 1, "protection": {"google_service_account": "name@project.iam.gserviceaccount.com"},
+				"packages": [{"name": "package_name1"}], "build": {"require_slsa_level": 1}}`,
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			iter := common.NewNamedBytesIterator([][]byte{[]byte(tt.content)}, true)
+			policies, _, err := FromReaders(iter, orgPolicy, nil, options.PackageOverlapError)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			policy := policies["policy_id0"]
+			if diff := cmp.Diff(tt.level, *policy.BuildRequirements.RequireSlsaLevel); diff != "" {
+				t.Fatalf("unexpected level (-want +got): \n%s", diff)
+			}
+			var environments [][]string
+			for _, pkg := range policy.Packages {
+				environments = append(environments, pkg.Environment.AnyOf)
+			}
+			if diff := cmp.Diff(tt.environments, environments); diff != "" {
+				t.Fatalf("unexpected environments (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package deployment
+
+import (
+	"io"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+// LintRules returns the lint rules of the deployment policy.
+func LintRules() []lint.Rule {
+	return internal.LintRules()
+}
+
+// Lint validates the policy and returns the findings of the lint rules.
+// The policy IDs are the locations of the findings. Findings for the
+// org policy have an empty location. Packages defined in multiple project
+// policies are reported as findings, unless PackageOverlapError is set.
+func Lint(org io.ReadCloser, projects iterator.NamedReadCloserIterator, opts ...PolicyOption) ([]lint.Finding, error) {
+	p, err := PolicyNew(org, projects, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return p.policy.Lint(), nil
+}
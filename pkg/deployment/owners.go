@@ -0,0 +1,38 @@
+package deployment
+
+import (
+	"io"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
+)
+
+// Owners defines the team owning a project policy.
+type Owners struct {
+	Team     string
+	Contacts []string
+	// Editors are the GitHub users or teams allowed to edit the policy,
+	// e.g. @org/team-x, or their email.
+	Editors []string
+	// Repositories are the repositories allowed to edit the policy,
+	// e.g. github.com/org/repo.
+	Repositories []string
+}
+
+// ProjectOwners returns the owners of a project policy, or nil if the policy has none.
+// It only validates the owners. Use PolicyNew() to validate the entire policy.
+func ProjectOwners(reader io.ReadCloser) (*Owners, error) {
+	owners, err := project.OwnersFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	if owners == nil {
+		return nil, nil
+	}
+	return &Owners{
+		Team: owners.Team,
+		// NOTE: make a copy of the arrays.
+		Contacts:     append([]string{}, owners.Contacts...),
+		Editors:      append([]string{}, owners.Editors...),
+		Repositories: append([]string(nil), owners.Repositories...),
+	}, nil
+}
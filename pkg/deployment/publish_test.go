@@ -0,0 +1,202 @@
+package deployment
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+	"github.com/slsa-framework/slsa-policy/pkg/publish"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
+)
+
+// Test_e2e_publish verifies that the attestation created by a publish policy
+// is accepted by a deployment policy. Unlike OCI images, generic artifacts
+// may be identified by any algorithm of the intoto registry.
+func Test_e2e_publish(t *testing.T) {
+	t.Parallel()
+	publishOrg := `{
+		"format": 1,
+		"roots": {
+			"build": [
+				{"id": "builder_id", "name": "builder_name", "slsa_level": 3}
+			]
+		}
+	}`
+	publishProject := `{
+		"format": 1,
+		"package": {"name": "artifact_name", "environment": {"any_of": ["prod"]}},
+		"build": {"require_slsa_builder": "builder_name", "repository": {"uri": "source_uri"}}
+	}`
+	deploymentOrg := `{
+		"format": 1,
+		"roots": {
+			"publish": [
+				{"id": "publishr_id", "build": {"max_slsa_level": 3}}
+			]
+		}
+	}`
+	deploymentProject := `{
+		"format": 1,
+		"protection": {"google_service_account": "name@project-id.iam.gserviceaccount.com"},
+		"packages": [{"name": "artifact_name", "environment": {"any_of": ["prod"]}}],
+		"build": {"require_slsa_level": 3}
+	}`
+	tests := []struct {
+		name     string
+		digests  intoto.DigestSet
+		deployed intoto.DigestSet
+		expected error
+	}{
+		{
+			name: "sha256",
+			digests: intoto.DigestSet{
+				"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
+			},
+		},
+		{
+			name: "sha512",
+			digests: intoto.DigestSet{
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
+			},
+		},
+		{
+			name: "sha512 and gitCommit",
+			digests: intoto.DigestSet{
+				"sha512":    "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
+				"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
+			},
+			deployed: intoto.DigestSet{
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
+			},
+		},
+		{
+			name: "different sha512",
+			digests: intoto.DigestSet{
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
+			},
+			deployed: intoto.DigestSet{
+				"sha512": "8b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
+			},
+			expected: errs.ErrorVerification,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Publish the artifact.
+			publishPolicy, err := publish.PolicyNew(io.NopCloser(strings.NewReader(publishOrg)),
+				&stringIterator{values: []string{publishProject}}, &publishPackageHelper{})
+			if err != nil {
+				t.Fatalf("failed to create publish policy: %v", err)
+			}
+			env := "prod"
+			publishResult := publishPolicy.Evaluate(tt.digests, "artifact_name", publish.RequestOption{Environment: &env},
+				publish.AttestationVerificationOption{Verifier: &buildVerifier{digests: tt.digests}})
+			if err := publishResult.Error(); err != nil {
+				t.Fatalf("failed to evaluate publish policy: %v", err)
+			}
+			creation, err := publishResult.AttestationNew()
+			if err != nil {
+				t.Fatalf("failed to create publish attestation: %v", err)
+			}
+			attBytes, err := creation.ToBytes()
+			if err != nil {
+				t.Fatalf("failed to get publish attestation bytes: %v", err)
+			}
+
+			// Deploy the artifact.
+			deploymentPolicy, err := PolicyNew(io.NopCloser(strings.NewReader(deploymentOrg)),
+				common.NewNamedBytesIterator([][]byte{[]byte(deploymentProject)}, true))
+			if err != nil {
+				t.Fatalf("failed to create deployment policy: %v", err)
+			}
+			deployed := tt.deployed
+			if deployed == nil {
+				deployed = tt.digests
+			}
+			result := deploymentPolicy.Evaluate(deployed, "artifact_name", "policy_id0",
+				AttestationVerificationOption{Verifier: &publishVerifier{attestation: attBytes}})
+			if diff := cmp.Diff(tt.expected, result.Error(), cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+type publishPackageHelper struct{}
+
+func (h *publishPackageHelper) PolicyPackageName(desc intoto.PackageDescriptor) (string, error) {
+	return desc.Name, nil
+}
+
+func (h *publishPackageHelper) PackageDescriptor(name string) (intoto.PackageDescriptor, error) {
+	return intoto.PackageDescriptor{
+		Name:     name,
+		Registry: "registry",
+	}, nil
+}
+
+// buildVerifier accepts the provenance of the artifact.
+type buildVerifier struct {
+	digests intoto.DigestSet
+}
+
+func (v *buildVerifier) VerifyBuildAttestation(digests intoto.DigestSet, packageName, builderID, sourceURI string) (publish.BuildVerificationResult, error) {
+	if _, err := intoto.MatchSubjects([]intoto.Subject{{Digests: v.digests}}, digests); err != nil {
+		return publish.BuildVerificationResult{}, err
+	}
+	return publish.BuildVerificationResult{
+		Provenance: publish.BuildProvenance{
+			BuilderID: builderID,
+			SourceURI: sourceURI,
+		},
+	}, nil
+}
+
+// publishVerifier verifies the content of the publish attestation.
+type publishVerifier struct {
+	attestation []byte
+}
+
+func (v *publishVerifier) VerifyPublishAttestation(digests intoto.DigestSet, packageURI string, environment []string, opts AttestationVerifierPublishOptions) (PublishVerificationResult, error) {
+	verification, err := publish.VerificationNew(io.NopCloser(bytes.NewReader(v.attestation)), &publishPackageHelper{})
+	if err != nil {
+		return PublishVerificationResult{}, err
+	}
+	for i := range environment {
+		env := environment[i]
+		if err := verification.Verify(digests, packageURI, publish.HasStrictDigests(),
+			publish.IsSlsaBuildLevelOrAbove(opts.BuildLevel), publish.IsPackageEnvironment(env)); err != nil {
+			continue
+		}
+		return PublishVerificationResult{Environment: &env}, nil
+	}
+	return PublishVerificationResult{}, fmt.Errorf("%w: package (%q) not published in environments (%q)",
+		errs.ErrorVerification, packageURI, environment)
+}
+
+type stringIterator struct {
+	values []string
+	index  int
+}
+
+func (iter *stringIterator) Next() io.ReadCloser {
+	iter.index++
+	return io.NopCloser(strings.NewReader(iter.values[iter.index-1]))
+}
+
+func (iter *stringIterator) HasNext() bool {
+	return iter.index < len(iter.values)
+}
+
+func (iter *stringIterator) Error() error {
+	return nil
+}
@@ -2,6 +2,8 @@ package deployment
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
 	"github.com/slsa-framework/slsa-policy/pkg/errs"
@@ -10,9 +12,12 @@ import (
 
 // PolicyEvaluationResult defines the result of policy evaluation.
 type PolicyEvaluationResult struct {
-	err        error
-	digests    intoto.DigestSet
-	protection *project.Protection
+	err              error
+	digests          intoto.DigestSet
+	subjects         []intoto.Subject
+	digestValidation intoto.DigestValidation
+	protection       *project.Protection
+	verifications    []PublishVerificationResult
 }
 
 // AttestationNew creates a deployment attestation.
@@ -23,11 +28,21 @@ func (r PolicyEvaluationResult) AttestationNew(options ...AttestationCreationOpt
 	if err := r.isValid(); err != nil {
 		return nil, err
 	}
-	subject := intoto.Subject{
-		Digests: r.digests,
+	subjects := r.subjects
+	if len(subjects) == 0 {
+		subjects = []intoto.Subject{{Digests: r.digests}}
 	}
 	// Create the options.
-	opts := []AttestationCreationOption{}
+	var opts []AttestationCreationOption
+	if r.digestValidation == intoto.DigestValidationStrict {
+		opts = append(opts, SetStrictDigests())
+	}
+	opts = append(opts, []AttestationCreationOption{
+		// Add the other evaluated subjects.
+		AddSubjects(subjects[1:]...),
+		// Record the verified publish attestations.
+		AddEvidence(r.evidence()...),
+	}...)
 	// Enter safe mode.
 	opts = append(opts, EnterSafeMode())
 	// Add caller options.
@@ -35,13 +50,38 @@ func (r PolicyEvaluationResult) AttestationNew(options ...AttestationCreationOpt
 	scopes := map[string]string{
 		scopeGoogleServiceAccount: r.protection.GoogleServiceAccount,
 	}
-	att, err := CreationNew(subject, scopes, opts...)
+	att, err := CreationNew(subjects[0], scopes, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return att, err
 }
 
+// PublishVerifications returns the results of the publish attestation
+// verifications, one per evaluated subject.
+func (r PolicyEvaluationResult) PublishVerifications() []PublishVerificationResult {
+	// NOTE: make a copy of the array.
+	return append([]PublishVerificationResult{}, r.verifications...)
+}
+
+// evidence returns the unique publish attestations verified.
+func (r PolicyEvaluationResult) evidence() []intoto.ResourceDescriptor {
+	var evidence []intoto.ResourceDescriptor
+	for i := range r.verifications {
+		descriptor := r.verifications[i].evidence()
+		if descriptor == nil {
+			continue
+		}
+		if slices.ContainsFunc(evidence, func(e intoto.ResourceDescriptor) bool {
+			return maps.Equal(e.Digest, descriptor.Digest)
+		}) {
+			continue
+		}
+		evidence = append(evidence, *descriptor)
+	}
+	return evidence
+}
+
 func (r PolicyEvaluationResult) Error() error {
 	return r.err
 }
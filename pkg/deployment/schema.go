@@ -0,0 +1,17 @@
+package deployment
+
+import (
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/schema"
+)
+
+// OrgSchema returns the JSON Schema of the org policy file.
+func OrgSchema() ([]byte, error) {
+	return schema.JSON("Deployment org policy", organization.Policy{})
+}
+
+// ProjectSchema returns the JSON Schema of the project policy files.
+func ProjectSchema() ([]byte, error) {
+	return schema.JSON("Deployment project policy", project.Policy{})
+}
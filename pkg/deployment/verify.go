@@ -43,10 +43,7 @@ func (v *Verification) Verify(digests intoto.DigestSet, scopes map[string]string
 			v.attestation.Header.PredicateType, predicateType)
 	}
 	// Subjects and digests.
-	if len(v.attestation.Header.Subjects) == 0 {
-		return fmt.Errorf("%w: no subjects in attestation", errs.ErrorInvalidField)
-	}
-	if err := verifyDigests(v.attestation.Header.Subjects[0].Digests, digests); err != nil {
+	if err := verifySubjects(v.attestation.Header.Subjects, digests); err != nil {
 		return err
 	}
 	// Scopes.
@@ -75,22 +72,24 @@ func (v *Verification) verifyScopes(scopes map[string]string) error {
 	return nil
 }
 
-func verifyDigests(ds intoto.DigestSet, digests intoto.DigestSet) error {
-	if err := ds.Validate(); err != nil {
-		return err
-	}
-	if err := digests.Validate(); err != nil {
-		return err
+// verifySubjects verifies that one of the subjects matches the digests.
+func verifySubjects(subjects []intoto.Subject, digests intoto.DigestSet) error {
+	_, err := intoto.MatchSubjects(subjects, digests)
+	return err
+}
+
+// HasStrictDigests verifies that the digests of all subjects
+// are valid in strict mode. See intoto.DigestValidationStrict.
+func HasStrictDigests() VerificationOption {
+	return func(v *Verification) error {
+		return v.hasStrictDigests()
 	}
-	for name, value := range digests {
-		val, exists := ds[name]
-		if !exists {
-			return fmt.Errorf("%w: subject with digest (%q:%q) is not present in attestation", errs.ErrorMismatch,
-				name, value)
-		}
-		if val != value {
-			return fmt.Errorf("%w: subject with digest (%q:%q) != attestation (%q:%q)", errs.ErrorMismatch,
-				name, value, name, val)
+}
+
+func (v *Verification) hasStrictDigests() error {
+	for i := range v.attestation.Header.Subjects {
+		if err := v.attestation.Header.Subjects[i].ValidateWithMode(intoto.DigestValidationStrict); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -14,88 +14,6 @@ import (
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 )
 
-func Test_verifyDigests(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name         string
-		attDigests   intoto.DigestSet
-		inputDigests intoto.DigestSet
-		expected     error
-	}{
-		{
-			name: "same digests",
-			attDigests: intoto.DigestSet{
-				"sha256":    "another",
-				"gitCommit": "mismatch_another_com",
-			},
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-				"sha256":    "another",
-			},
-		},
-		{
-			name: "subset in attestations",
-			attDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-			},
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-			},
-		},
-		{
-			name: "empty input digests",
-			attDigests: intoto.DigestSet{
-				"sha256":    "another",
-				"gitCommit": "mismatch_another_com",
-			},
-			expected: errs.ErrorInvalidField,
-		},
-		{
-			name: "empty att digests",
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-				"sha256":    "another",
-			},
-			expected: errs.ErrorInvalidField,
-		},
-		{
-			name: "different digest names",
-			attDigests: intoto.DigestSet{
-				"a-sha256":    "another",
-				"a-gitCommit": "mismatch_another_com",
-			},
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-				"sha256":    "another",
-			},
-			expected: errs.ErrorMismatch,
-		},
-		{
-			name: "mismatch sha256 digest",
-			attDigests: intoto.DigestSet{
-				"sha256":    "not_another",
-				"gitCommit": "mismatch_another_com",
-			},
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-				"sha256":    "another",
-			},
-			expected: errs.ErrorMismatch,
-		},
-	}
-	for _, tt := range tests {
-		tt := tt // Re-initializing variable so it is not changed while executing the closure below
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			err := verifyDigests(tt.attDigests, tt.inputDigests)
-			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
-				t.Fatalf("unexpected err (-want +got): \n%s", diff)
-			}
-		})
-	}
-}
-
 func Test_verifyScopes(t *testing.T) {
 	t.Parallel()
 	scopes := map[string]string{
@@ -192,8 +110,8 @@ func Test_verifyScopes(t *testing.T) {
 func Test_Verify(t *testing.T) {
 	t.Parallel()
 	digests := intoto.DigestSet{
-		"sha256":    "another",
-		"gitCommit": "another_com",
+		"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+		"gitCommit": "d83f6526d87d2d52ef4d789b2933c6cd7d9b8981",
 	}
 	subjects := []intoto.Subject{
 		intoto.Subject{
@@ -287,7 +205,7 @@ func Test_Verify(t *testing.T) {
 					Subjects: []intoto.Subject{
 						{
 							Digests: intoto.DigestSet{
-								"sha256": "another",
+								"sha256": "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
 								"":       "mismatch_another_com",
 							},
 						},
@@ -304,7 +222,7 @@ func Test_Verify(t *testing.T) {
 			att:      att,
 			scopes:   scopes,
 			digests: intoto.DigestSet{
-				"sha256": "another",
+				"sha256": "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
 				"":       "mismatch_another_com",
 			},
 		},
@@ -318,7 +236,7 @@ func Test_Verify(t *testing.T) {
 					Subjects: []intoto.Subject{
 						{
 							Digests: intoto.DigestSet{
-								"sha256":    "another",
+								"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
 								"gitCommit": "",
 							},
 						},
@@ -339,8 +257,8 @@ func Test_Verify(t *testing.T) {
 					Subjects: []intoto.Subject{
 						{
 							Digests: intoto.DigestSet{
-								"sha256":    "another",
-								"gitCommit": "mismatch_another_com",
+								"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+								"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
 							},
 						},
 					},
@@ -349,7 +267,7 @@ func Test_Verify(t *testing.T) {
 			},
 			scopes: scopes,
 			digests: intoto.DigestSet{
-				"sha256":    "another",
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
 				"gitCommit": "",
 			},
 		},
@@ -359,8 +277,8 @@ func Test_Verify(t *testing.T) {
 			att:      att,
 			scopes:   scopes,
 			digests: intoto.DigestSet{
-				"sha256":    "not_another",
-				"gitCommit": "mismatch_another_com",
+				"sha256":    "cd84e8eb5ec577de03d8b159a56c809b9ec7ae1956f828bfab6479de57a1e88d",
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
 			},
 		},
 		{
@@ -369,8 +287,8 @@ func Test_Verify(t *testing.T) {
 			att:      att,
 			scopes:   scopes,
 			digests: intoto.DigestSet{
-				"sha256":    "another",
-				"gitCommit": "mismatch_another_com",
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
 			},
 		},
 		{
@@ -388,7 +306,7 @@ func Test_Verify(t *testing.T) {
 			att:    att,
 			scopes: scopes,
 			digests: intoto.DigestSet{
-				"gitCommit": "another_com",
+				"gitCommit": "d83f6526d87d2d52ef4d789b2933c6cd7d9b8981",
 			},
 		},
 		{
@@ -505,3 +423,76 @@ func Test_Verify(t *testing.T) {
 		})
 	}
 }
+
+func Test_verifySubjects(t *testing.T) {
+	t.Parallel()
+
+	index := intoto.Subject{
+		Digests: intoto.DigestSet{
+			"sha256": "1bc04b5291c26a46d918139138b992d2de976d6851d0893b0476b85bfbdfc6e6",
+		},
+	}
+	amd64 := intoto.Subject{
+		Name: "linux/amd64",
+		Digests: intoto.DigestSet{
+			"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51",
+		},
+	}
+	arm64 := intoto.Subject{
+		Name: "linux/arm64",
+		Digests: intoto.DigestSet{
+			"sha256": "f69162950f235e3cdbbad33f1f912d1a504be90d8a37d002c735d6f3e3882265",
+		},
+	}
+	tests := []struct {
+		name     string
+		subjects []intoto.Subject
+		digests  intoto.DigestSet
+		expected error
+	}{
+		{
+			name:     "first subject",
+			subjects: []intoto.Subject{index, amd64, arm64},
+			digests:  index.Digests,
+		},
+		{
+			name:     "last subject",
+			subjects: []intoto.Subject{index, amd64, arm64},
+			digests:  arm64.Digests,
+		},
+		{
+			name:     "no matching subject",
+			subjects: []intoto.Subject{index, amd64},
+			digests:  arm64.Digests,
+			expected: errs.ErrorMismatch,
+		},
+		{
+			name:     "no subjects",
+			digests:  arm64.Digests,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "empty digests",
+			subjects: []intoto.Subject{index, amd64},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "invalid subject",
+			subjects: []intoto.Subject{index, amd64, intoto.Subject{
+				Name: "linux/arm64",
+			}},
+			digests:  amd64.Digests,
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := verifySubjects(tt.subjects, tt.digests)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
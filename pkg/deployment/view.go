@@ -0,0 +1,67 @@
+package deployment
+
+import (
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
+)
+
+// ProjectPolicy is a read-only view of a project policy.
+type ProjectPolicy struct {
+	ID                   string
+	GoogleServiceAccount string
+	Packages             []Package
+	RequireSlsaLevel     int
+	Owners               *Owners
+}
+
+// Package defines a package a project policy allows to deploy.
+type Package struct {
+	Name         string
+	Environments []string
+}
+
+// ProjectPolicies returns the project policies, sorted by ID.
+func (p *Policy) ProjectPolicies() []ProjectPolicy {
+	ids := p.policy.ProjectPolicyIDs()
+	res := make([]ProjectPolicy, len(ids))
+	for i, id := range ids {
+		// NOTE: the ID is one of the policies' IDs, so it exists.
+		policy, _ := p.policy.ProjectPolicy(id)
+		res[i] = projectPolicy(id, policy)
+	}
+	return res
+}
+
+// ProjectPolicy returns the project policy with the given ID.
+func (p *Policy) ProjectPolicy(id string) (ProjectPolicy, bool) {
+	policy, exists := p.policy.ProjectPolicy(id)
+	if !exists {
+		return ProjectPolicy{}, false
+	}
+	return projectPolicy(id, policy), true
+}
+
+func projectPolicy(id string, policy project.Policy) ProjectPolicy {
+	res := ProjectPolicy{
+		ID:                   id,
+		GoogleServiceAccount: policy.Protection.GoogleServiceAccount,
+		Packages:             make([]Package, len(policy.Packages)),
+		RequireSlsaLevel:     *policy.BuildRequirements.RequireSlsaLevel,
+	}
+	for i, pkg := range policy.Packages {
+		res.Packages[i] = Package{
+			Name: pkg.Name,
+			// NOTE: make a copy of the array.
+			Environments: append([]string{}, pkg.Environment.AnyOf...),
+		}
+	}
+	if policy.Owners != nil {
+		res.Owners = &Owners{
+			Team: policy.Owners.Team,
+			// NOTE: make a copy of the arrays.
+			Contacts:     append([]string{}, policy.Owners.Contacts...),
+			Editors:      append([]string{}, policy.Owners.Editors...),
+			Repositories: append([]string(nil), policy.Owners.Repositories...),
+		}
+	}
+	return res
+}
@@ -0,0 +1,164 @@
+package deployment
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/deployment/internal/project"
+)
+
+func Test_ProjectPolicies(t *testing.T) {
+	t.Parallel()
+	org := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Publish: []organization.Root{
+				{
+					ID: "publisher_id",
+					Build: organization.Build{
+						MaxSlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+		},
+	}
+	projects := []project.Policy{
+		{
+			Format: 1,
+			Protection: project.Protection{
+				GoogleServiceAccount: "name1@project.iam.gserviceaccount.com",
+			},
+			Packages: []project.Package{
+				{
+					Name: "package_name1",
+					Environment: project.Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				{
+					Name: "package_name2",
+				},
+			},
+			BuildRequirements: project.BuildRequirements{
+				RequireSlsaLevel: common.AsPointer(3),
+			},
+			Owners: &project.Owners{
+				Team:     "team1",
+				Contacts: []string{"team1@example.com"},
+				Editors:  []string{"@org/team1"},
+			},
+		},
+		{
+			Format: 1,
+			Protection: project.Protection{
+				GoogleServiceAccount: "name2@project.iam.gserviceaccount.com",
+			},
+			Packages: []project.Package{
+				{
+					Name: "package_name3",
+				},
+			},
+			BuildRequirements: project.BuildRequirements{
+				RequireSlsaLevel: common.AsPointer(2),
+			},
+		},
+	}
+	policy0 := ProjectPolicy{
+		ID:                   "policy_id0",
+		GoogleServiceAccount: "name1@project.iam.gserviceaccount.com",
+		Packages: []Package{
+			{
+				Name:         "package_name1",
+				Environments: []string{"dev", "prod"},
+			},
+			{
+				Name:         "package_name2",
+				Environments: []string{},
+			},
+		},
+		RequireSlsaLevel: 3,
+		Owners: &Owners{
+			Team:     "team1",
+			Contacts: []string{"team1@example.com"},
+			Editors:  []string{"@org/team1"},
+		},
+	}
+	policy1 := ProjectPolicy{
+		ID:                   "policy_id1",
+		GoogleServiceAccount: "name2@project.iam.gserviceaccount.com",
+		Packages: []Package{
+			{
+				Name:         "package_name3",
+				Environments: []string{},
+			},
+		},
+		RequireSlsaLevel: 2,
+	}
+
+	// Marshal the org policy.
+	content, err := json.Marshal(org)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	orgReader := io.NopCloser(bytes.NewReader(content))
+	// Marshal the project policies into bytes.
+	policies := make([][]byte, len(projects), len(projects))
+	for i := range projects {
+		content, err := json.Marshal(projects[i])
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		policies[i] = content
+	}
+	policy, err := PolicyNew(orgReader, common.NewNamedBytesIterator(policies, true))
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+
+	if diff := cmp.Diff([]ProjectPolicy{policy0, policy1}, policy.ProjectPolicies()); diff != "" {
+		t.Fatalf("unexpected policies (-want +got): \n%s", diff)
+	}
+	tests := []struct {
+		name     string
+		id       string
+		expected *ProjectPolicy
+	}{
+		{
+			name:     "policy with owners",
+			id:       "policy_id0",
+			expected: &policy0,
+		},
+		{
+			name:     "policy without owners",
+			id:       "policy_id1",
+			expected: &policy1,
+		},
+		{
+			name: "unknown policy",
+			id:   "policy_id2",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectPolicy, exists := policy.ProjectPolicy(tt.id)
+			if exists != (tt.expected != nil) {
+				t.Fatalf("unexpected exists: %v", exists)
+			}
+			if !exists {
+				return
+			}
+			if diff := cmp.Diff(*tt.expected, projectPolicy); diff != "" {
+				t.Fatalf("unexpected policy (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
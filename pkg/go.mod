@@ -2,4 +2,9 @@ module github.com/slsa-framework/slsa-policy/pkg
 
 go 1.22
 
-require github.com/google/go-cmp v0.6.0
+require (
+	github.com/google/go-cmp v0.6.0
+	sigs.k8s.io/yaml v1.3.0
+)
+
+require gopkg.in/yaml.v2 v2.4.0 // indirect
@@ -10,11 +10,11 @@ type decisionDetails struct {
 }
 
 type predicate struct {
-	CreationTime    string                   `json:"creationTime"`
-	DecisionDetails *decisionDetails         `json:"decisionDetails,omitempty"`
+	CreationTime    string           `json:"creationTime"`
+	DecisionDetails *decisionDetails `json:"decisionDetails,omitempty"`
 	// NOTE: We may replace the descriptor by a PURL.
-	Package         intoto.PackageDescriptor `json:"package"`
-	Properties      properties               `json:"properties,omitempty"`
+	Package    intoto.PackageDescriptor `json:"package"`
+	Properties properties               `json:"properties,omitempty"`
 	// TODO: properties for dependencies.
 }
 
@@ -26,7 +26,18 @@ type attestation struct {
 type properties map[string]interface{}
 
 const (
-	statementType      = "https://in-toto.io/Statement/v1"
+	statementType      = intoto.StatementTypeV1
 	predicateType      = "https://slsa.dev/publish/v0.1"
 	buildLevelProperty = "slsa.dev/build/level"
+	// Verified build provenance.
+	builderIDProperty    = "slsa.dev/build/builder/id"
+	buildTypeProperty    = "slsa.dev/build/type"
+	invocationIDProperty = "slsa.dev/build/invocation/id"
+	workflowPathProperty = "slsa.dev/build/workflow/path"
+	sourceURIProperty    = "slsa.dev/source/uri"
+	sourceCommitProperty = "slsa.dev/source/commit"
+	sourceRefProperty    = "slsa.dev/source/ref"
+	sourceLevelProperty  = "slsa.dev/source/level"
+	// Annotation of the evidence in the decision details.
+	predicateTypeAnnotation = "predicateType"
 )
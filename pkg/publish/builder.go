@@ -0,0 +1,232 @@
+package publish
+
+import (
+	"io"
+
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/format"
+)
+
+// policyFormat is the format of the policies created programmatically.
+const policyFormat = 1
+
+// OrgPolicyBuilder builds an org policy programmatically.
+// Errors are reported by Validate(), JSON() and YAML().
+type OrgPolicyBuilder struct {
+	policy organization.Policy
+}
+
+// NewOrgPolicy returns a builder for an empty org policy.
+func NewOrgPolicy() *OrgPolicyBuilder {
+	return &OrgPolicyBuilder{
+		policy: organization.Policy{
+			Format: policyFormat,
+		},
+	}
+}
+
+// OrgPolicyFromReader returns a builder for an existing org policy.
+func OrgPolicyFromReader(reader io.ReadCloser) (*OrgPolicyBuilder, error) {
+	policy, err := organization.FromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &OrgPolicyBuilder{policy: *policy}, nil
+}
+
+// AddBuilder adds a trusted builder.
+func (b *OrgPolicyBuilder) AddBuilder(id, name string, slsaLevel int) *OrgPolicyBuilder {
+	b.policy.Roots.Build = append(b.policy.Roots.Build, organization.Root{
+		ID:        id,
+		Name:      name,
+		SlsaLevel: &slsaLevel,
+	})
+	return b
+}
+
+// SetDefaultBuilder sets the builder of the project policies that do not set one.
+func (b *OrgPolicyBuilder) SetDefaultBuilder(name string) *OrgPolicyBuilder {
+	b.defaults().Build = &organization.BuildDefaults{
+		RequireSlsaBuilder: name,
+	}
+	return b
+}
+
+// SetDefaultEnvironments sets the environments of the packages that do not set any.
+func (b *OrgPolicyBuilder) SetDefaultEnvironments(environments ...string) *OrgPolicyBuilder {
+	b.defaults().Environment = &organization.EnvironmentDefaults{
+		// NOTE: make a copy of the array.
+		AnyOf: append([]string{}, environments...),
+	}
+	return b
+}
+
+// SetMinSlsaLevel sets the minimum level of the builders the project policies may use.
+func (b *OrgPolicyBuilder) SetMinSlsaLevel(slsaLevel int) *OrgPolicyBuilder {
+	b.policy.Minimums = &organization.Minimums{
+		Build: &organization.BuildMinimums{
+			SlsaLevel: &slsaLevel,
+		},
+	}
+	return b
+}
+
+func (b *OrgPolicyBuilder) defaults() *organization.Defaults {
+	if b.policy.Defaults == nil {
+		b.policy.Defaults = &organization.Defaults{}
+	}
+	return b.policy.Defaults
+}
+
+// Validate validates the policy like PolicyNew() does.
+func (b *OrgPolicyBuilder) Validate() error {
+	return b.policy.Validate()
+}
+
+// JSON validates the policy and returns its canonical JSON encoding.
+func (b *OrgPolicyBuilder) JSON() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return format.JSON(b.policy)
+}
+
+// YAML validates the policy and returns its canonical YAML encoding.
+func (b *OrgPolicyBuilder) YAML() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return format.YAML(b.policy)
+}
+
+// ProjectPolicyBuilder builds a project policy programmatically.
+// Errors are reported by Validate(), JSON() and YAML().
+type ProjectPolicyBuilder struct {
+	packages     []project.Package
+	requirements project.BuildRequirements
+	owners       *project.Owners
+}
+
+// NewProjectPolicy returns a builder for a project policy
+// that allows to publish a package.
+func NewProjectPolicy(packageName string, environments ...string) *ProjectPolicyBuilder {
+	return new(ProjectPolicyBuilder).AddPackage(packageName, environments...)
+}
+
+// AddPackage adds a package name or pattern to the policy.
+func (b *ProjectPolicyBuilder) AddPackage(packageName string, environments ...string) *ProjectPolicyBuilder {
+	b.packages = append(b.packages, project.Package{
+		Name: packageName,
+		Environment: project.Environment{
+			AnyOf: environments,
+		},
+	})
+	return b
+}
+
+// SetRepository sets the repository the packages must be built from.
+func (b *ProjectPolicyBuilder) SetRepository(uri string) *ProjectPolicyBuilder {
+	b.requirements.Repository.URI = uri
+	return b
+}
+
+// SetBuilder sets the builder the packages must be built by.
+func (b *ProjectPolicyBuilder) SetBuilder(name string) *ProjectPolicyBuilder {
+	b.requirements.RequireSlsaBuilder = name
+	return b
+}
+
+// AddBuilder adds a builder the packages may be built by.
+// It cannot be used together with SetBuilder().
+func (b *ProjectPolicyBuilder) AddBuilder(name string) *ProjectPolicyBuilder {
+	if b.requirements.Builders == nil {
+		b.requirements.Builders = new(project.Builders)
+	}
+	b.requirements.Builders.AnyOf = append(b.requirements.Builders.AnyOf, project.Builder{Name: name})
+	return b
+}
+
+// SetEnvironmentBuilder sets the builder the packages must be built by for an environment.
+func (b *ProjectPolicyBuilder) SetEnvironmentBuilder(environment, name string) *ProjectPolicyBuilder {
+	if b.requirements.Environments == nil {
+		b.requirements.Environments = make(map[string]project.EnvironmentBuildRequirements)
+	}
+	requirements := b.requirements.Environments[environment]
+	requirements.RequireSlsaBuilder = name
+	b.requirements.Environments[environment] = requirements
+	return b
+}
+
+// SetSource sets the requirements on the source the packages are built from.
+func (b *ProjectPolicyBuilder) SetSource(source SourceRequirements) *ProjectPolicyBuilder {
+	b.requirements.Source = &project.SourceRequirements{
+		Refs:             project.Patterns{AnyOf: source.Refs},
+		Workflows:        project.Patterns{AnyOf: source.Workflows},
+		RequireSlsaLevel: source.RequireSlsaLevel,
+	}
+	return b
+}
+
+// SetOwners sets the owners of the policy.
+func (b *ProjectPolicyBuilder) SetOwners(owners Owners) *ProjectPolicyBuilder {
+	b.owners = &project.Owners{
+		Team:         owners.Team,
+		Contacts:     owners.Contacts,
+		Editors:      owners.Editors,
+		Repositories: owners.Repositories,
+	}
+	return b
+}
+
+// Validate validates the policy against the org policy, like PolicyNew() does.
+func (b *ProjectPolicyBuilder) Validate(org *OrgPolicyBuilder, opts ...PolicyOption) error {
+	_, err := b.build(org, opts...)
+	return err
+}
+
+// JSON validates the policy against the org policy and returns its canonical JSON encoding.
+func (b *ProjectPolicyBuilder) JSON(org *OrgPolicyBuilder, opts ...PolicyOption) ([]byte, error) {
+	policy, err := b.build(org, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return format.JSON(policy)
+}
+
+// YAML validates the policy against the org policy and returns its canonical YAML encoding.
+func (b *ProjectPolicyBuilder) YAML(org *OrgPolicyBuilder, opts ...PolicyOption) ([]byte, error) {
+	policy, err := b.build(org, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return format.YAML(policy)
+}
+
+func (b *ProjectPolicyBuilder) build(org *OrgPolicyBuilder, opts ...PolicyOption) (*project.Policy, error) {
+	if err := org.Validate(); err != nil {
+		return nil, err
+	}
+	// Initialize a policy with caller options.
+	p := new(Policy)
+	for _, option := range opts {
+		err := option(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	policy := project.Policy{
+		Format:            policyFormat,
+		BuildRequirements: b.requirements,
+		Owners:            b.owners,
+	}
+	if len(b.packages) == 1 {
+		policy.Package = b.packages[0]
+	} else {
+		policy.Packages = b.packages
+	}
+	if err := policy.Validate(org.policy, p.validator); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
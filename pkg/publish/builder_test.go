@@ -0,0 +1,294 @@
+package publish
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/common"
+)
+
+func Test_OrgPolicyBuilder(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		org      *OrgPolicyBuilder
+		content  string
+		expected error
+	}{
+		{
+			name: "valid policy",
+			org: NewOrgPolicy().
+				AddBuilder("builder_id1", "builder_name1", 2).
+				AddBuilder("builder_id2", "builder_name2", 3),
+			content: "" +
+				"{\n" +
+				"  \"format\": 1,\n" +
+				"  \"roots\": {\n" +
+				"    \"build\": [\n" +
+				"      {\n" +
+				"        \"id\": \"builder_id1\",\n" +
+				"        \"name\": \"builder_name1\",\n" +
+				"        \"slsa_level\": 2\n" +
+				"      },\n" +
+				"      {\n" +
+				"        \"id\": \"builder_id2\",\n" +
+				"        \"name\": \"builder_name2\",\n" +
+				"        \"slsa_level\": 3\n" +
+				"      }\n" +
+				"    ]\n" +
+				"  }\n" +
+				"}\n",
+		},
+		{
+			name:     "no builders",
+			org:      NewOrgPolicy(),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "invalid level",
+			org:      NewOrgPolicy().AddBuilder("builder_id1", "builder_name1", 5),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "duplicate builder name",
+			org: NewOrgPolicy().
+				AddBuilder("builder_id1", "builder_name1", 2).
+				AddBuilder("builder_id2", "builder_name1", 3),
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := tt.org.JSON()
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.content, string(content)); diff != "" {
+				t.Fatalf("unexpected content (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ProjectPolicyBuilder(t *testing.T) {
+	t.Parallel()
+	org := NewOrgPolicy().
+		AddBuilder("builder_id1", "builder_name1", 2).
+		AddBuilder("builder_id2", "builder_name2", 3)
+	tests := []struct {
+		name     string
+		project  *ProjectPolicyBuilder
+		content  string
+		expected error
+	}{
+		{
+			name: "single package",
+			project: NewProjectPolicy("package_name1", "dev", "prod").
+				SetBuilder("builder_name2").
+				SetRepository("source_name1").
+				SetEnvironmentBuilder("dev", "builder_name1").
+				SetOwners(Owners{Team: "team1", Editors: []string{"@org/team1"}}),
+			content: "" +
+				"{\n" +
+				"  \"build\": {\n" +
+				"    \"environments\": {\n" +
+				"      \"dev\": {\n" +
+				"        \"require_slsa_builder\": \"builder_name1\"\n" +
+				"      }\n" +
+				"    },\n" +
+				"    \"repository\": {\n" +
+				"      \"uri\": \"source_name1\"\n" +
+				"    },\n" +
+				"    \"require_slsa_builder\": \"builder_name2\"\n" +
+				"  },\n" +
+				"  \"format\": 1,\n" +
+				"  \"owners\": {\n" +
+				"    \"editors\": [\n" +
+				"      \"@org/team1\"\n" +
+				"    ],\n" +
+				"    \"team\": \"team1\"\n" +
+				"  },\n" +
+				"  \"package\": {\n" +
+				"    \"environment\": {\n" +
+				"      \"any_of\": [\n" +
+				"        \"dev\",\n" +
+				"        \"prod\"\n" +
+				"      ]\n" +
+				"    },\n" +
+				"    \"name\": \"package_name1\"\n" +
+				"  }\n" +
+				"}\n",
+		},
+		{
+			name: "multiple packages and builders",
+			project: NewProjectPolicy("package_name1").
+				AddPackage("org/*").
+				AddBuilder("builder_name1").
+				AddBuilder("builder_name2").
+				SetRepository("source_name1").
+				SetSource(SourceRequirements{Refs: []string{"refs/heads/main"}}),
+			content: "" +
+				"{\n" +
+				"  \"build\": {\n" +
+				"    \"builders\": {\n" +
+				"      \"any_of\": [\n" +
+				"        {\n" +
+				"          \"name\": \"builder_name1\"\n" +
+				"        },\n" +
+				"        {\n" +
+				"          \"name\": \"builder_name2\"\n" +
+				"        }\n" +
+				"      ]\n" +
+				"    },\n" +
+				"    \"repository\": {\n" +
+				"      \"uri\": \"source_name1\"\n" +
+				"    },\n" +
+				"    \"source\": {\n" +
+				"      \"refs\": {\n" +
+				"        \"any_of\": [\n" +
+				"          \"refs/heads/main\"\n" +
+				"        ]\n" +
+				"      }\n" +
+				"    }\n" +
+				"  },\n" +
+				"  \"format\": 1,\n" +
+				"  \"packages\": [\n" +
+				"    {\n" +
+				"      \"name\": \"org/*\"\n" +
+				"    },\n" +
+				"    {\n" +
+				"      \"name\": \"package_name1\"\n" +
+				"    }\n" +
+				"  ]\n" +
+				"}\n",
+		},
+		{
+			name:     "unknown builder",
+			project:  NewProjectPolicy("package_name1").SetBuilder("builder_name3").SetRepository("source_name1"),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "no repository",
+			project:  NewProjectPolicy("package_name1").SetBuilder("builder_name1"),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "overlapping packages",
+			project:  NewProjectPolicy("org/package_name1").AddPackage("org/*").SetBuilder("builder_name1").SetRepository("source_name1"),
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := tt.project.JSON(org)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.content, string(content)); diff != "" {
+				t.Fatalf("unexpected content (-want +got): \n%s", diff)
+			}
+
+			// The generated files must be accepted by PolicyNew().
+			orgContent, err := org.JSON()
+			if err != nil {
+				t.Fatalf("failed to marshal org: %v", err)
+			}
+			_, err = PolicyNew(io.NopCloser(bytes.NewReader(orgContent)), common.NewBytesIterator([][]byte{content}),
+				newPackageHelper("registry"))
+			if err != nil {
+				t.Fatalf("failed to create policy: %v", err)
+			}
+		})
+	}
+}
+
+func Test_ProjectPolicyBuilderDefaults(t *testing.T) {
+	t.Parallel()
+	org := NewOrgPolicy().
+		AddBuilder("builder_id1", "builder_name1", 2).
+		AddBuilder("builder_id2", "builder_name2", 3).
+		SetDefaultBuilder("builder_name2").
+		SetDefaultEnvironments("dev", "prod").
+		SetMinSlsaLevel(3)
+	tests := []struct {
+		name     string
+		project  *ProjectPolicyBuilder
+		content  string
+		expected error
+	}{
+		{
+			name:    "defaults not written",
+			project: NewProjectPolicy("package_name1").SetRepository("source_name1"),
+			content: "" +
+				"{\n" +
+				"  \"build\": {\n" +
+				"    \"repository\": {\n" +
+				"      \"uri\": \"source_name1\"\n" +
+				"    }\n" +
+				"  },\n" +
+				"  \"format\": 1,\n" +
+				"  \"package\": {\n" +
+				"    \"name\": \"package_name1\"\n" +
+				"  }\n" +
+				"}\n",
+		},
+		{
+			name:     "builder below minimum",
+			project:  NewProjectPolicy("package_name1").SetBuilder("builder_name1").SetRepository("source_name1"),
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := tt.project.JSON(org)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.content, string(content)); diff != "" {
+				t.Fatalf("unexpected content (-want +got): \n%s", diff)
+			}
+
+			// PolicyNew() applies the defaults.
+			orgContent, err := org.JSON()
+			if err != nil {
+				t.Fatalf("failed to marshal org: %v", err)
+			}
+			policy, err := PolicyNew(io.NopCloser(bytes.NewReader(orgContent)), common.NewBytesIterator([][]byte{content}),
+				newPackageHelper("registry"))
+			if err != nil {
+				t.Fatalf("failed to create policy: %v", err)
+			}
+			env := "prod"
+			builders, err := policy.PackageBuilders("package_name1", &env)
+			if err != nil {
+				t.Fatalf("failed to get builders: %v", err)
+			}
+			if diff := cmp.Diff([]PackageBuilder{{ID: "builder_id2", Name: "builder_name2", Repository: "source_name1", SlsaLevel: 3}}, builders); diff != "" {
+				t.Fatalf("unexpected builders (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
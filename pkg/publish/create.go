@@ -11,7 +11,8 @@ import (
 
 type Creation struct {
 	attestation
-	safeMode bool
+	safeMode         bool
+	digestValidation intoto.DigestValidation
 }
 
 type AttestationCreationOption func(*Creation) error
@@ -19,7 +20,8 @@ type AttestationCreationOption func(*Creation) error
 // NOTE: See https://dave.cheney.net/2014/10/17/functional-options-for-friendly-apis.
 func CreationNew(subject intoto.Subject, packageDesc intoto.PackageDescriptor,
 	options ...AttestationCreationOption) (*Creation, error) {
-	if err := subject.Validate(); err != nil {
+	subject, err := subject.Normalize(intoto.DigestValidationPermissive)
+	if err != nil {
 		return nil, err
 	}
 	if err := packageDesc.Validate(); err != nil {
@@ -70,6 +72,63 @@ func (a *Creation) isSafeMode() bool {
 	return a.safeMode
 }
 
+// AddSubjects adds subjects to the attestation, e.g. the platform
+// manifests of a multi-arch image index. A subject must not share
+// a digest with another subject.
+func AddSubjects(subjects ...intoto.Subject) AttestationCreationOption {
+	return func(a *Creation) error {
+		return a.addSubjects(subjects...)
+	}
+}
+
+func (a *Creation) addSubjects(subjects ...intoto.Subject) error {
+	if a.isSafeMode() {
+		return fmt.Errorf("%w: safe mode enabled, cannot add subjects", errs.ErrorInternal)
+	}
+	for i := range subjects {
+		subject, err := subjects[i].Normalize(a.digestValidation)
+		if err != nil {
+			return err
+		}
+		if err := validateUniqueDigests(a.attestation.Header.Subjects, subject); err != nil {
+			return err
+		}
+		a.attestation.Header.Subjects = append(a.attestation.Header.Subjects, subject)
+	}
+	return nil
+}
+
+// SetStrictDigests requires the digests of all subjects to be valid
+// in strict mode. See intoto.DigestValidationStrict.
+func SetStrictDigests() AttestationCreationOption {
+	return func(a *Creation) error {
+		return a.setStrictDigests()
+	}
+}
+
+func (a *Creation) setStrictDigests() error {
+	// NOTE: this is allowed in safe mode, since it only makes validation stricter.
+	for i := range a.attestation.Header.Subjects {
+		if err := a.attestation.Header.Subjects[i].ValidateWithMode(intoto.DigestValidationStrict); err != nil {
+			return err
+		}
+	}
+	a.digestValidation = intoto.DigestValidationStrict
+	return nil
+}
+
+func validateUniqueDigests(subjects []intoto.Subject, subject intoto.Subject) error {
+	for i := range subjects {
+		for name, value := range subjects[i].Digests {
+			if val, exists := subject.Digests[name]; exists && val == value {
+				return fmt.Errorf("%w: subject with digest (%q:%q) is present more than once", errs.ErrorInvalidInput,
+					name, value)
+			}
+		}
+	}
+	return nil
+}
+
 func SetPackageVersion(version string) AttestationCreationOption {
 	return func(a *Creation) error {
 		return a.setPackageVersion(version)
@@ -104,6 +163,71 @@ func (a *Creation) setSlsaBuildLevel(level int) error {
 	return nil
 }
 
+// SetBuildProvenance records the verified build provenance
+// as properties. Empty fields are not recorded.
+func SetBuildProvenance(provenance BuildProvenance) AttestationCreationOption {
+	return func(a *Creation) error {
+		return a.setBuildProvenance(provenance)
+	}
+}
+
+func (a *Creation) setBuildProvenance(provenance BuildProvenance) error {
+	if a.isSafeMode() {
+		return fmt.Errorf("%w: safe mode enabled, cannot edit build provenance", errs.ErrorInternal)
+	}
+	if provenance.SourceLevel < 0 || provenance.SourceLevel > 4 {
+		return fmt.Errorf("%w: source level (%v) is invalid", errs.ErrorInvalidInput, provenance.SourceLevel)
+	}
+	for name, value := range map[string]string{
+		builderIDProperty:    provenance.BuilderID,
+		buildTypeProperty:    provenance.BuildType,
+		invocationIDProperty: provenance.InvocationID,
+		sourceURIProperty:    provenance.SourceURI,
+		sourceCommitProperty: provenance.SourceCommit,
+		sourceRefProperty:    provenance.SourceRef,
+		workflowPathProperty: provenance.WorkflowPath,
+	} {
+		if value == "" {
+			continue
+		}
+		if a.attestation.Predicate.Properties == nil {
+			a.attestation.Predicate.Properties = make(map[string]interface{})
+		}
+		a.attestation.Predicate.Properties[name] = value
+	}
+	if provenance.SourceLevel > 0 {
+		if a.attestation.Predicate.Properties == nil {
+			a.attestation.Predicate.Properties = make(map[string]interface{})
+		}
+		a.attestation.Predicate.Properties[sourceLevelProperty] = provenance.SourceLevel
+	}
+	return nil
+}
+
+// AddEvidence records the attestations verified during policy
+// evaluation in the decision details.
+func AddEvidence(evidence ...intoto.ResourceDescriptor) AttestationCreationOption {
+	return func(a *Creation) error {
+		return a.addEvidence(evidence...)
+	}
+}
+
+func (a *Creation) addEvidence(evidence ...intoto.ResourceDescriptor) error {
+	if a.isSafeMode() {
+		return fmt.Errorf("%w: safe mode enabled, cannot add evidence", errs.ErrorInternal)
+	}
+	for i := range evidence {
+		if err := evidence[i].Validate(); err != nil {
+			return err
+		}
+		if a.attestation.Predicate.DecisionDetails == nil {
+			a.attestation.Predicate.DecisionDetails = &decisionDetails{}
+		}
+		a.attestation.Predicate.DecisionDetails.Evidence = append(a.attestation.Predicate.DecisionDetails.Evidence, evidence[i])
+	}
+	return nil
+}
+
 // Utility functions needed by cosign APIs.
 func (a *Creation) PredicateType() string {
 	return predicateType
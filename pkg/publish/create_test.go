@@ -15,8 +15,8 @@ func Test_CreationNew(t *testing.T) {
 	t.Parallel()
 	subject := intoto.Subject{
 		Digests: intoto.DigestSet{
-			"sha256":    "some_value",
-			"gitCommit": "another_value",
+			"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+			"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
 		},
 	}
 	packageName := "package_name"
@@ -52,7 +52,7 @@ func Test_CreationNew(t *testing.T) {
 			name: "result with empty digest value",
 			subject: intoto.Subject{
 				Digests: intoto.DigestSet{
-					"sha256":    "some_value",
+					"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
 					"gitCommit": "",
 				},
 			},
@@ -63,7 +63,7 @@ func Test_CreationNew(t *testing.T) {
 			name: "result with empty digest key",
 			subject: intoto.Subject{
 				Digests: intoto.DigestSet{
-					"sha256": "some_value",
+					"sha256": "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
 					"":       "another_value",
 				},
 			},
@@ -155,8 +155,8 @@ func Test_EnterSafeMode(t *testing.T) {
 	t.Parallel()
 	subject := intoto.Subject{
 		Digests: intoto.DigestSet{
-			"sha256":    "some_value",
-			"gitCommit": "another_value",
+			"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+			"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
 		},
 	}
 	packageName := "package_name"
@@ -232,3 +232,281 @@ func Test_EnterSafeMode(t *testing.T) {
 		})
 	}
 }
+
+func Test_AddSubjects(t *testing.T) {
+	t.Parallel()
+	subject := intoto.Subject{
+		Digests: intoto.DigestSet{
+			"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+			"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
+		},
+	}
+	other := intoto.Subject{
+		Name: "linux/amd64",
+		Digests: intoto.DigestSet{
+			"sha256": "77a73f170325fc6a4dc852ca8c5f0fdf45633eeb6dee51a903716a58d4d48e9c",
+		},
+	}
+	packageDesc := intoto.PackageDescriptor{
+		Name:     "package_name",
+		Registry: "package_registry",
+	}
+	tests := []struct {
+		name     string
+		options  []AttestationCreationOption
+		subjects []intoto.Subject
+		expected error
+	}{
+		{
+			name:     "no additional subjects",
+			options:  []AttestationCreationOption{AddSubjects()},
+			subjects: []intoto.Subject{subject},
+		},
+		{
+			name:     "additional subject",
+			options:  []AttestationCreationOption{AddSubjects(other)},
+			subjects: []intoto.Subject{subject, other},
+		},
+		{
+			name:     "invalid subject",
+			options:  []AttestationCreationOption{AddSubjects(intoto.Subject{Name: "linux/amd64"})},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "duplicate digest",
+			options: []AttestationCreationOption{AddSubjects(intoto.Subject{
+				Digests: intoto.DigestSet{
+					"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
+				},
+			})},
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name:     "duplicate subject",
+			options:  []AttestationCreationOption{AddSubjects(other, other)},
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name:     "safe mode",
+			options:  []AttestationCreationOption{EnterSafeMode(), AddSubjects(other)},
+			expected: errs.ErrorInternal,
+		},
+		{
+			name: "uppercase subject",
+			options: []AttestationCreationOption{AddSubjects(intoto.Subject{
+				Name: "linux/amd64",
+				Digests: intoto.DigestSet{
+					"sha256": "77A73F170325FC6A4DC852CA8C5F0FDF45633EEB6DEE51A903716A58D4D48E9C",
+				},
+			})},
+			subjects: []intoto.Subject{subject, other},
+		},
+		{
+			name:     "strict digests",
+			options:  []AttestationCreationOption{SetStrictDigests(), AddSubjects(other)},
+			subjects: []intoto.Subject{subject, other},
+		},
+		{
+			name: "strict digests unknown algorithm",
+			options: []AttestationCreationOption{SetStrictDigests(), AddSubjects(intoto.Subject{
+				Digests: intoto.DigestSet{
+					"custom": "some_value",
+				},
+			})},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "strict digests uppercase",
+			options: []AttestationCreationOption{SetStrictDigests(), AddSubjects(intoto.Subject{
+				Digests: intoto.DigestSet{
+					"sha256": "77A73F170325FC6A4DC852CA8C5F0FDF45633EEB6DEE51A903716A58D4D48E9C",
+				},
+			})},
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			att, err := CreationNew(subject, packageDesc, tt.options...)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.subjects, att.Header.Subjects); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetBuildProvenance(t *testing.T) {
+	t.Parallel()
+	subject := intoto.Subject{
+		Digests: intoto.DigestSet{
+			"sha256": "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+		},
+	}
+	packageDesc := intoto.PackageDescriptor{
+		Name:     "package_name",
+		Registry: "package_registry",
+	}
+	tests := []struct {
+		name       string
+		options    []AttestationCreationOption
+		properties properties
+		expected   error
+	}{
+		{
+			name: "all fields",
+			options: []AttestationCreationOption{SetBuildProvenance(BuildProvenance{
+				BuilderID:    "builder_id",
+				SourceURI:    "source_uri",
+				SourceCommit: "source_commit",
+				SourceRef:    "source_ref",
+				BuildType:    "build_type",
+				InvocationID: "invocation_id",
+				WorkflowPath: "workflow_path",
+				SourceLevel:  2,
+			})},
+			properties: properties{
+				builderIDProperty:    "builder_id",
+				sourceURIProperty:    "source_uri",
+				sourceCommitProperty: "source_commit",
+				sourceRefProperty:    "source_ref",
+				buildTypeProperty:    "build_type",
+				invocationIDProperty: "invocation_id",
+				workflowPathProperty: "workflow_path",
+				sourceLevelProperty:  2,
+			},
+		},
+		{
+			name: "invalid source level",
+			options: []AttestationCreationOption{SetBuildProvenance(BuildProvenance{
+				SourceLevel: 5,
+			})},
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name: "empty fields",
+			options: []AttestationCreationOption{SetBuildProvenance(BuildProvenance{
+				BuilderID: "builder_id",
+			})},
+			properties: properties{
+				builderIDProperty: "builder_id",
+			},
+		},
+		{
+			name:    "empty provenance",
+			options: []AttestationCreationOption{SetBuildProvenance(BuildProvenance{})},
+		},
+		{
+			name: "with level",
+			options: []AttestationCreationOption{SetSlsaBuildLevel(3), SetBuildProvenance(BuildProvenance{
+				SourceURI: "source_uri",
+			})},
+			properties: properties{
+				buildLevelProperty: 3,
+				sourceURIProperty:  "source_uri",
+			},
+		},
+		{
+			name: "safe mode",
+			options: []AttestationCreationOption{EnterSafeMode(), SetBuildProvenance(BuildProvenance{
+				BuilderID: "builder_id",
+			})},
+			expected: errs.ErrorInternal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			att, err := CreationNew(subject, packageDesc, tt.options...)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.properties, att.Predicate.Properties); diff != "" {
+				t.Fatalf("unexpected properties (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_AddEvidence(t *testing.T) {
+	t.Parallel()
+	subject := intoto.Subject{
+		Digests: intoto.DigestSet{
+			"sha256": "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+		},
+	}
+	packageDesc := intoto.PackageDescriptor{
+		Name:     "package_name",
+		Registry: "package_registry",
+	}
+	evidence := intoto.ResourceDescriptor{
+		Digest: intoto.DigestSet{
+			"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51",
+		},
+		Annotations: map[string]interface{}{
+			"predicateType": "https://slsa.dev/provenance/v1",
+		},
+	}
+	tests := []struct {
+		name            string
+		options         []AttestationCreationOption
+		decisionDetails *decisionDetails
+		expected        error
+	}{
+		{
+			name:    "single evidence",
+			options: []AttestationCreationOption{AddEvidence(evidence)},
+			decisionDetails: &decisionDetails{
+				Evidence: []intoto.ResourceDescriptor{evidence},
+			},
+		},
+		{
+			name:    "multiple options",
+			options: []AttestationCreationOption{AddEvidence(evidence), AddEvidence(intoto.ResourceDescriptor{URI: "https://example.com/att"})},
+			decisionDetails: &decisionDetails{
+				Evidence: []intoto.ResourceDescriptor{evidence, {URI: "https://example.com/att"}},
+			},
+		},
+		{
+			name:    "no evidence",
+			options: []AttestationCreationOption{AddEvidence()},
+		},
+		{
+			name:     "empty evidence",
+			options:  []AttestationCreationOption{AddEvidence(intoto.ResourceDescriptor{Name: "name"})},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "safe mode",
+			options:  []AttestationCreationOption{EnterSafeMode(), AddEvidence(evidence)},
+			expected: errs.ErrorInternal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			att, err := CreationNew(subject, packageDesc, tt.options...)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.decisionDetails, att.Predicate.DecisionDetails); diff != "" {
+				t.Fatalf("unexpected decision details (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
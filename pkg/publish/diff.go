@@ -0,0 +1,13 @@
+package publish
+
+import (
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/diff"
+)
+
+// Diff returns the semantic changes from the old policy to the new policy.
+// Changes that weaken the policy, such as a lower SLSA level or a new environment,
+// have their Weakening field set.
+func Diff(old, new *Policy) []diff.Change {
+	return internal.Diff(old.policy, new.policy)
+}
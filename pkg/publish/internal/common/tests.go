@@ -45,6 +45,20 @@ func (iter *bytesIterator) Error() error {
 	return nil
 }
 
+// Named bytes iterator.
+func NewNamedBytesIterator(values [][]byte) iterator.NamedReadCloserIterator {
+	return &namedBytesIterator{bytesIterator: bytesIterator{values: values, index: -1}}
+}
+
+type namedBytesIterator struct {
+	bytesIterator
+}
+
+func (iter *namedBytesIterator) Next() (string, io.ReadCloser) {
+	reader := iter.bytesIterator.Next()
+	return fmt.Sprintf("policy_id%d", iter.index), reader
+}
+
 // Attestation verifier.
 func NewAttestationVerifier(digests intoto.DigestSet, packageName, builderID, sourceName string) options.AttestationVerifier {
 	return &attestationVerifier{packageName: packageName,
@@ -59,11 +73,16 @@ type attestationVerifier struct {
 	digests     intoto.DigestSet
 }
 
-func (v *attestationVerifier) VerifyBuildAttestation(digests intoto.DigestSet, packageName, builderID, sourceName string) error {
+func (v *attestationVerifier) VerifyBuildAttestation(digests intoto.DigestSet, packageName, builderID, sourceName string) (options.BuildVerificationResult, error) {
 	if packageName == v.packageName && builderID == v.builderID && sourceName == v.sourceName && mapEq(digests, v.digests) {
-		return nil
+		return options.BuildVerificationResult{
+			Provenance: options.BuildProvenance{
+				BuilderID: builderID,
+				SourceURI: sourceName,
+			},
+		}, nil
 	}
-	return fmt.Errorf("%w: cannot verify package Name (%q) builder ID (%q) source Name (%q) digests (%q)",
+	return options.BuildVerificationResult{}, fmt.Errorf("%w: cannot verify package Name (%q) builder ID (%q) source Name (%q) digests (%q)",
 		errs.ErrorVerification, packageName, builderID, sourceName, digests)
 }
 
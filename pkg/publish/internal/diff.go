@@ -0,0 +1,259 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/diff"
+)
+
+// Diff returns the semantic changes from the old policy to the new policy,
+// sorted by subject. Project changes have the package name as subject.
+func Diff(old, new *Policy) []diff.Change {
+	changes := diffOrg(old.orgPolicy, new.orgPolicy)
+	for name, oldProject := range old.projectPolicies {
+		newProject, exists := new.projectPolicies[name]
+		if !exists {
+			changes = append(changes, diff.Change{
+				Kind:    diff.KindRemoved,
+				Subject: name,
+				Field:   "package",
+			})
+			continue
+		}
+		changes = append(changes, diffProject(name, oldProject, newProject, old.orgPolicy, new.orgPolicy)...)
+	}
+	for name := range new.projectPolicies {
+		if _, exists := old.projectPolicies[name]; !exists {
+			changes = append(changes, diff.Change{
+				Kind:    diff.KindAdded,
+				Subject: name,
+				Field:   "package",
+			})
+		}
+	}
+	diff.Sort(changes)
+	return changes
+}
+
+func diffOrg(old, new organization.Policy) []diff.Change {
+	var changes []diff.Change
+	oldRoots := make(map[string]organization.Root, len(old.Roots.Build))
+	for _, root := range old.Roots.Build {
+		oldRoots[root.Name] = root
+	}
+	newRoots := make(map[string]organization.Root, len(new.Roots.Build))
+	for _, root := range new.Roots.Build {
+		newRoots[root.Name] = root
+		oldRoot, exists := oldRoots[root.Name]
+		if !exists {
+			// NOTE: a new builder may be used by all the projects.
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindAdded,
+				Field:     "builder",
+				New:       rootString(root),
+				Weakening: true,
+			})
+			continue
+		}
+		// NOTE: a different builder is now trusted for all the projects using this name.
+		if oldRoot.ID != root.ID {
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindModified,
+				Field:     fmt.Sprintf("builder[%s].id", root.Name),
+				Old:       oldRoot.ID,
+				New:       root.ID,
+				Weakening: true,
+			})
+		}
+		if *oldRoot.SlsaLevel != *root.SlsaLevel {
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindModified,
+				Field:     fmt.Sprintf("builder[%s].slsa_level", root.Name),
+				Old:       fmt.Sprint(*oldRoot.SlsaLevel),
+				New:       fmt.Sprint(*root.SlsaLevel),
+				Weakening: *root.SlsaLevel > *oldRoot.SlsaLevel,
+			})
+		}
+	}
+	for _, root := range old.Roots.Build {
+		if _, exists := newRoots[root.Name]; !exists {
+			changes = append(changes, diff.Change{
+				Kind:  diff.KindRemoved,
+				Field: "builder",
+				Old:   rootString(root),
+			})
+		}
+	}
+	if oldLevel, newLevel := old.MinSlsaLevel(), new.MinSlsaLevel(); oldLevel != newLevel {
+		changes = append(changes, diff.Change{
+			Kind:      diff.KindModified,
+			Field:     "minimums.slsa_level",
+			Old:       fmt.Sprint(oldLevel),
+			New:       fmt.Sprint(newLevel),
+			Weakening: newLevel < oldLevel,
+		})
+	}
+	return changes
+}
+
+func rootString(root organization.Root) string {
+	return fmt.Sprintf("%s (id %s, level %d)", root.Name, root.ID, *root.SlsaLevel)
+}
+
+func diffProject(name string, old, new project.Policy, oldOrg, newOrg organization.Policy) []diff.Change {
+	var changes []diff.Change
+	oldEnvs, newEnvs := old.Package.Environment.AnyOf, new.Package.Environment.AnyOf
+	removedEnvs, addedEnvs := diff.Strings(oldEnvs, newEnvs)
+	if len(oldEnvs) > 0 && len(newEnvs) == 0 {
+		// The package is now published without environment.
+		changes = append(changes, diff.Change{
+			Kind:      diff.KindModified,
+			Subject:   name,
+			Field:     "environment",
+			Old:       strings.Join(oldEnvs, ","),
+			Weakening: true,
+		})
+	} else {
+		for _, env := range addedEnvs {
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindAdded,
+				Subject:   name,
+				Field:     "environment",
+				New:       env,
+				Weakening: len(oldEnvs) > 0,
+			})
+		}
+		for _, env := range removedEnvs {
+			changes = append(changes, diff.Change{
+				Kind:    diff.KindRemoved,
+				Subject: name,
+				Field:   "environment",
+				Old:     env,
+			})
+		}
+	}
+
+	// Compare the requirements of each environment.
+	_, scopes := diff.Strings(nil, append(append([]string{}, oldEnvs...), newEnvs...))
+	if len(scopes) == 0 {
+		scopes = []string{""}
+	}
+	for _, scope := range scopes {
+		suffix := ""
+		if scope != "" {
+			suffix = "[" + scope + "]"
+		}
+		oldRequirements, oldBuilders := old.EffectiveBuildRequirements(environment(oldEnvs, scope))
+		newRequirements, newBuilders := new.EffectiveBuildRequirements(environment(newEnvs, scope))
+		oldNames, newNames := builderStrings(oldBuilders), builderStrings(newBuilders)
+		if removed, added := diff.Strings(oldNames, newNames); len(removed) > 0 || len(added) > 0 {
+			// NOTE: a new builder or repository is now trusted to build the package.
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindModified,
+				Subject:   name,
+				Field:     "builders" + suffix,
+				Old:       strings.Join(oldNames, ","),
+				New:       strings.Join(newNames, ","),
+				Weakening: len(added) > 0,
+			})
+		}
+		oldLevel, newLevel := minLevel(oldBuilders, oldOrg), minLevel(newBuilders, newOrg)
+		if oldLevel != newLevel {
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindModified,
+				Subject:   name,
+				Field:     "slsa_level" + suffix,
+				Old:       fmt.Sprint(oldLevel),
+				New:       fmt.Sprint(newLevel),
+				Weakening: newLevel < oldLevel,
+			})
+		}
+		oldSource, newSource := sourceString(oldRequirements.Source), sourceString(newRequirements.Source)
+		if oldSource != newSource {
+			changes = append(changes, diff.Change{
+				Kind:      diff.KindModified,
+				Subject:   name,
+				Field:     "source" + suffix,
+				Old:       oldSource,
+				New:       newSource,
+				Weakening: weakerSource(oldRequirements.Source, newRequirements.Source),
+			})
+		}
+	}
+	return changes
+}
+
+// environment returns the environment to evaluate the requirements for.
+func environment(envs []string, scope string) *string {
+	for i := range envs {
+		if envs[i] == scope {
+			return &envs[i]
+		}
+	}
+	return nil
+}
+
+func builderStrings(builders []project.Builder) []string {
+	res := make([]string, len(builders))
+	for i, builder := range builders {
+		res[i] = fmt.Sprintf("%s (%s)", builder.Name, builder.Repository.URI)
+	}
+	return res
+}
+
+// minLevel returns the lowest SLSA level of the builders.
+func minLevel(builders []project.Builder, org organization.Policy) int {
+	level := -1
+	for _, builder := range builders {
+		builderLevel := org.BuilderSlsaLevel(builder.Name)
+		if level == -1 || builderLevel < level {
+			level = builderLevel
+		}
+	}
+	return level
+}
+
+func sourceString(source *project.SourceRequirements) string {
+	if source == nil {
+		return ""
+	}
+	// NOTE: marshaling a struct cannot fail.
+	content, _ := json.Marshal(source)
+	return string(content)
+}
+
+// weakerSource returns true if the new source requirements
+// drop a requirement, widen the patterns or lower the source level.
+func weakerSource(old, new *project.SourceRequirements) bool {
+	if old == nil {
+		return false
+	}
+	if new == nil {
+		return true
+	}
+	if widerPatterns(old.Refs, new.Refs) || widerPatterns(old.Workflows, new.Workflows) {
+		return true
+	}
+	oldLevel, newLevel := 0, 0
+	if old.RequireSlsaLevel != nil {
+		oldLevel = *old.RequireSlsaLevel
+	}
+	if new.RequireSlsaLevel != nil {
+		newLevel = *new.RequireSlsaLevel
+	}
+	return newLevel < oldLevel
+}
+
+// widerPatterns returns true if the new patterns drop the restriction
+// or add a pattern, which may match values the old patterns did not.
+func widerPatterns(old, new project.Patterns) bool {
+	if len(old.AnyOf) == 0 {
+		return false
+	}
+	_, added := diff.Strings(old.AnyOf, new.AnyOf)
+	return len(new.AnyOf) == 0 || len(added) > 0
+}
@@ -0,0 +1,468 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/diff"
+)
+
+func Test_Diff(t *testing.T) {
+	t.Parallel()
+	org := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Build: []organization.Root{
+				{
+					ID:        "builder_id1",
+					Name:      "builder_name1",
+					SlsaLevel: common.AsPointer(2),
+				},
+				{
+					ID:        "builder_id2",
+					Name:      "builder_name2",
+					SlsaLevel: common.AsPointer(3),
+				},
+			},
+		},
+	}
+	org3 := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Build: append(append([]organization.Root{}, org.Roots.Build...), organization.Root{
+				ID:        "builder_id3",
+				Name:      "builder_name3",
+				SlsaLevel: common.AsPointer(3),
+			}),
+		},
+	}
+	project1 := project.Policy{
+		Format: 1,
+		Package: project.Package{
+			Name: "package_name1",
+			Environment: project.Environment{
+				AnyOf: []string{"dev", "prod"},
+			},
+		},
+		BuildRequirements: project.BuildRequirements{
+			RequireSlsaBuilder: "builder_name2",
+			Repository: project.Repository{
+				URI: "source_name1",
+			},
+		},
+	}
+	project2 := project.Policy{
+		Format: 1,
+		Package: project.Package{
+			Name: "package_name2",
+		},
+		BuildRequirements: project.BuildRequirements{
+			RequireSlsaBuilder: "builder_name2",
+			Repository: project.Repository{
+				URI: "source_name2",
+			},
+		},
+	}
+	withSource := func(source *project.SourceRequirements) project.Policy {
+		policy := project2
+		policy.BuildRequirements.Source = source
+		return policy
+	}
+	tests := []struct {
+		name        string
+		oldOrg      organization.Policy
+		newOrg      organization.Policy
+		oldProjects []project.Policy
+		newProjects []project.Policy
+		expected    []diff.Change
+	}{
+		{
+			name:        "no changes",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1, project2},
+			newProjects: []project.Policy{project1, project2},
+		},
+		{
+			name:   "org changes",
+			oldOrg: org,
+			newOrg: organization.Policy{
+				Format: 1,
+				Roots: organization.Roots{
+					Build: []organization.Root{
+						{
+							ID:        "builder_id1",
+							Name:      "builder_name1",
+							SlsaLevel: common.AsPointer(3),
+						},
+						{
+							ID:        "builder_id3",
+							Name:      "builder_name3",
+							SlsaLevel: common.AsPointer(3),
+						},
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindAdded,
+					Field:     "builder",
+					New:       "builder_name3 (id builder_id3, level 3)",
+					Weakening: true,
+				},
+				{
+					Kind:  diff.KindRemoved,
+					Field: "builder",
+					Old:   "builder_name2 (id builder_id2, level 3)",
+				},
+				{
+					Kind:      diff.KindModified,
+					Field:     "builder[builder_name1].slsa_level",
+					Old:       "2",
+					New:       "3",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name: "minimum lowered",
+			oldOrg: organization.Policy{
+				Format: 1,
+				Roots:  org.Roots,
+				Minimums: &organization.Minimums{
+					Build: &organization.BuildMinimums{
+						SlsaLevel: common.AsPointer(3),
+					},
+				},
+			},
+			newOrg: org,
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Field:     "minimums.slsa_level",
+					Old:       "3",
+					New:       "0",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:   "builder added",
+			oldOrg: org,
+			newOrg: org3,
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindAdded,
+					Field:     "builder",
+					New:       "builder_name3 (id builder_id3, level 3)",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:        "builder repository changed",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project2},
+			newProjects: []project.Policy{
+				{
+					Format: 1,
+					Package: project.Package{
+						Name: "package_name2",
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaBuilder: "builder_name2",
+						Repository: project.Repository{
+							URI: "source_other",
+						},
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Subject:   "package_name2",
+					Field:     "builders",
+					Old:       "builder_name2 (source_name2)",
+					New:       "builder_name2 (source_other)",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:        "any_of builder added",
+			oldOrg:      org3,
+			newOrg:      org3,
+			oldProjects: []project.Policy{project2},
+			newProjects: []project.Policy{
+				{
+					Format: 1,
+					Package: project.Package{
+						Name: "package_name2",
+					},
+					BuildRequirements: project.BuildRequirements{
+						Builders: &project.Builders{
+							AnyOf: []project.Builder{
+								{Name: "builder_name2"},
+								{Name: "builder_name3"},
+							},
+						},
+						Repository: project.Repository{
+							URI: "source_name2",
+						},
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Subject:   "package_name2",
+					Field:     "builders",
+					Old:       "builder_name2 (source_name2)",
+					New:       "builder_name2 (source_name2),builder_name3 (source_name2)",
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:   "any_of builder removed",
+			oldOrg: org3,
+			newOrg: org3,
+			oldProjects: []project.Policy{
+				{
+					Format: 1,
+					Package: project.Package{
+						Name: "package_name2",
+					},
+					BuildRequirements: project.BuildRequirements{
+						Builders: &project.Builders{
+							AnyOf: []project.Builder{
+								{Name: "builder_name2"},
+								{Name: "builder_name3"},
+							},
+						},
+						Repository: project.Repository{
+							URI: "source_name2",
+						},
+					},
+				},
+			},
+			newProjects: []project.Policy{project2},
+			expected: []diff.Change{
+				{
+					Kind:    diff.KindModified,
+					Subject: "package_name2",
+					Field:   "builders",
+					Old:     "builder_name2 (source_name2),builder_name3 (source_name2)",
+					New:     "builder_name2 (source_name2)",
+				},
+			},
+		},
+		{
+			name:   "refs widened",
+			oldOrg: org,
+			newOrg: org,
+			oldProjects: []project.Policy{withSource(&project.SourceRequirements{
+				Refs: project.Patterns{AnyOf: []string{"refs/heads/main"}},
+			})},
+			newProjects: []project.Policy{withSource(&project.SourceRequirements{
+				Refs: project.Patterns{AnyOf: []string{"refs/heads/main", "refs/heads/*"}},
+			})},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Subject:   "package_name2",
+					Field:     "source",
+					Old:       `{"refs":{"any_of":["refs/heads/main"]},"workflows":{}}`,
+					New:       `{"refs":{"any_of":["refs/heads/main","refs/heads/*"]},"workflows":{}}`,
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:   "workflows widened",
+			oldOrg: org,
+			newOrg: org,
+			oldProjects: []project.Policy{withSource(&project.SourceRequirements{
+				Workflows: project.Patterns{AnyOf: []string{".github/workflows/release.yml"}},
+			})},
+			newProjects: []project.Policy{withSource(&project.SourceRequirements{
+				Workflows: project.Patterns{AnyOf: []string{".github/workflows/*.yml"}},
+			})},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Subject:   "package_name2",
+					Field:     "source",
+					Old:       `{"refs":{},"workflows":{"any_of":[".github/workflows/release.yml"]}}`,
+					New:       `{"refs":{},"workflows":{"any_of":[".github/workflows/*.yml"]}}`,
+					Weakening: true,
+				},
+			},
+		},
+		{
+			name:   "refs narrowed",
+			oldOrg: org,
+			newOrg: org,
+			oldProjects: []project.Policy{withSource(&project.SourceRequirements{
+				Refs: project.Patterns{AnyOf: []string{"refs/heads/main", "refs/heads/*"}},
+			})},
+			newProjects: []project.Policy{withSource(&project.SourceRequirements{
+				Refs: project.Patterns{AnyOf: []string{"refs/heads/main"}},
+			})},
+			expected: []diff.Change{
+				{
+					Kind:    diff.KindModified,
+					Subject: "package_name2",
+					Field:   "source",
+					Old:     `{"refs":{"any_of":["refs/heads/main","refs/heads/*"]},"workflows":{}}`,
+					New:     `{"refs":{"any_of":["refs/heads/main"]},"workflows":{}}`,
+				},
+			},
+		},
+		{
+			name:        "packages added and removed",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1},
+			newProjects: []project.Policy{project2},
+			expected: []diff.Change{
+				{
+					Kind:    diff.KindRemoved,
+					Subject: "package_name1",
+					Field:   "package",
+				},
+				{
+					Kind:    diff.KindAdded,
+					Subject: "package_name2",
+					Field:   "package",
+				},
+			},
+		},
+		{
+			name:        "project changes",
+			oldOrg:      org,
+			newOrg:      org,
+			oldProjects: []project.Policy{project1, project2},
+			newProjects: []project.Policy{
+				{
+					Format: 1,
+					Package: project.Package{
+						Name: "package_name1",
+						Environment: project.Environment{
+							AnyOf: []string{"prod", "staging"},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaBuilder: "builder_name2",
+						Repository: project.Repository{
+							URI: "source_name1",
+						},
+						Environments: map[string]project.EnvironmentBuildRequirements{
+							"staging": {
+								RequireSlsaBuilder: "builder_name1",
+							},
+						},
+					},
+				},
+				{
+					Format: 1,
+					Package: project.Package{
+						Name: "package_name2",
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaBuilder: "builder_name2",
+						Repository: project.Repository{
+							URI: "source_name2",
+						},
+						Source: &project.SourceRequirements{
+							Refs: project.Patterns{
+								AnyOf: []string{"refs/heads/main"},
+							},
+						},
+					},
+				},
+			},
+			expected: []diff.Change{
+				{
+					Kind:      diff.KindModified,
+					Subject:   "package_name1",
+					Field:     "builders[staging]",
+					Old:       "builder_name2 (source_name1)",
+					New:       "builder_name1 (source_name1)",
+					Weakening: true,
+				},
+				{
+					Kind:      diff.KindAdded,
+					Subject:   "package_name1",
+					Field:     "environment",
+					New:       "staging",
+					Weakening: true,
+				},
+				{
+					Kind:    diff.KindRemoved,
+					Subject: "package_name1",
+					Field:   "environment",
+					Old:     "dev",
+				},
+				{
+					Kind:      diff.KindModified,
+					Subject:   "package_name1",
+					Field:     "slsa_level[staging]",
+					Old:       "3",
+					New:       "2",
+					Weakening: true,
+				},
+				{
+					Kind:    diff.KindModified,
+					Subject: "package_name2",
+					Field:   "source",
+					New:     `{"refs":{"any_of":["refs/heads/main"]},"workflows":{}}`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			oldPolicy := newDiffPolicy(t, tt.oldOrg, tt.oldProjects)
+			newPolicy := newDiffPolicy(t, tt.newOrg, tt.newProjects)
+			changes := Diff(oldPolicy, newPolicy)
+			if diff := cmp.Diff(tt.expected, changes); diff != "" {
+				t.Fatalf("unexpected changes (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func newDiffPolicy(t *testing.T, org organization.Policy, projects []project.Policy) *Policy {
+	// Marshal the org policy.
+	content, err := json.Marshal(org)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	orgReader := io.NopCloser(bytes.NewReader(content))
+	// Marshal the project policies into bytes.
+	policies := make([][]byte, len(projects), len(projects))
+	for i := range projects {
+		content, err := json.Marshal(projects[i])
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		policies[i] = content
+	}
+	policy, err := PolicyNewFromNamedReaders(orgReader, common.NewNamedBytesIterator(policies), nil)
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	return policy
+}
@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+// recommendedSlsaLevel is the SLSA build level below which builders are reported.
+const recommendedSlsaLevel = 3
+
+// Lint rules of the publish policy.
+var (
+	ruleBuilderBelowOrgMax = lint.Rule{
+		ID:          "PUB001",
+		Name:        "builder-level-below-org-max",
+		Description: "A project allows a builder whose SLSA level is lower than the highest level of the org builders.",
+		Severity:    lint.SeverityWarning,
+	}
+	ruleLowLevelBuilder = lint.Rule{
+		ID:          "PUB002",
+		Name:        "low-level-builder",
+		Description: fmt.Sprintf("The org policy trusts a builder whose SLSA level is lower than %d.", recommendedSlsaLevel),
+		Severity:    lint.SeverityNote,
+	}
+	rulePackageWithoutEnvironment = lint.Rule{
+		ID:          "PUB003",
+		Name:        "package-without-environment",
+		Description: "A package is published without environment, so its deployments cannot be restricted to an environment, e.g. prod.",
+		Severity:    lint.SeverityWarning,
+	}
+	ruleDuplicateRepository = lint.Rule{
+		ID:          "PUB004",
+		Name:        "duplicate-repository",
+		Description: "A source repository is used by several project policies.",
+		Severity:    lint.SeverityWarning,
+	}
+	ruleUnusedEnvironment = lint.Rule{
+		ID:          "PUB005",
+		Name:        "unused-environment",
+		Description: "An environment is used by a single project policy, which may be a typo.",
+		Severity:    lint.SeverityNote,
+	}
+)
+
+// LintRules returns the lint rules of the publish policy.
+func LintRules() []lint.Rule {
+	return []lint.Rule{
+		ruleBuilderBelowOrgMax,
+		ruleLowLevelBuilder,
+		rulePackageWithoutEnvironment,
+		ruleDuplicateRepository,
+		ruleUnusedEnvironment,
+	}
+}
+
+func newFinding(rule lint.Rule, location, format string, a ...any) lint.Finding {
+	return lint.Finding{
+		RuleID:   rule.ID,
+		Severity: rule.Severity,
+		Location: location,
+		Message:  fmt.Sprintf(format, a...),
+	}
+}
+
+// Lint returns the findings of the lint rules, sorted by location.
+func (p *Policy) Lint() []lint.Finding {
+	var findings []lint.Finding
+	// Org rules.
+	maxLevel := 0
+	for _, builder := range p.orgPolicy.Roots.Build {
+		maxLevel = max(maxLevel, *builder.SlsaLevel)
+		if *builder.SlsaLevel < recommendedSlsaLevel {
+			findings = append(findings, newFinding(ruleLowLevelBuilder, "",
+				"builder (%q) has SLSA level %d", builder.Name, *builder.SlsaLevel))
+		}
+	}
+
+	// Project rules. A file may declare several packages, so
+	// group the packages by the file they are defined in.
+	files := make(map[string][]project.Policy)
+	for _, policy := range p.projectPolicies {
+		location := policyLocation(policy)
+		files[location] = append(files[location], policy)
+	}
+	repositories := make(map[string][]string)
+	environments := make(map[string][]string)
+	for location, policies := range files {
+		// NOTE: the packages of a file share the build requirements.
+		candidates := policies[0].Candidates()
+		var names []string
+		for _, candidate := range candidates {
+			if slices.Contains(names, candidate.Name) {
+				continue
+			}
+			names = append(names, candidate.Name)
+			level := p.orgPolicy.BuilderSlsaLevel(candidate.Name)
+			if level < maxLevel {
+				findings = append(findings, newFinding(ruleBuilderBelowOrgMax, location,
+					"builder (%q) has SLSA level %d, lower than the org's highest level %d", candidate.Name, level, maxLevel))
+			}
+		}
+		for _, candidate := range candidates {
+			uri := candidate.Repository.URI
+			if !slices.Contains(repositories[uri], location) {
+				repositories[uri] = append(repositories[uri], location)
+			}
+		}
+		for _, policy := range policies {
+			if len(policy.Package.Environment.AnyOf) == 0 {
+				findings = append(findings, newFinding(rulePackageWithoutEnvironment, location,
+					"package (%q) has no environment", policy.Package.Name))
+			}
+			for _, env := range policy.Package.Environment.AnyOf {
+				if !slices.Contains(environments[env], location) {
+					environments[env] = append(environments[env], location)
+				}
+			}
+		}
+	}
+	for uri, locations := range repositories {
+		if len(locations) < 2 {
+			continue
+		}
+		for _, location := range locations {
+			others := slices.DeleteFunc(slices.Clone(locations), func(other string) bool {
+				return other == location
+			})
+			slices.Sort(others)
+			findings = append(findings, newFinding(ruleDuplicateRepository, location,
+				"repository (%q) is also used by (%q)", uri, others))
+		}
+	}
+	// NOTE: with a single project policy, all environments are used once.
+	if len(files) > 1 {
+		for env, locations := range environments {
+			if len(locations) == 1 {
+				findings = append(findings, newFinding(ruleUnusedEnvironment, locations[0],
+					"environment (%q) is not used by other project policies", env))
+			}
+		}
+	}
+	lint.Sort(findings)
+	return findings
+}
+
+// policyLocation returns the location of a policy: its ID if known,
+// or its package name.
+func policyLocation(policy project.Policy) string {
+	if policy.ID() != "" {
+		return policy.ID()
+	}
+	return policy.Package.Name
+}
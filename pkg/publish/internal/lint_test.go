@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+func Test_Lint(t *testing.T) {
+	t.Parallel()
+	org := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Build: []organization.Root{
+				{
+					ID:        "builder_id1",
+					Name:      "builder_name1",
+					SlsaLevel: common.AsPointer(2),
+				},
+				{
+					ID:        "builder_id2",
+					Name:      "builder_name2",
+					SlsaLevel: common.AsPointer(3),
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		projects []project.Policy
+		expected []lint.Finding
+	}{
+		{
+			name: "no project findings",
+			projects: []project.Policy{
+				{
+					Format: 1,
+					Package: project.Package{
+						Name: "package_name1",
+						Environment: project.Environment{
+							AnyOf: []string{"dev", "prod"},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaBuilder: "builder_name2",
+						Repository: project.Repository{
+							URI: "source_name1",
+						},
+					},
+				},
+				{
+					Format: 1,
+					Package: project.Package{
+						Name: "package_name2",
+						Environment: project.Environment{
+							AnyOf: []string{"dev", "prod"},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaBuilder: "builder_name2",
+						Repository: project.Repository{
+							URI: "source_name2",
+						},
+					},
+				},
+			},
+			expected: []lint.Finding{
+				{
+					RuleID:   ruleLowLevelBuilder.ID,
+					Severity: lint.SeverityNote,
+					Message:  `builder ("builder_name1") has SLSA level 2`,
+				},
+			},
+		},
+		{
+			name: "project findings",
+			projects: []project.Policy{
+				{
+					Format: 1,
+					Packages: []project.Package{
+						{
+							Name: "package_name1",
+						},
+						{
+							Name: "package_name2",
+							Environment: project.Environment{
+								AnyOf: []string{"dev", "prod"},
+							},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaBuilder: "builder_name2",
+						Repository: project.Repository{
+							URI: "source_name1",
+						},
+						Environments: map[string]project.EnvironmentBuildRequirements{
+							"dev": {
+								RequireSlsaBuilder: "builder_name1",
+							},
+						},
+					},
+				},
+				{
+					Format: 1,
+					Package: project.Package{
+						Name: "package_name3",
+						Environment: project.Environment{
+							AnyOf: []string{"prod", "staging"},
+						},
+					},
+					BuildRequirements: project.BuildRequirements{
+						RequireSlsaBuilder: "builder_name2",
+						Repository: project.Repository{
+							URI: "source_name1",
+						},
+					},
+				},
+			},
+			expected: []lint.Finding{
+				{
+					RuleID:   ruleLowLevelBuilder.ID,
+					Severity: lint.SeverityNote,
+					Message:  `builder ("builder_name1") has SLSA level 2`,
+				},
+				{
+					RuleID:   ruleBuilderBelowOrgMax.ID,
+					Severity: lint.SeverityWarning,
+					Location: "policy_id0",
+					Message:  `builder ("builder_name1") has SLSA level 2, lower than the org's highest level 3`,
+				},
+				{
+					RuleID:   rulePackageWithoutEnvironment.ID,
+					Severity: lint.SeverityWarning,
+					Location: "policy_id0",
+					Message:  `package ("package_name1") has no environment`,
+				},
+				{
+					RuleID:   ruleDuplicateRepository.ID,
+					Severity: lint.SeverityWarning,
+					Location: "policy_id0",
+					Message:  `repository ("source_name1") is also used by (["policy_id1"])`,
+				},
+				{
+					RuleID:   ruleUnusedEnvironment.ID,
+					Severity: lint.SeverityNote,
+					Location: "policy_id0",
+					Message:  `environment ("dev") is not used by other project policies`,
+				},
+				{
+					RuleID:   ruleDuplicateRepository.ID,
+					Severity: lint.SeverityWarning,
+					Location: "policy_id1",
+					Message:  `repository ("source_name1") is also used by (["policy_id0"])`,
+				},
+				{
+					RuleID:   ruleUnusedEnvironment.ID,
+					Severity: lint.SeverityNote,
+					Location: "policy_id1",
+					Message:  `environment ("staging") is not used by other project policies`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Marshal the org policy.
+			content, err := json.Marshal(org)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			orgReader := io.NopCloser(bytes.NewReader(content))
+			// Marshal the project policies into bytes.
+			policies := make([][]byte, len(tt.projects), len(tt.projects))
+			for i := range tt.projects {
+				content, err := json.Marshal(tt.projects[i])
+				if err != nil {
+					t.Fatalf("failed to marshal: %v", err)
+				}
+				policies[i] = content
+			}
+			projectsReader := common.NewNamedBytesIterator(policies)
+			policy, err := PolicyNewFromNamedReaders(orgReader, projectsReader, nil)
+			if err != nil {
+				t.Fatalf("failed to create policy: %v", err)
+			}
+			findings := policy.Lint()
+			if diff := cmp.Diff(tt.expected, findings); diff != "" {
+				t.Fatalf("unexpected findings (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
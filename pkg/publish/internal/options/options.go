@@ -1,13 +1,37 @@
 package options
 
 import (
+	"encoding/json"
+
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 )
 
 // AttestationVerifier defines an interface to verify attestations.
 type AttestationVerifier interface {
 	// Build attestations.
-	VerifyBuildAttestation(digests intoto.DigestSet, publishName, builderID, sourceName string) error
+	VerifyBuildAttestation(digests intoto.DigestSet, publishName, builderID, sourceName string) (BuildVerificationResult, error)
+}
+
+// BuildVerificationResult defines the result of a build attestation verification.
+type BuildVerificationResult struct {
+	Provenance         BuildProvenance
+	Materials          []intoto.ResourceDescriptor
+	PredicateType      string
+	Predicate          json.RawMessage
+	AttestationDigests intoto.DigestSet
+	BuilderName        string
+}
+
+// BuildProvenance defines the facts verified in a build attestation.
+type BuildProvenance struct {
+	BuilderID    string
+	SourceURI    string
+	SourceCommit string
+	SourceRef    string
+	BuildType    string
+	InvocationID string
+	WorkflowPath string
+	SourceLevel  int
 }
 
 // BuildVerification defines the configuration to verify
@@ -39,3 +63,26 @@ type ValidationEnvironment struct {
 type PolicyValidator interface {
 	ValidatePackage(pkg ValidationPackage) error
 }
+
+// PublishedPackage defines a package the policy allows to publish.
+// The name is a package name or a pattern ending with '*'.
+type PublishedPackage struct {
+	Name         string
+	Environments []string
+}
+
+// OrgBuilder defines a builder trusted by the org policy.
+type OrgBuilder struct {
+	ID        string
+	Name      string
+	SlsaLevel int
+}
+
+// PackageBuilder defines a builder allowed to build a package,
+// with the repository it must build from.
+type PackageBuilder struct {
+	ID         string
+	Name       string
+	Repository string
+	SlsaLevel  int
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"slices"
 
 	"github.com/slsa-framework/slsa-policy/pkg/errs"
 	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/options"
@@ -13,9 +14,9 @@ import (
 
 // Root defines a trusted root.
 type Root struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	SlsaLevel *int   `json:"slsa_level"`
+	ID        string `json:"id" schema:"required"`
+	Name      string `json:"name" schema:"required"`
+	SlsaLevel *int   `json:"slsa_level" schema:"required,enum=0|1|2|3|4"`
 	// TODO: list of repositories the builder is allowed to attest to:
 	// example: GitHub can attest to github.com/* only, GCB can attest to github.com/*
 	// gitlab.com/*, etc.
@@ -23,13 +24,47 @@ type Root struct {
 
 // Roots defines a set of truted roots.
 type Roots struct {
-	Build []Root `json:"build"`
+	Build []Root `json:"build" schema:"required"`
+}
+
+// Defaults defines the values of the project policies' fields
+// that the project policies do not set.
+type Defaults struct {
+	Build       *BuildDefaults       `json:"build,omitempty"`
+	Environment *EnvironmentDefaults `json:"environment,omitempty"`
+}
+
+// BuildDefaults defines the default build requirements.
+type BuildDefaults struct {
+	// RequireSlsaBuilder is the name of the builder of the project
+	// policies that set neither require_slsa_builder nor builders.
+	RequireSlsaBuilder string `json:"require_slsa_builder" schema:"required"`
+}
+
+// EnvironmentDefaults defines the environments of the packages
+// that do not set any.
+type EnvironmentDefaults struct {
+	AnyOf []string `json:"any_of" schema:"required"`
+}
+
+// Minimums defines the requirements the project policies cannot lower.
+type Minimums struct {
+	Build *BuildMinimums `json:"build,omitempty"`
+}
+
+// BuildMinimums defines the minimum build requirements.
+type BuildMinimums struct {
+	// SlsaLevel is the minimum level of the builders
+	// the project policies may use.
+	SlsaLevel *int `json:"slsa_level" schema:"required,enum=0|1|2|3|4"`
 }
 
 // Policy defines the policy.
 type Policy struct {
-	Format int   `json:"format"`
-	Roots  Roots `json:"roots"`
+	Format   int       `json:"format" schema:"required,enum=1"`
+	Roots    Roots     `json:"roots" schema:"required"`
+	Defaults *Defaults `json:"defaults,omitempty"`
+	Minimums *Minimums `json:"minimums,omitempty"`
 }
 
 // FromReader creates a new instance of a Policy from an IO reader.
@@ -50,6 +85,11 @@ func FromReader(reader io.ReadCloser) (*Policy, error) {
 	return &org, nil
 }
 
+// Validate validates the policy, e.g. one created programmatically.
+func (p *Policy) Validate() error {
+	return p.validate()
+}
+
 // validate validates the format of the policy.
 func (p *Policy) validate() error {
 	if err := p.validateFormat(); err != nil {
@@ -58,6 +98,12 @@ func (p *Policy) validate() error {
 	if err := p.validateBuildRoots(); err != nil {
 		return err
 	}
+	if err := p.validateMinimums(); err != nil {
+		return err
+	}
+	if err := p.validateDefaults(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -113,6 +159,84 @@ func (p *Policy) validateBuildRoots() error {
 	return nil
 }
 
+func (p *Policy) validateMinimums() error {
+	if p.Minimums == nil || p.Minimums.Build == nil {
+		return nil
+	}
+	level := p.Minimums.Build.SlsaLevel
+	// Level must be defined and in the correct range.
+	if level == nil {
+		return fmt.Errorf("[organization] %w: minimums's slsa_level is not defined", errs.ErrorInvalidField)
+	}
+	if *level < 0 || *level > 4 {
+		return fmt.Errorf("[organization] %w: minimums's slsa_level is invalid (%d). Must satisfy 0 <= slsa_level <= 4",
+			errs.ErrorInvalidField, *level)
+	}
+	// At least one builder must satisfy the minimum.
+	for i := range p.Roots.Build {
+		if *p.Roots.Build[i].SlsaLevel >= *level {
+			return nil
+		}
+	}
+	return fmt.Errorf("[organization] %w: minimums's slsa_level (%d) is not satisfied by any builder",
+		errs.ErrorInvalidField, *level)
+}
+
+func (p *Policy) validateDefaults() error {
+	if p.Defaults == nil {
+		return nil
+	}
+	if build := p.Defaults.Build; build != nil {
+		// The default builder must be one of the builders and satisfy the minimum.
+		if !slices.Contains(p.RootBuilderNames(), build.RequireSlsaBuilder) {
+			return fmt.Errorf("[organization] %w: defaults's require_slsa_builder has unexpected value (%q). Must be one of %q",
+				errs.ErrorInvalidField, build.RequireSlsaBuilder, p.RootBuilderNames())
+		}
+		if level := p.BuilderSlsaLevel(build.RequireSlsaBuilder); level < p.MinSlsaLevel() {
+			return fmt.Errorf("[organization] %w: defaults's require_slsa_builder (%q) has level (%d) < minimums's slsa_level (%d)",
+				errs.ErrorInvalidField, build.RequireSlsaBuilder, level, p.MinSlsaLevel())
+		}
+	}
+	if env := p.Defaults.Environment; env != nil {
+		// Environments must be defined and non-empty.
+		if len(env.AnyOf) == 0 {
+			return fmt.Errorf("[organization] %w: defaults's environment any_of is empty", errs.ErrorInvalidField)
+		}
+		for _, val := range env.AnyOf {
+			if val == "" {
+				return fmt.Errorf("[organization] %w: defaults's environment any_of has an empty field", errs.ErrorInvalidField)
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultBuilder returns the name of the default builder, or an empty string
+// if there is none.
+func (p *Policy) DefaultBuilder() string {
+	if p.Defaults == nil || p.Defaults.Build == nil {
+		return ""
+	}
+	return p.Defaults.Build.RequireSlsaBuilder
+}
+
+// DefaultEnvironments returns the default environments of the packages.
+func (p *Policy) DefaultEnvironments() []string {
+	if p.Defaults == nil || p.Defaults.Environment == nil {
+		return nil
+	}
+	return p.Defaults.Environment.AnyOf
+}
+
+// MinSlsaLevel returns the minimum level of the builders the project
+// policies may use, or 0 if there is none.
+func (p *Policy) MinSlsaLevel() int {
+	if p.Minimums == nil || p.Minimums.Build == nil || p.Minimums.Build.SlsaLevel == nil {
+		return 0
+	}
+	return *p.Minimums.Build.SlsaLevel
+}
+
 // BuilderNames returns the list of trusted builder names.
 func (p *Policy) RootBuilderNames() []string {
 	var names []string
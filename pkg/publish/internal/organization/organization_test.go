@@ -628,3 +628,111 @@ func Test_Evaluate(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateDefaultsAndMinimums(t *testing.T) {
+	t.Parallel()
+
+	roots := Roots{
+		Build: []Root{
+			{
+				ID:        "builder_id1",
+				Name:      "builder_name1",
+				SlsaLevel: common.AsPointer(2),
+			},
+			{
+				ID:        "builder_id2",
+				Name:      "builder_name2",
+				SlsaLevel: common.AsPointer(3),
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		defaults *Defaults
+		minimums *Minimums
+		expected error
+	}{
+		{
+			name: "no defaults or minimums",
+		},
+		{
+			name: "valid defaults and minimums",
+			defaults: &Defaults{
+				Build:       &BuildDefaults{RequireSlsaBuilder: "builder_name2"},
+				Environment: &EnvironmentDefaults{AnyOf: []string{"dev", "prod"}},
+			},
+			minimums: &Minimums{
+				Build: &BuildMinimums{SlsaLevel: common.AsPointer(3)},
+			},
+		},
+		{
+			name: "unknown default builder",
+			defaults: &Defaults{
+				Build: &BuildDefaults{RequireSlsaBuilder: "builder_name3"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "default builder below minimum",
+			defaults: &Defaults{
+				Build: &BuildDefaults{RequireSlsaBuilder: "builder_name1"},
+			},
+			minimums: &Minimums{
+				Build: &BuildMinimums{SlsaLevel: common.AsPointer(3)},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "empty default environments",
+			defaults: &Defaults{
+				Environment: &EnvironmentDefaults{},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "empty default environment",
+			defaults: &Defaults{
+				Environment: &EnvironmentDefaults{AnyOf: []string{"dev", ""}},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "minimum not defined",
+			minimums: &Minimums{
+				Build: &BuildMinimums{},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "minimum out of range",
+			minimums: &Minimums{
+				Build: &BuildMinimums{SlsaLevel: common.AsPointer(5)},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "minimum not satisfied by any builder",
+			minimums: &Minimums{
+				Build: &BuildMinimums{SlsaLevel: common.AsPointer(4)},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy := Policy{
+				Format:   1,
+				Roots:    roots,
+				Defaults: tt.defaults,
+				Minimums: tt.minimums,
+			}
+			err := policy.validate()
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package internal
 import (
 	"fmt"
 	"io"
+	"slices"
+	"sort"
 
 	"github.com/slsa-framework/slsa-policy/pkg/errs"
 	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/options"
@@ -32,30 +34,159 @@ func PolicyNew(org io.ReadCloser, projects iterator.ReadCloserIterator, validato
 	}, nil
 }
 
-func (p *Policy) Evaluate(digests intoto.DigestSet, packageName string, reqOpts options.Request, buildOpts options.BuildVerification) (int, error) {
+// PolicyNewFromNamedReaders creates a policy from named project readers.
+// The IDs of the readers, e.g. their paths, are recorded in the project policies.
+func PolicyNewFromNamedReaders(org io.ReadCloser, projects iterator.NamedReadCloserIterator, validator options.PolicyValidator) (*Policy, error) {
+	orgPolicy, err := organization.FromReader(org)
+	if err != nil {
+		return nil, err
+	}
+	projectPolicies, err := project.FromNamedReaders(projects, *orgPolicy, validator)
+	if err != nil {
+		return nil, err
+	}
+	return &Policy{
+		orgPolicy:       *orgPolicy,
+		projectPolicies: projectPolicies,
+	}, nil
+}
+
+func (p *Policy) Evaluate(digests intoto.DigestSet, packageName string, reqOpts options.Request, buildOpts options.BuildVerification) (int, options.BuildVerificationResult, error) {
 	if packageName == "" {
-		return -1, fmt.Errorf("%w: package name is empty", errs.ErrorInvalidInput)
+		return -1, options.BuildVerificationResult{}, fmt.Errorf("%w: package name is empty", errs.ErrorInvalidInput)
 	}
 	return p.evaluateBuildPolicy(digests, packageName, reqOpts, buildOpts)
 }
 
-func (p *Policy) evaluateBuildPolicy(digests intoto.DigestSet, packageName string, reqOpts options.Request, buildOpts options.BuildVerification) (int, error) {
+func (p *Policy) evaluateBuildPolicy(digests intoto.DigestSet, packageName string, reqOpts options.Request, buildOpts options.BuildVerification) (int, options.BuildVerificationResult, error) {
 	// Get the project policy for the artifact.
-	projectPolicy, exists := p.projectPolicies[packageName]
+	projectPolicy, exists := project.Find(p.projectPolicies, packageName)
 	if !exists {
-		return -1, fmt.Errorf("%w: package's name (%q) not present in project policies", errs.ErrorNotFound, packageName)
+		return -1, options.BuildVerificationResult{}, fmt.Errorf("%w: package's name (%q) not present in project policies", errs.ErrorNotFound, packageName)
 	}
 
 	// Evaluate the org policy.
 	err := p.orgPolicy.Evaluate(digests, packageName, reqOpts, buildOpts)
 	if err != nil {
-		return -1, err
+		return -1, options.BuildVerificationResult{}, err
 	}
 
 	// Evaluate the project policy.
-	level, err := projectPolicy.Evaluate(digests, packageName, p.orgPolicy, reqOpts, buildOpts)
+	level, result, err := projectPolicy.Evaluate(digests, packageName, p.orgPolicy, reqOpts, buildOpts)
+	if err != nil {
+		return -1, options.BuildVerificationResult{}, err
+	}
+	return level, result, nil
+}
+
+// PublishedPackages returns the packages the policy allows to publish, sorted by name.
+func (p *Policy) PublishedPackages() []options.PublishedPackage {
+	packages := make([]options.PublishedPackage, 0, len(p.projectPolicies))
+	for name, policy := range p.projectPolicies {
+		packages = append(packages, options.PublishedPackage{
+			Name:         name,
+			Environments: policy.Package.Environment.AnyOf,
+		})
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages
+}
+
+// ProjectPolicies returns the project policies, one per package name
+// or pattern, sorted by name.
+func (p *Policy) ProjectPolicies() []project.Policy {
+	policies := make([]project.Policy, 0, len(p.projectPolicies))
+	for _, policy := range p.projectPolicies {
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Package.Name < policies[j].Package.Name
+	})
+	return policies
+}
+
+// ProjectPolicy returns the project policy for a package name, either
+// defined for its name or for a matching pattern. The name may also be
+// the pattern itself.
+func (p *Policy) ProjectPolicy(packageName string) (project.Policy, bool) {
+	if policy, exists := p.projectPolicies[packageName]; exists {
+		return policy, true
+	}
+	return project.Find(p.projectPolicies, packageName)
+}
+
+// FindPackage returns the published package for a package name, either
+// defined for its name or for a matching pattern.
+func (p *Policy) FindPackage(packageName string) (options.PublishedPackage, bool) {
+	policy, exists := p.ProjectPolicy(packageName)
+	if !exists {
+		return options.PublishedPackage{}, false
+	}
+	return options.PublishedPackage{
+		Name:         policy.Package.Name,
+		Environments: policy.Package.Environment.AnyOf,
+	}, true
+}
+
+// OrgBuilders returns the builders trusted by the org policy.
+func (p *Policy) OrgBuilders() []options.OrgBuilder {
+	builders := make([]options.OrgBuilder, len(p.orgPolicy.Roots.Build))
+	for i, root := range p.orgPolicy.Roots.Build {
+		builders[i] = options.OrgBuilder{
+			ID:        root.ID,
+			Name:      root.Name,
+			SlsaLevel: *root.SlsaLevel,
+		}
+	}
+	return builders
+}
+
+// PackageBuilders returns the builders allowed to build a package in an environment.
+func (p *Policy) PackageBuilders(packageName string, environment *string) ([]options.PackageBuilder, error) {
+	policy, exists := p.ProjectPolicy(packageName)
+	if !exists {
+		return nil, fmt.Errorf("%w: package (%q) not present in project policies", errs.ErrorNotFound, packageName)
+	}
+	envs := policy.Package.Environment.AnyOf
+	if len(envs) > 0 && environment == nil {
+		return nil, fmt.Errorf("%w: environment is empty but the policy has it defined (%q)", errs.ErrorInvalidInput, envs)
+	}
+	if len(envs) > 0 && !slices.Contains(envs, *environment) {
+		return nil, fmt.Errorf("%w: environment (%q) is not one of (%q)", errs.ErrorInvalidInput, *environment, envs)
+	}
+	if len(envs) == 0 && environment != nil {
+		return nil, fmt.Errorf("%w: environment (%q) is set but the policy has none defined", errs.ErrorInvalidInput, *environment)
+	}
+	_, candidates := policy.EffectiveBuildRequirements(environment)
+	builders := make([]options.PackageBuilder, len(candidates))
+	for i, candidate := range candidates {
+		// NOTE: the project policies are validated against the org builders.
+		id, err := p.orgPolicy.BuilderID(candidate.Name)
+		if err != nil {
+			return nil, err
+		}
+		builders[i] = options.PackageBuilder{
+			ID:         id,
+			Name:       candidate.Name,
+			Repository: candidate.Repository.URI,
+			SlsaLevel:  p.orgPolicy.BuilderSlsaLevel(candidate.Name),
+		}
+	}
+	return builders, nil
+}
+
+// MaxSlsaLevel returns the highest SLSA level a package can be published
+// with in an environment, i.e. the highest level of its allowed builders.
+func (p *Policy) MaxSlsaLevel(packageName string, environment *string) (int, error) {
+	builders, err := p.PackageBuilders(packageName, environment)
 	if err != nil {
 		return -1, err
 	}
+	level := -1
+	for _, builder := range builders {
+		level = max(level, builder.SlsaLevel)
+	}
 	return level, nil
 }
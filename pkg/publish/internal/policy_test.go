@@ -382,8 +382,8 @@ func Test_Evaluate(t *testing.T) {
 		digests              intoto.DigestSet
 	}
 	digests := intoto.DigestSet{
-		"sha256": "val256",
-		"sha512": "val512",
+		"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
+		"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
 	}
 	packageName1 := "package_name1"
 	packageName2 := "package_name2"
@@ -624,7 +624,7 @@ func Test_Evaluate(t *testing.T) {
 			req := options.Request{
 				Environment: tt.verifierOpts.environment,
 			}
-			level, err := policy.Evaluate(tt.verifierOpts.digests, tt.packageName, req, opts)
+			level, _, err := policy.Evaluate(tt.verifierOpts.digests, tt.packageName, req, opts)
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
@@ -637,3 +637,173 @@ func Test_Evaluate(t *testing.T) {
 		})
 	}
 }
+
+func Test_PackageBuilders(t *testing.T) {
+	t.Parallel()
+	org := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Build: []organization.Root{
+				{
+					ID:        "builder_id1",
+					Name:      "builder_name1",
+					SlsaLevel: common.AsPointer(2),
+				},
+				{
+					ID:        "builder_id2",
+					Name:      "builder_name2",
+					SlsaLevel: common.AsPointer(3),
+				},
+			},
+		},
+	}
+	projects := []project.Policy{
+		{
+			Format: 1,
+			Package: project.Package{
+				Name: "package_name1",
+				Environment: project.Environment{
+					AnyOf: []string{"dev", "prod"},
+				},
+			},
+			BuildRequirements: project.BuildRequirements{
+				RequireSlsaBuilder: "builder_name2",
+				Repository: project.Repository{
+					URI: "source_name1",
+				},
+				Environments: map[string]project.EnvironmentBuildRequirements{
+					"dev": {
+						RequireSlsaBuilder: "builder_name1",
+					},
+				},
+			},
+		},
+		{
+			Format: 1,
+			Package: project.Package{
+				Name: "package_name2",
+			},
+			BuildRequirements: project.BuildRequirements{
+				RequireSlsaBuilder: "builder_name1",
+				Repository: project.Repository{
+					URI: "source_name2",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		packageName string
+		environment *string
+		builders    []options.PackageBuilder
+		level       int
+		expected    error
+	}{
+		{
+			name:        "environment override",
+			packageName: "package_name1",
+			environment: common.AsPointer("dev"),
+			builders: []options.PackageBuilder{
+				{
+					ID:         "builder_id1",
+					Name:       "builder_name1",
+					Repository: "source_name1",
+					SlsaLevel:  2,
+				},
+			},
+			level: 2,
+		},
+		{
+			name:        "default requirements",
+			packageName: "package_name1",
+			environment: common.AsPointer("prod"),
+			builders: []options.PackageBuilder{
+				{
+					ID:         "builder_id2",
+					Name:       "builder_name2",
+					Repository: "source_name1",
+					SlsaLevel:  3,
+				},
+			},
+			level: 3,
+		},
+		{
+			name:        "no environment",
+			packageName: "package_name2",
+			builders: []options.PackageBuilder{
+				{
+					ID:         "builder_id1",
+					Name:       "builder_name1",
+					Repository: "source_name2",
+					SlsaLevel:  2,
+				},
+			},
+			level: 2,
+		},
+		{
+			name:        "missing environment",
+			packageName: "package_name1",
+			expected:    errs.ErrorInvalidInput,
+		},
+		{
+			name:        "unexpected environment",
+			packageName: "package_name2",
+			environment: common.AsPointer("prod"),
+			expected:    errs.ErrorInvalidInput,
+		},
+		{
+			name:        "unknown environment",
+			packageName: "package_name1",
+			environment: common.AsPointer("staging"),
+			expected:    errs.ErrorInvalidInput,
+		},
+		{
+			name:        "unknown package",
+			packageName: "package_name3",
+			expected:    errs.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Marshal the org policy.
+			content, err := json.Marshal(org)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			orgReader := io.NopCloser(bytes.NewReader(content))
+			// Marshal the project policies into bytes.
+			policies := make([][]byte, len(projects), len(projects))
+			for i := range projects {
+				content, err := json.Marshal(projects[i])
+				if err != nil {
+					t.Fatalf("failed to marshal: %v", err)
+				}
+				policies[i] = content
+			}
+			policy, err := PolicyNew(orgReader, common.NewBytesIterator(policies), nil)
+			if err != nil {
+				t.Fatalf("failed to create policy: %v", err)
+			}
+			builders, err := policy.PackageBuilders(tt.packageName, tt.environment)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.builders, builders); diff != "" {
+				t.Fatalf("unexpected builders (-want +got): \n%s", diff)
+			}
+			level, err := policy.MaxSlsaLevel(tt.packageName, tt.environment)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if diff := cmp.Diff(tt.level, level); diff != "" {
+				t.Fatalf("unexpected level (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -2,27 +2,82 @@ package project
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"path"
 	"slices"
+	"strings"
 
 	"github.com/slsa-framework/slsa-policy/pkg/errs"
 	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/options"
 	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/organization"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/owners"
 )
 
 // Repository defines the repository.
 type Repository struct {
-	URI string `json:"uri"`
+	URI string `json:"uri" schema:"required"`
 }
 
 // BuildRequirements defines the build requirements.
+// Exactly one of RequireSlsaBuilder or Builders must be set.
 type BuildRequirements struct {
-	RequireSlsaBuilder string     `json:"require_slsa_builder"`
-	Repository         Repository `json:"repository"`
+	RequireSlsaBuilder string              `json:"require_slsa_builder,omitempty"`
+	Builders           *Builders           `json:"builders,omitempty"`
+	Repository         Repository          `json:"repository"`
+	Source             *SourceRequirements `json:"source,omitempty"`
+	// Environments contains the requirements overridden for
+	// some environments, keyed by environment.
+	Environments map[string]EnvironmentBuildRequirements `json:"environments,omitempty"`
+}
+
+// Builders defines the builders a package may be built by,
+// e.g. while migrating from one builder to another.
+type Builders struct {
+	AnyOf []Builder `json:"any_of" schema:"required"`
+}
+
+// Builder defines a builder a package may be built by.
+type Builder struct {
+	// Name is the name of the builder in the organization policy.
+	Name string `json:"name" schema:"required"`
+	// Repository, if set, overrides the project's repository.
+	Repository *Repository `json:"repository,omitempty"`
+}
+
+// EnvironmentBuildRequirements defines the build requirements
+// of an environment. Fields that are set override the project's
+// build requirements. At most one of RequireSlsaBuilder or Builders
+// may be set.
+type EnvironmentBuildRequirements struct {
+	RequireSlsaBuilder string              `json:"require_slsa_builder,omitempty"`
+	Builders           *Builders           `json:"builders,omitempty"`
+	Repository         *Repository         `json:"repository,omitempty"`
+	Source             *SourceRequirements `json:"source,omitempty"`
+}
+
+// SourceRequirements defines the requirements on the source
+// the package is built from.
+type SourceRequirements struct {
+	// Refs contains patterns the source ref must match,
+	// e.g. refs/heads/main or refs/tags/v*.
+	Refs Patterns `json:"refs,omitempty"`
+	// Workflows contains patterns the path of the build workflow must match,
+	// e.g. .github/workflows/release.yml.
+	Workflows Patterns `json:"workflows,omitempty"`
+	// RequireSlsaLevel is the minimum SLSA source track level.
+	// Source attestations are not verified yet, so only level 0 is accepted.
+	RequireSlsaLevel *int `json:"require_slsa_level,omitempty" schema:"enum=0"`
+}
+
+// Patterns defines a set of patterns. A value matches if it
+// matches one of the patterns, using the syntax of path.Match.
+type Patterns struct {
+	AnyOf []string `json:"any_of,omitempty"`
 }
 
 // Environment defines the target environment.
@@ -31,21 +86,104 @@ type Environment struct {
 }
 
 // Package defines publication metadata, such as
-// the name and the target environment.
+// the name and the target environment. The name may be
+// a prefix pattern ending with a '*', e.g. ghcr.io/team-x/*,
+// which matches all the packages with this prefix.
 type Package struct {
-	Name        string      `json:"name"`
+	Name        string      `json:"name" schema:"required"`
 	Environment Environment `json:"environment,omitempty"`
 }
 
-// Policy defines the policy.
+// MarshalJSON encodes an empty package as null, so that
+// a policy with 'packages' has no 'package' field.
+func (p Package) MarshalJSON() ([]byte, error) {
+	if p.Name == "" && len(p.Environment.AnyOf) == 0 {
+		return []byte("null"), nil
+	}
+	// NOTE: the alias type does not have the MarshalJSON method.
+	type alias Package
+	return json.Marshal(alias(p))
+}
+
+// Owners defines the team owning a policy.
+type Owners = owners.Owners
+
+// validateOwners validates the owners. Owners are optional.
+func validateOwners(o *Owners) error {
+	if err := o.Validate(); err != nil {
+		return fmt.Errorf("[projects] %w", err)
+	}
+	return nil
+}
+
+// OwnersFromReader returns the owners of a policy, or nil if the policy has none.
+// It does not validate the other fields of the policy.
+func OwnersFromReader(reader io.ReadCloser) (*Owners, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("[projects] failed to read: %w", err)
+	}
+	defer reader.Close()
+	var project struct {
+		Owners *Owners `json:"owners"`
+	}
+	if err := json.Unmarshal(content, &project); err != nil {
+		return nil, fmt.Errorf("[projects] failed to unmarshal: %w", err)
+	}
+	if err := validateOwners(project.Owners); err != nil {
+		return nil, err
+	}
+	return project.Owners, nil
+}
+
+// Policy defines the policy. A policy declares either a single
+// package or several packages, which share the build requirements.
 type Policy struct {
-	Format            int                     `json:"format"`
+	Format            int                     `json:"format" schema:"required,enum=1"`
 	Package           Package                 `json:"package"`
+	Packages          []Package               `json:"packages,omitempty"`
 	BuildRequirements BuildRequirements       `json:"build"`
+	Owners            *Owners                 `json:"owners,omitempty"`
 	validator         options.PolicyValidator `json:"-"`
+	id                string                  `json:"-"`
+}
+
+// packages returns the packages declared by the policy.
+func (p *Policy) packages() []Package {
+	if len(p.Packages) > 0 {
+		return p.Packages
+	}
+	return []Package{p.Package}
 }
 
-func fromReader(reader io.ReadCloser, builderNames []string, validator options.PolicyValidator) (*Policy, error) {
+// isPattern returns true if the package name is a prefix pattern.
+func isPattern(name string) bool {
+	return strings.HasSuffix(name, "*")
+}
+
+// matchPackage returns true if the package name matches the policy's package name.
+func matchPackage(policyName, packageName string) bool {
+	if isPattern(policyName) {
+		return strings.HasPrefix(packageName, strings.TrimSuffix(policyName, "*"))
+	}
+	return policyName == packageName
+}
+
+// overlapPackages returns true if a package name may match both
+// policy package names.
+func overlapPackages(name1, name2 string) bool {
+	switch {
+	case isPattern(name1) && isPattern(name2):
+		prefix1, prefix2 := strings.TrimSuffix(name1, "*"), strings.TrimSuffix(name2, "*")
+		return strings.HasPrefix(prefix1, prefix2) || strings.HasPrefix(prefix2, prefix1)
+	case isPattern(name1):
+		return matchPackage(name1, name2)
+	default:
+		return matchPackage(name2, name1)
+	}
+}
+
+func fromReader(reader io.ReadCloser, orgPolicy organization.Policy, validator options.PolicyValidator) (*Policy, error) {
 	// NOTE: see https://yourbasic.org/golang/io-reader-interface-explained.
 	content, err := ioutil.ReadAll(reader)
 	if err != nil {
@@ -57,26 +195,104 @@ func fromReader(reader io.ReadCloser, builderNames []string, validator options.P
 		return nil, fmt.Errorf("[projects] failed to unmarshal: %w", err)
 	}
 	project.validator = validator
-	if err := project.validate(builderNames); err != nil {
+	project.applyDefaults(orgPolicy)
+	if err := project.validate(orgPolicy); err != nil {
 		return nil, err
 	}
 	return &project, nil
 }
 
+// Validate validates the policy against the org policy, e.g. a policy created programmatically.
+// The org policy's defaults apply to the fields the policy does not set, but are not written to the policy.
+func (p *Policy) Validate(orgPolicy organization.Policy, validator options.PolicyValidator) error {
+	policy := *p
+	policy.validator = validator
+	policy.applyDefaults(orgPolicy)
+	return policy.validate(orgPolicy)
+}
+
+// applyDefaults sets the fields the policy does not set to the org policy's defaults.
+func (p *Policy) applyDefaults(orgPolicy organization.Policy) {
+	if p.BuildRequirements.RequireSlsaBuilder == "" && p.BuildRequirements.Builders == nil {
+		p.BuildRequirements.RequireSlsaBuilder = orgPolicy.DefaultBuilder()
+	}
+	environments := orgPolicy.DefaultEnvironments()
+	if len(environments) == 0 {
+		return
+	}
+	if len(p.Packages) == 0 {
+		p.Package = p.Package.withDefaultEnvironments(environments)
+		return
+	}
+	// NOTE: make a copy of the packages, which may be shared with a caller.
+	packages := make([]Package, len(p.Packages))
+	for i := range p.Packages {
+		packages[i] = p.Packages[i].withDefaultEnvironments(environments)
+	}
+	p.Packages = packages
+}
+
+// withDefaultEnvironments returns the package with the environments
+// set to the defaults if it has none.
+func (p Package) withDefaultEnvironments(environments []string) Package {
+	if len(p.Environment.AnyOf) == 0 {
+		// NOTE: make a copy of the array.
+		p.Environment.AnyOf = append([]string{}, environments...)
+	}
+	return p
+}
+
 // validate validates the format of the policy.
-func (p *Policy) validate(builderNames []string) error {
+func (p *Policy) validate(orgPolicy organization.Policy) error {
 	if err := p.validateFormat(); err != nil {
 		return err
 	}
 	if err := p.validatePackage(); err != nil {
 		return err
 	}
-	if err := p.validateBuildRequirements(builderNames); err != nil {
+	if err := p.validateBuildRequirements(orgPolicy.RootBuilderNames()); err != nil {
+		return err
+	}
+	if err := p.validateMinimums(orgPolicy); err != nil {
 		return err
 	}
+	if err := validateOwners(p.Owners); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateMinimums validates that the builders satisfy the org policy's minimum level.
+func (p *Policy) validateMinimums(orgPolicy organization.Policy) error {
+	minLevel := orgPolicy.MinSlsaLevel()
+	if minLevel == 0 {
+		return nil
+	}
+	for _, name := range p.builderNames() {
+		if level := orgPolicy.BuilderSlsaLevel(name); level < minLevel {
+			return fmt.Errorf("[projects] %w: build's builder (%q) has level (%d) < org policy's minimum level (%d)",
+				errs.ErrorInvalidField, name, level, minLevel)
+		}
+	}
 	return nil
 }
 
+// builderNames returns the names of the builders the policy uses,
+// including the ones of the environments.
+func (p *Policy) builderNames() []string {
+	names := p.BuildRequirements.Builders.names()
+	if p.BuildRequirements.RequireSlsaBuilder != "" {
+		names = append(names, p.BuildRequirements.RequireSlsaBuilder)
+	}
+	for _, requirements := range p.BuildRequirements.Environments {
+		names = append(names, requirements.Builders.names()...)
+		if requirements.RequireSlsaBuilder != "" {
+			names = append(names, requirements.RequireSlsaBuilder)
+		}
+	}
+	return names
+}
+
 func (p *Policy) validateFormat() error {
 	// Format must be 1.
 	if p.Format != 1 {
@@ -86,26 +302,52 @@ func (p *Policy) validateFormat() error {
 }
 
 func (p *Policy) validatePackage() error {
+	// Package and packages are mutually exclusive.
+	if len(p.Packages) > 0 && (p.Package.Name != "" || len(p.Package.Environment.AnyOf) > 0) {
+		return fmt.Errorf("[projects] %w: package and packages are both defined", errs.ErrorInvalidField)
+	}
+	packages := p.packages()
+	for i := range packages {
+		if err := p.validateOnePackage(&packages[i]); err != nil {
+			return err
+		}
+		// Packages in the same file must not overlap.
+		for j := 0; j < i; j++ {
+			if overlapPackages(packages[i].Name, packages[j].Name) {
+				return fmt.Errorf("[projects] %w: package's name (%q) overlaps with (%q)",
+					errs.ErrorInvalidField, packages[i].Name, packages[j].Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Policy) validateOnePackage(pkg *Package) error {
 	// Package must have a non-empty Name.
-	if p.Package.Name == "" {
+	if pkg.Name == "" {
 		return fmt.Errorf("[projects] %w: package's name is empty", errs.ErrorInvalidField)
 	}
+	// A pattern must have a non-empty prefix and a single trailing '*'.
+	if strings.Count(pkg.Name, "*") > 1 || (strings.Contains(pkg.Name, "*") && !isPattern(pkg.Name)) ||
+		pkg.Name == "*" {
+		return fmt.Errorf("[projects] %w: package's name (%q) is an invalid pattern", errs.ErrorInvalidField, pkg.Name)
+	}
 	// Environment field, if set, must contain non-empty values.
-	for i := range p.Package.Environment.AnyOf {
-		val := &p.Package.Environment.AnyOf[i]
+	for i := range pkg.Environment.AnyOf {
+		val := &pkg.Environment.AnyOf[i]
 		if *val == "" {
 			return fmt.Errorf("[projects] %w: package's any_of value has an empty field", errs.ErrorInvalidField)
 		}
 	}
 	// Validate the package using the custom validator.
 	if p.validator != nil {
-		pkg := options.ValidationPackage{
-			Name: p.Package.Name,
+		vpkg := options.ValidationPackage{
+			Name: pkg.Name,
 			Environment: options.ValidationEnvironment{
-				AnyOf: append([]string{}, p.Package.Environment.AnyOf...), // NOTE: Make a copy of the array.
+				AnyOf: append([]string{}, pkg.Environment.AnyOf...), // NOTE: Make a copy of the array.
 			},
 		}
-		if err := p.validator.ValidatePackage(pkg); err != nil {
+		if err := p.validator.ValidatePackage(vpkg); err != nil {
 			return fmt.Errorf("%w: failed to validate package: %w", errs.ErrorInvalidField, err)
 		}
 	}
@@ -114,46 +356,282 @@ func (p *Policy) validatePackage() error {
 
 func (p *Policy) validateBuildRequirements(builderNames []string) error {
 	// SLSA builder
-	//	1) must be set
+	//	1) must be set, either as require_slsa_builder or builders
 	//	2) must contain one the builders configured by the organization-level policy
 	//	3) must contain a repository URI.
 	if len(builderNames) == 0 {
 		return fmt.Errorf("[projects] %w: builder names are empty", errs.ErrorInvalidInput)
 	}
-	if p.BuildRequirements.RequireSlsaBuilder == "" {
+	if p.BuildRequirements.RequireSlsaBuilder == "" && p.BuildRequirements.Builders == nil {
 		return fmt.Errorf("[projects] %w: build's require_slsa_builder is not defined", errs.ErrorInvalidField)
 	}
-	if !slices.Contains(builderNames, p.BuildRequirements.RequireSlsaBuilder) {
+	if p.BuildRequirements.RequireSlsaBuilder != "" && p.BuildRequirements.Builders != nil {
+		return fmt.Errorf("[projects] %w: build's require_slsa_builder and builders are both defined", errs.ErrorInvalidField)
+	}
+	if p.BuildRequirements.RequireSlsaBuilder != "" &&
+		!slices.Contains(builderNames, p.BuildRequirements.RequireSlsaBuilder) {
 		return fmt.Errorf("[projects] %w: build's require_slsa_builder has unexpected value (%q). Must be one of %q",
 			errs.ErrorInvalidField, p.BuildRequirements.RequireSlsaBuilder, builderNames)
 	}
-	if p.BuildRequirements.Repository.URI == "" {
+	if err := p.BuildRequirements.Builders.validate(builderNames); err != nil {
+		return err
+	}
+	// The repository may only be omitted if all builders define their own.
+	if p.BuildRequirements.Repository.URI == "" &&
+		(p.BuildRequirements.Builders == nil || !p.BuildRequirements.Builders.hasRepositories()) {
 		return fmt.Errorf("[projects] %w: build's repository URI is not defined", errs.ErrorInvalidField)
 	}
+	if err := p.BuildRequirements.Source.validate(); err != nil {
+		return err
+	}
+	if err := p.validateEnvironmentBuildRequirements(builderNames); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *Policy) validateEnvironmentBuildRequirements(builderNames []string) error {
+	var environments []string
+	for _, pkg := range p.packages() {
+		environments = append(environments, pkg.Environment.AnyOf...)
+	}
+	for env, requirements := range p.BuildRequirements.Environments {
+		// The environment must be one of the packages' environments.
+		if !slices.Contains(environments, env) {
+			return fmt.Errorf("[projects] %w: build's environment (%q) not in package's environments (%q)",
+				errs.ErrorInvalidField, env, environments)
+		}
+		if requirements.RequireSlsaBuilder != "" && requirements.Builders != nil {
+			return fmt.Errorf("[projects] %w: build's environment (%q) require_slsa_builder and builders are both defined",
+				errs.ErrorInvalidField, env)
+		}
+		if requirements.RequireSlsaBuilder != "" && !slices.Contains(builderNames, requirements.RequireSlsaBuilder) {
+			return fmt.Errorf("[projects] %w: build's environment (%q) require_slsa_builder has unexpected value (%q). Must be one of %q",
+				errs.ErrorInvalidField, env, requirements.RequireSlsaBuilder, builderNames)
+		}
+		if err := requirements.Builders.validate(builderNames); err != nil {
+			return err
+		}
+		if requirements.Repository != nil && requirements.Repository.URI == "" {
+			return fmt.Errorf("[projects] %w: build's environment (%q) repository URI is not defined", errs.ErrorInvalidField, env)
+		}
+		// The effective requirements must have a repository for each builder.
+		effective := p.buildRequirements(&env)
+		if effective.Repository.URI == "" &&
+			(effective.Builders == nil || !effective.Builders.hasRepositories()) {
+			return fmt.Errorf("[projects] %w: build's environment (%q) repository URI is not defined", errs.ErrorInvalidField, env)
+		}
+		if err := requirements.Source.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildRequirements returns the build requirements for an environment.
+func (p *Policy) buildRequirements(environment *string) BuildRequirements {
+	requirements := BuildRequirements{
+		RequireSlsaBuilder: p.BuildRequirements.RequireSlsaBuilder,
+		Builders:           p.BuildRequirements.Builders,
+		Repository:         p.BuildRequirements.Repository,
+		Source:             p.BuildRequirements.Source,
+	}
+	if environment == nil {
+		return requirements
+	}
+	overrides, exists := p.BuildRequirements.Environments[*environment]
+	if !exists {
+		return requirements
+	}
+	// NOTE: the builders set by the environment replace the project's.
+	if overrides.RequireSlsaBuilder != "" {
+		requirements.RequireSlsaBuilder = overrides.RequireSlsaBuilder
+		requirements.Builders = nil
+	}
+	if overrides.Builders != nil {
+		requirements.RequireSlsaBuilder = ""
+		requirements.Builders = overrides.Builders
+	}
+	if overrides.Repository != nil {
+		requirements.Repository = *overrides.Repository
+	}
+	if overrides.Source != nil {
+		requirements.Source = overrides.Source
+	}
+	return requirements
+}
+
+// candidates returns the builders the package may be built by,
+// with their repository.
+func (r BuildRequirements) candidates() []Builder {
+	if r.RequireSlsaBuilder != "" {
+		return []Builder{{Name: r.RequireSlsaBuilder, Repository: &r.Repository}}
+	}
+	if r.Builders == nil {
+		return nil
+	}
+	candidates := make([]Builder, len(r.Builders.AnyOf))
+	for i := range r.Builders.AnyOf {
+		candidates[i] = r.Builders.AnyOf[i]
+		if candidates[i].Repository == nil {
+			candidates[i].Repository = &r.Repository
+		}
+	}
+	return candidates
+}
+
+// EffectiveBuildRequirements returns the build requirements for an environment,
+// and the builders they allow. A nil environment returns the project's requirements.
+func (p *Policy) EffectiveBuildRequirements(environment *string) (BuildRequirements, []Builder) {
+	requirements := p.buildRequirements(environment)
+	return requirements, requirements.candidates()
+}
+
+// Candidates returns the builders the policy's packages may be built by,
+// for all the environments. Each builder has its repository set.
+func (p *Policy) Candidates() []Builder {
+	candidates := p.buildRequirements(nil).candidates()
+	environments := make([]string, 0, len(p.BuildRequirements.Environments))
+	for env := range p.BuildRequirements.Environments {
+		environments = append(environments, env)
+	}
+	slices.Sort(environments)
+	for i := range environments {
+		candidates = append(candidates, p.buildRequirements(&environments[i]).candidates()...)
+	}
+	return candidates
+}
+
+func (b *Builders) validate(builderNames []string) error {
+	if b == nil {
+		return nil
+	}
+	if len(b.AnyOf) == 0 {
+		return fmt.Errorf("[projects] %w: build's builders any_of is empty", errs.ErrorInvalidField)
+	}
+	names := make(map[string]bool)
+	for i := range b.AnyOf {
+		builder := &b.AnyOf[i]
+		if !slices.Contains(builderNames, builder.Name) {
+			return fmt.Errorf("[projects] %w: build's builders has unexpected value (%q). Must be one of %q",
+				errs.ErrorInvalidField, builder.Name, builderNames)
+		}
+		if names[builder.Name] {
+			return fmt.Errorf("[projects] %w: build's builders (%q) is defined more than once", errs.ErrorInvalidField, builder.Name)
+		}
+		names[builder.Name] = true
+		if builder.Repository != nil && builder.Repository.URI == "" {
+			return fmt.Errorf("[projects] %w: build's builders (%q) repository URI is not defined", errs.ErrorInvalidField, builder.Name)
+		}
+	}
+	return nil
+}
+
+// names returns the names of the builders.
+func (b *Builders) names() []string {
+	if b == nil {
+		return nil
+	}
+	names := make([]string, len(b.AnyOf))
+	for i := range b.AnyOf {
+		names[i] = b.AnyOf[i].Name
+	}
+	return names
+}
+
+// hasRepositories returns true if all builders define their repository.
+func (b *Builders) hasRepositories() bool {
+	for i := range b.AnyOf {
+		if b.AnyOf[i].Repository == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (source *SourceRequirements) validate() error {
+	if source == nil {
+		return nil
+	}
+	if err := source.Refs.validate("refs"); err != nil {
+		return err
+	}
+	if err := source.Workflows.validate("workflows"); err != nil {
+		return err
+	}
+	// NOTE: source attestations are not verified yet, so
+	// a level above 0 could never be satisfied.
+	if source.RequireSlsaLevel != nil && *source.RequireSlsaLevel != 0 {
+		return fmt.Errorf("[projects] %w: source's require_slsa_level (%d) is not supported. Must be 0",
+			errs.ErrorInvalidField, *source.RequireSlsaLevel)
+	}
+	return nil
+}
+
+func (p Patterns) validate(name string) error {
+	for i := range p.AnyOf {
+		pattern := p.AnyOf[i]
+		if pattern == "" {
+			return fmt.Errorf("[projects] %w: source's %s any_of value has an empty field", errs.ErrorInvalidField, name)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("[projects] %w: source's %s pattern (%q) is invalid: %w", errs.ErrorInvalidField, name, pattern, err)
+		}
+	}
 	return nil
 }
 
-// FromReaders creates a set of policies keyed by their package Name (and if present, the environment).
+// match returns true if there are no patterns or
+// the value matches one of the patterns.
+func (p Patterns) match(value string) bool {
+	if len(p.AnyOf) == 0 {
+		return true
+	}
+	for i := range p.AnyOf {
+		// NOTE: patterns are validated when the policy is loaded.
+		if matched, _ := path.Match(p.AnyOf[i], value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FromReaders creates a set of policies keyed by their package Name or pattern.
+// A policy declaring several packages results in one policy per package.
+// Package names and patterns must not overlap, so that each package
+// is owned by a single policy.
 func FromReaders(readers iterator.ReadCloserIterator, orgPolicy organization.Policy, validator options.PolicyValidator) (map[string]Policy, error) {
+	return FromNamedReaders(&unnamedIterator{readers: readers}, orgPolicy, validator)
+}
+
+// FromNamedReaders is like FromReaders, and records the ID of the reader,
+// e.g. its path, in each policy.
+func FromNamedReaders(readers iterator.NamedReadCloserIterator, orgPolicy organization.Policy, validator options.PolicyValidator) (map[string]Policy, error) {
 	policies := make(map[string]Policy)
 	for readers.HasNext() {
-		reader := readers.Next()
+		id, reader := readers.Next()
 		// NOTE: fromReader() calls validates that the builder used are consistent
 		// with the org policy.
-		policy, err := fromReader(reader, orgPolicy.RootBuilderNames(), validator)
+		policy, err := fromReader(reader, orgPolicy, validator)
 		if err != nil {
 			return nil, err
 		}
-		// TODO: Re-visit what we consider unique. It maye require some tweaks to support
-		// different environments in different files.
-		// If we want to support multiple files, they should all have the environment defined or none
-		// should.
-		name := policy.Package.Name
-		if _, exists := policies[name]; exists {
-			return nil, fmt.Errorf("[projects] %w: package's name (%q) is defined more than once", errs.ErrorInvalidField, name)
+		policy.id = id
+		for _, pkg := range policy.packages() {
+			name := pkg.Name
+			if _, exists := policies[name]; exists {
+				return nil, fmt.Errorf("[projects] %w: package's name (%q) is defined more than once", errs.ErrorInvalidField, name)
+			}
+			for other := range policies {
+				if overlapPackages(name, other) {
+					return nil, fmt.Errorf("[projects] %w: package's name (%q) overlaps with (%q)", errs.ErrorInvalidField, name, other)
+				}
+			}
+			cpy := *policy
+			cpy.Package = pkg
+			cpy.Packages = nil
+			policies[name] = cpy
 		}
-		policies[name] = *policy
-
 	}
 	//TODO: add test for this.
 	if readers.Error() != nil {
@@ -162,47 +640,144 @@ func FromReaders(readers iterator.ReadCloserIterator, orgPolicy organization.Pol
 	return policies, nil
 }
 
+// unnamedIterator returns an empty ID for each reader.
+type unnamedIterator struct {
+	readers iterator.ReadCloserIterator
+}
+
+func (iter *unnamedIterator) Next() (string, io.ReadCloser) {
+	return "", iter.readers.Next()
+}
+
+func (iter *unnamedIterator) HasNext() bool {
+	return iter.readers.HasNext()
+}
+
+func (iter *unnamedIterator) Error() error {
+	return iter.readers.Error()
+}
+
+// ID returns the ID of the reader the policy was created from, if known.
+func (p *Policy) ID() string {
+	return p.id
+}
+
+// Find returns the policy for a package, either defined
+// for its name or for a matching pattern.
+func Find(policies map[string]Policy, packageName string) (Policy, bool) {
+	if policy, exists := policies[packageName]; exists && !isPattern(packageName) {
+		return policy, true
+	}
+	for name, policy := range policies {
+		if isPattern(name) && matchPackage(name, packageName) {
+			return policy, true
+		}
+	}
+	return Policy{}, false
+}
+
 // Evaluate evaluates the policy.
 func (p *Policy) Evaluate(digests intoto.DigestSet, packageName string,
-	orgPolicy organization.Policy, reqOpts options.Request, buildOpts options.BuildVerification) (int, error) {
+	orgPolicy organization.Policy, reqOpts options.Request, buildOpts options.BuildVerification) (int, options.BuildVerificationResult, error) {
 	if buildOpts.Verifier == nil {
-		return -1, fmt.Errorf("[projects] %w: verifier is empty", errs.ErrorInvalidInput)
+		return -1, options.BuildVerificationResult{}, fmt.Errorf("[projects] %w: verifier is empty", errs.ErrorInvalidInput)
 	}
 	// If the policy has environment defined, the request must contain an environment.
 	if len(p.Package.Environment.AnyOf) > 0 && (reqOpts.Environment == nil || *reqOpts.Environment == "") {
-		return -1, fmt.Errorf("[projects] %w: build config's environment is empty but the policy has it defined (%q)",
+		return -1, options.BuildVerificationResult{}, fmt.Errorf("[projects] %w: build config's environment is empty but the policy has it defined (%q)",
 			errs.ErrorInvalidInput, p.Package.Environment.AnyOf)
 	}
 	// If the policy has no environment defined, the request must not contain an environment.
 	if len(p.Package.Environment.AnyOf) == 0 && reqOpts.Environment != nil {
-		return -1, fmt.Errorf("[projects] %w: build config's environment is set (%q) but the policy has none defined",
+		return -1, options.BuildVerificationResult{}, fmt.Errorf("[projects] %w: build config's environment is set (%q) but the policy has none defined",
 			errs.ErrorInvalidInput, *reqOpts.Environment)
 	}
 	// Verify the environment and request match.
 	if reqOpts.Environment != nil {
 		if *reqOpts.Environment == "" {
-			return -1, fmt.Errorf("[projects] %w: build config's environment is empty", errs.ErrorInvalidInput)
+			return -1, options.BuildVerificationResult{}, fmt.Errorf("[projects] %w: build config's environment is empty", errs.ErrorInvalidInput)
 		}
 		if !slices.Contains(p.Package.Environment.AnyOf, *reqOpts.Environment) {
-			return -1, fmt.Errorf("[projects] %w: failed to verify artifact (%q) for environment (%q): not defined in policy",
+			return -1, options.BuildVerificationResult{}, fmt.Errorf("[projects] %w: failed to verify artifact (%q) for environment (%q): not defined in policy",
 				errs.ErrorNotFound, packageName, *reqOpts.Environment)
 		}
 	}
 	// Validate digests.
 	if err := digests.Validate(); err != nil {
-		return -1, err
+		return -1, options.BuildVerificationResult{}, err
+	}
+	// Select the build requirements for the environment.
+	requirements := p.buildRequirements(reqOpts.Environment)
+	// Verify build attestations. The builders are tried in order
+	// and the first one verified is recorded in the result.
+	var allErrs []error
+	for _, builder := range requirements.candidates() {
+		result, err := verifyBuilder(digests, packageName, builder, orgPolicy, buildOpts)
+		if err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+		// Verify the source requirements.
+		if err := requirements.Source.evaluate(packageName, result.Provenance); err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+		result.BuilderName = builder.Name
+		return orgPolicy.BuilderSlsaLevel(builder.Name), result, nil
 	}
-	// Verify build attestations.
-	builderID, err := orgPolicy.BuilderID(p.BuildRequirements.RequireSlsaBuilder)
+	return -1, options.BuildVerificationResult{}, fmt.Errorf("[projects] %w: failed to verify artifact (%q) with builders: %w",
+		errs.ErrorVerification, packageName, errors.Join(allErrs...))
+}
+
+func verifyBuilder(digests intoto.DigestSet, packageName string, builder Builder,
+	orgPolicy organization.Policy, buildOpts options.BuildVerification) (options.BuildVerificationResult, error) {
+	builderID, err := orgPolicy.BuilderID(builder.Name)
 	if err != nil {
-		return -1, err
+		return options.BuildVerificationResult{}, err
 	}
-	err = buildOpts.Verifier.VerifyBuildAttestation(digests, packageName, builderID, p.BuildRequirements.Repository.URI)
+	result, err := buildOpts.Verifier.VerifyBuildAttestation(digests, packageName, builderID, builder.Repository.URI)
 	if err != nil {
-		return -1, fmt.Errorf("[projects] %w: failed to verify artifact (%q) with builder (%q -> %q) source URI (%q) digests (%q): %w",
-			errs.ErrorVerification, packageName, p.BuildRequirements.RequireSlsaBuilder, builderID,
-			p.BuildRequirements.Repository.URI, digests, err)
+		return options.BuildVerificationResult{}, fmt.Errorf("[projects] %w: failed to verify artifact (%q) with builder (%q -> %q) source URI (%q) digests (%q): %w",
+			errs.ErrorVerification, packageName, builder.Name, builderID,
+			builder.Repository.URI, digests, err)
+	}
+	// Sanity check the facts returned by the verifier.
+	if err := validateBuilderID(builderID, result.Provenance.BuilderID); err != nil {
+		return options.BuildVerificationResult{}, err
 	}
+	return result, nil
+}
 
-	return orgPolicy.BuilderSlsaLevel(p.BuildRequirements.RequireSlsaBuilder), nil
+// evaluate evaluates the source requirements
+// against the verified provenance.
+func (source *SourceRequirements) evaluate(packageName string, provenance options.BuildProvenance) error {
+	if source == nil {
+		return nil
+	}
+	if len(source.Refs.AnyOf) > 0 && (provenance.SourceRef == "" || !source.Refs.match(provenance.SourceRef)) {
+		return fmt.Errorf("[projects] %w: artifact (%q) built from source ref (%q) not in (%q)",
+			errs.ErrorVerification, packageName, provenance.SourceRef, source.Refs.AnyOf)
+	}
+	if len(source.Workflows.AnyOf) > 0 && (provenance.WorkflowPath == "" || !source.Workflows.match(provenance.WorkflowPath)) {
+		return fmt.Errorf("[projects] %w: artifact (%q) built by workflow (%q) not in (%q)",
+			errs.ErrorVerification, packageName, provenance.WorkflowPath, source.Workflows.AnyOf)
+	}
+	if source.RequireSlsaLevel != nil && provenance.SourceLevel < *source.RequireSlsaLevel {
+		return fmt.Errorf("[projects] %w: artifact (%q) built from source with level (%d) < required level (%d)",
+			errs.ErrorVerification, packageName, provenance.SourceLevel, *source.RequireSlsaLevel)
+	}
+	return nil
+}
+
+// validateBuilderID validates that the builder ID verified matches
+// the builder ID in the policy. The verified builder ID may contain
+// the builder's version, e.g. https://github.com/org/builder.yml@refs/tags/v1.2.3.
+// An empty verified builder ID means the verifier did not return it.
+func validateBuilderID(builderID, verifiedBuilderID string) error {
+	if verifiedBuilderID == "" || verifiedBuilderID == builderID ||
+		strings.HasPrefix(verifiedBuilderID, builderID+"@") {
+		return nil
+	}
+	return fmt.Errorf("[projects] %w: mismatch builder ID (%q) and verified builder ID (%q)",
+		errs.ErrorVerification, builderID, verifiedBuilderID)
 }
@@ -2,6 +2,8 @@ package project
 
 import (
 	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -98,6 +100,116 @@ func Test_validatePackage(t *testing.T) {
 			},
 			expected: errs.ErrorInvalidField,
 		},
+		{
+			name: "set pattern",
+			policy: Policy{
+				Package: Package{
+					Name: "registry/team/*",
+				},
+			},
+		},
+		{
+			name: "pattern only",
+			policy: Policy{
+				Package: Package{
+					Name: "*",
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "pattern not at the end",
+			policy: Policy{
+				Package: Package{
+					Name: "registry/*/name",
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "pattern twice",
+			policy: Policy{
+				Package: Package{
+					Name: "registry/**",
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "set packages",
+			policy: Policy{
+				Packages: []Package{
+					{
+						Name: "name1",
+					},
+					{
+						Name: "name2",
+						Environment: Environment{
+							AnyOf: []string{"dev", "prod"},
+						},
+					},
+					{
+						Name: "registry/team/*",
+					},
+				},
+			},
+		},
+		{
+			name: "package and packages",
+			policy: Policy{
+				Package: Package{
+					Name: "name1",
+				},
+				Packages: []Package{
+					{
+						Name: "name2",
+					},
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "packages empty name",
+			policy: Policy{
+				Packages: []Package{
+					{
+						Name: "name1",
+					},
+					{
+						Name: "",
+					},
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "packages same name",
+			policy: Policy{
+				Packages: []Package{
+					{
+						Name: "name1",
+					},
+					{
+						Name: "name1",
+					},
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "packages overlap",
+			policy: Policy{
+				Packages: []Package{
+					{
+						Name: "registry/team/name",
+					},
+					{
+						Name: "registry/team/*",
+					},
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -195,128 +307,94 @@ func Test_validateBuildRequirements(t *testing.T) {
 			builders: []string{"other_builder_name"},
 			expected: errs.ErrorInvalidField,
 		},
-	}
-	for _, tt := range tests {
-		tt := tt // Re-initializing variable so it is not changed while executing the closure below
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			err := tt.policy.validateBuildRequirements(tt.builders)
-			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
-				t.Fatalf("unexpected err (-want +got): \n%s", diff)
-			}
-		})
-	}
-}
-
-func Test_FromReaders(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name     string
-		policies []Policy
-		builders []string
-		expected error
-	}{
 		{
-			name: "valid policy",
-			policies: []Policy{
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
+			name: "builders",
+			policy: Policy{
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder_name"},
+							{Name: "other_builder_name", Repository: &Repository{URI: "other_non_empty"}},
 						},
 					},
+					Repository: Repository{
+						URI: "non_empty",
+					},
 				},
 			},
-			builders: []string{"builder_name"},
+			builders: []string{"builder_name", "other_builder_name"},
 		},
 		{
-			name: "builder name not present in org policy",
-			policies: []Policy{
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "other_builder_name",
-						Repository: Repository{
-							URI: "non_empty",
+			name: "builders with repositories",
+			policy: Policy{
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder_name", Repository: &Repository{URI: "non_empty"}},
+							{Name: "other_builder_name", Repository: &Repository{URI: "other_non_empty"}},
 						},
 					},
 				},
 			},
-			builders: []string{"builder_name"},
-			expected: errs.ErrorInvalidField,
+			builders: []string{"builder_name", "other_builder_name"},
 		},
 		{
-			name: "package name re-use",
-			policies: []Policy{
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
+			name: "builders missing repository",
+			policy: Policy{
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder_name"},
+							{Name: "other_builder_name", Repository: &Repository{URI: "other_non_empty"}},
 						},
 					},
 				},
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
+			},
+			builders: []string{"builder_name", "other_builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "builders empty repository",
+			policy: Policy{
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder_name", Repository: &Repository{}},
 						},
 					},
+					Repository: Repository{
+						URI: "non_empty",
+					},
 				},
 			},
 			builders: []string{"builder_name"},
 			expected: errs.ErrorInvalidField,
 		},
 		{
-			name: "package name re-use same env",
-			policies: []Policy{
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-						Environment: Environment{
-							AnyOf: []string{"dev"},
+			name: "builders and require_slsa_builder",
+			policy: Policy{
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder_name",
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "other_builder_name"},
 						},
 					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
-						},
+					Repository: Repository{
+						URI: "non_empty",
 					},
 				},
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-						Environment: Environment{
-							AnyOf: []string{"dev"},
-						},
-					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
-						},
+			},
+			builders: []string{"builder_name", "other_builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "empty builders",
+			policy: Policy{
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{},
+					Repository: Repository{
+						URI: "non_empty",
 					},
 				},
 			},
@@ -324,36 +402,17 @@ func Test_FromReaders(t *testing.T) {
 			expected: errs.ErrorInvalidField,
 		},
 		{
-			name: "package name re-use different env",
-			policies: []Policy{
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-						Environment: Environment{
-							AnyOf: []string{"prod"},
-						},
-					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
-						},
-					},
-				},
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-						Environment: Environment{
-							AnyOf: []string{"dev"},
+			name: "duplicate builders",
+			policy: Policy{
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder_name"},
+							{Name: "builder_name"},
 						},
 					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
-						},
+					Repository: Repository{
+						URI: "non_empty",
 					},
 				},
 			},
@@ -361,33 +420,17 @@ func Test_FromReaders(t *testing.T) {
 			expected: errs.ErrorInvalidField,
 		},
 		{
-			name: "package name re-use env set and not",
-			policies: []Policy{
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-						Environment: Environment{
-							AnyOf: []string{"prod"},
-						},
-					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
+			name: "mismatch builders",
+			policy: Policy{
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder_name"},
+							{Name: "unknown_builder_name"},
 						},
 					},
-				},
-				Policy{
-					Format: 1,
-					Package: Package{
-						Name: "name_set",
-					},
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
-						},
+					Repository: Repository{
+						URI: "non_empty",
 					},
 				},
 			},
@@ -395,7 +438,209 @@ func Test_FromReaders(t *testing.T) {
 			expected: errs.ErrorInvalidField,
 		},
 		{
-			name: "builder not set",
+			name: "environment builders and require_slsa_builder",
+			policy: Policy{
+				Package: Package{
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder_name",
+					Repository: Repository{
+						URI: "non_empty",
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"dev": {
+							RequireSlsaBuilder: "builder_name",
+							Builders: &Builders{
+								AnyOf: []Builder{
+									{Name: "other_builder_name"},
+								},
+							},
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name", "other_builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "environment builders",
+			policy: Policy{
+				Package: Package{
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder_name",
+					Repository: Repository{
+						URI: "non_empty",
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"dev": {
+							Builders: &Builders{
+								AnyOf: []Builder{
+									{Name: "builder_name"},
+									{Name: "other_builder_name"},
+								},
+							},
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name", "other_builder_name"},
+		},
+		{
+			name: "environment overrides",
+			policy: Policy{
+				Package: Package{
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder_name",
+					Repository: Repository{
+						URI: "non_empty",
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"prod": {
+							RequireSlsaBuilder: "other_builder_name",
+							Repository: &Repository{
+								URI: "other_non_empty",
+							},
+							Source: &SourceRequirements{
+								Refs: Patterns{
+									AnyOf: []string{"refs/tags/v*"},
+								},
+							},
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name", "other_builder_name"},
+		},
+		{
+			name: "environment not in package",
+			policy: Policy{
+				Package: Package{
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder_name",
+					Repository: Repository{
+						URI: "non_empty",
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"staging": {
+							RequireSlsaBuilder: "builder_name",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "environment mismatch builder names",
+			policy: Policy{
+				Package: Package{
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder_name",
+					Repository: Repository{
+						URI: "non_empty",
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"prod": {
+							RequireSlsaBuilder: "other_builder_name",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "environment empty repository name",
+			policy: Policy{
+				Package: Package{
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder_name",
+					Repository: Repository{
+						URI: "non_empty",
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"prod": {
+							Repository: &Repository{},
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "environment invalid source",
+			policy: Policy{
+				Package: Package{
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder_name",
+					Repository: Repository{
+						URI: "non_empty",
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"prod": {
+							Source: &SourceRequirements{
+								RequireSlsaLevel: common.AsPointer(5),
+							},
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.policy.validateBuildRequirements(tt.builders)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_FromReaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		policies []Policy
+		builders []string
+		expected error
+	}{
+		{
+			name: "valid policy",
 			policies: []Policy{
 				Policy{
 					Format: 1,
@@ -410,17 +655,86 @@ func Test_FromReaders(t *testing.T) {
 					},
 				},
 			},
-			expected: errs.ErrorInvalidInput,
+			builders: []string{"builder_name"},
 		},
 		{
-			name: "empty builder name",
+			name: "builder name not present in org policy",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "other_builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "package name re-use",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "package name re-use same env",
 			policies: []Policy{
 				Policy{
 					Format: 1,
 					Package: Package{
 						Name: "name_set",
+						Environment: Environment{
+							AnyOf: []string{"dev"},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+						Environment: Environment{
+							AnyOf: []string{"dev"},
+						},
 					},
 					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
 						Repository: Repository{
 							URI: "non_empty",
 						},
@@ -431,19 +745,1187 @@ func Test_FromReaders(t *testing.T) {
 			expected: errs.ErrorInvalidField,
 		},
 		{
-			name: "empty repository name",
-			policies: []Policy{
-				Policy{
-					Format: 1,
-					BuildRequirements: BuildRequirements{
-						RequireSlsaBuilder: "builder_name",
-						Repository: Repository{
-							URI: "non_empty",
-						},
-					},
-				},
-			},
-			builders: []string{"builder_name"},
+			name: "package name re-use different env",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+						Environment: Environment{
+							AnyOf: []string{"prod"},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+						Environment: Environment{
+							AnyOf: []string{"dev"},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "package name re-use env set and not",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+						Environment: Environment{
+							AnyOf: []string{"prod"},
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "builder not set",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name: "empty builder name",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name_set",
+					},
+					BuildRequirements: BuildRequirements{
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "empty repository name",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "multiple packages",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Packages: []Package{
+						{
+							Name: "name1",
+						},
+						{
+							Name: "name2",
+						},
+						{
+							Name: "registry/team/*",
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name3",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+		},
+		{
+			name: "multiple packages name re-use",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Packages: []Package{
+						{
+							Name: "name1",
+						},
+						{
+							Name: "name2",
+						},
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "name2",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "pattern overlaps name",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/team/name",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/team/*",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "name overlaps pattern",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/team/*",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/team/name",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "pattern overlaps pattern",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/team/*",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/*",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "non-overlapping patterns",
+			policies: []Policy{
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/team1/*",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/team2/*",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+				Policy{
+					Format: 1,
+					Package: Package{
+						Name: "registry/team3",
+					},
+					BuildRequirements: BuildRequirements{
+						RequireSlsaBuilder: "builder_name",
+						Repository: Repository{
+							URI: "non_empty",
+						},
+					},
+				},
+			},
+			builders: []string{"builder_name"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Create the org policy (only the builder names are needed).
+			orgPolicy := organization.Policy{}
+			for i := range tt.builders {
+				orgPolicy.Roots.Build = append(orgPolicy.Roots.Build, organization.Root{Name: tt.builders[i]})
+			}
+
+			// Marshal the project policies into bytes.
+			policies := make([][]byte, len(tt.policies), len(tt.policies))
+			for i := range tt.policies {
+				content, err := json.Marshal(tt.policies[i])
+				if err != nil {
+					t.Fatalf("failed to marshal: %v", err)
+				}
+				policies[i] = content
+			}
+			// Create the project iterator.
+			iter := common.NewBytesIterator(policies)
+
+			// Call the constructor.
+			_, err := FromReaders(iter, orgPolicy, nil)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			// Same policy with a passing validator.
+			iter = common.NewBytesIterator(policies)
+			_, err = FromReaders(iter, orgPolicy, common.NewPolicyValidator(true))
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			// Same policy with a failing validator.
+			iter = common.NewBytesIterator(policies)
+			_, err = FromReaders(iter, orgPolicy, common.NewPolicyValidator(false))
+			if diff := cmp.Diff(errs.ErrorInvalidField, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Find(t *testing.T) {
+	t.Parallel()
+
+	policies := map[string]Policy{
+		"registry/team/name": Policy{
+			Package: Package{
+				Name: "registry/team/name",
+			},
+		},
+		"registry/team/*": Policy{
+			Package: Package{
+				Name: "registry/team/*",
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		packageName string
+		expected    string
+		exists      bool
+	}{
+		{
+			name:        "exact name",
+			packageName: "registry/team/name",
+			expected:    "registry/team/name",
+			exists:      true,
+		},
+		{
+			name:        "pattern",
+			packageName: "registry/team/other",
+			expected:    "registry/team/*",
+			exists:      true,
+		},
+		{
+			name:        "no match",
+			packageName: "registry/other/name",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, exists := Find(policies, tt.packageName)
+			if diff := cmp.Diff(tt.exists, exists); diff != "" {
+				t.Fatalf("unexpected exists (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expected, policy.Package.Name); diff != "" {
+				t.Fatalf("unexpected name (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Evaluate(t *testing.T) {
+	t.Parallel()
+	type dummyVerifierOpts struct {
+		builderID, sourceURI string
+		environment          *string
+		digests              intoto.DigestSet
+	}
+	digests := intoto.DigestSet{
+		"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
+		"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
+	}
+	packageName := "package_name"
+	sourceURI := "source_name"
+	projectBuilder1 := Policy{
+		Format: 1,
+		Package: Package{
+			Name: packageName,
+		},
+		BuildRequirements: BuildRequirements{
+			RequireSlsaBuilder: "builder1",
+			Repository: Repository{
+				URI: sourceURI,
+			},
+		},
+	}
+	projectBuilder2 := Policy{
+		Format: 1,
+		Package: Package{
+			Name: packageName,
+		},
+		BuildRequirements: BuildRequirements{
+			RequireSlsaBuilder: "builder2",
+			Repository: Repository{
+				URI: sourceURI,
+			},
+		},
+	}
+	org := organization.Policy{
+		Roots: organization.Roots{
+			Build: []organization.Root{
+				{
+					ID:        "builder2_id",
+					Name:      "builder2",
+					SlsaLevel: common.AsPointer(2),
+				},
+				{
+					ID:        "builder1_id",
+					Name:      "builder1",
+					SlsaLevel: common.AsPointer(1),
+				},
+			},
+		},
+	}
+	vopts := dummyVerifierOpts{
+		builderID: "builder1_id",
+		sourceURI: sourceURI,
+		digests:   digests,
+	}
+	tests := []struct {
+		name         string
+		policy       Policy
+		org          organization.Policy
+		noVerifier   bool
+		packageName  string
+		digests      intoto.DigestSet
+		verifierOpts dummyVerifierOpts
+		level        int
+		builderName  string
+		expected     error
+	}{
+		{
+			name:        "no verifier defined",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy:      projectBuilder1,
+			noVerifier:  true,
+			expected:    errs.ErrorInvalidInput,
+		},
+		{
+			name:        "digest mismatch",
+			packageName: packageName,
+			digests: intoto.DigestSet{
+				"sha256": "458270be419e48236a00808661ccc4c73a61103b9a54fb189175d1c4d1250902",
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
+			},
+			org:      org,
+			policy:   projectBuilder1,
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:        "digest mismatch single",
+			packageName: packageName,
+			digests: intoto.DigestSet{
+				"sha512": "9c596acbaf263f1afe5027267b9acc188490ca989c234e72f1d16b7b5c20cb552c31d5f28ce4af01d6dbff0788ba3caf594d4ede7d0a467de54deb613bd702b1",
+			},
+			org:      org,
+			policy:   projectBuilder1,
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:        "digest mismatch one correct match",
+			packageName: packageName,
+			digests: intoto.DigestSet{
+				"sha512": "7b24ead39742c8bb26600a8da693b70b1f604c10b3a3df310aa2f8de0497f5f7f3f0965b2fb779976c1b71e085bf182e91de6a9dd9db60b60ea58c709d3b58f2",
+			},
+			org:      org,
+			policy:   projectBuilder1,
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:        "empty digests",
+			packageName: packageName,
+			org:         org,
+			policy:      projectBuilder1,
+			expected:    errs.ErrorInvalidField,
+		},
+		{
+			name:        "empty digest value",
+			packageName: packageName,
+			org:         org,
+			policy:      projectBuilder1,
+			digests: intoto.DigestSet{
+				"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
+				"sha512": "",
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:        "empty digest key",
+			packageName: packageName,
+			org:         org,
+			policy:      projectBuilder1,
+			digests: intoto.DigestSet{
+				"sha256": "728349abb3d6fe69386e0755b956978e62e81c56759c4e21801554a5fdc7e7f5",
+				"":       "val512",
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:         "builder 1 success",
+			packageName:  packageName,
+			digests:      digests,
+			org:          org,
+			policy:       projectBuilder1,
+			level:        1,
+			verifierOpts: vopts,
+		},
+		{
+			name:        "builder 2 success",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy:      projectBuilder2,
+			verifierOpts: dummyVerifierOpts{
+				builderID: "builder2_id",
+				sourceURI: sourceURI,
+				digests:   digests,
+			},
+			level: 2,
+		},
+		{
+			name:        "no builder is supported",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy:      projectBuilder2,
+			verifierOpts: dummyVerifierOpts{
+				builderID: "builder3_id",
+				sourceURI: sourceURI,
+				digests:   digests,
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:        "builder 2 different source",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy:      projectBuilder2,
+			verifierOpts: dummyVerifierOpts{
+				builderID: "builder2_id",
+				sourceURI: sourceURI + "_different",
+				digests:   digests,
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:        "request with env policy no env",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy:      projectBuilder1,
+			level:       1,
+			verifierOpts: dummyVerifierOpts{
+				builderID:   "builder1_id",
+				sourceURI:   sourceURI,
+				digests:     digests,
+				environment: common.AsPointer("dev"),
+			},
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name:        "request no env policy with env",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy: Policy{
+				Format: 1,
+				Package: Package{
+					Name: packageName,
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder1",
+					Repository: Repository{
+						URI: sourceURI,
+					},
+				},
+			},
+			level:        1,
+			verifierOpts: vopts,
+			expected:     errs.ErrorInvalidInput,
+		},
+		{
+			name:        "any of builders first",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy: Policy{
+				Format: 1,
+				Package: Package{
+					Name: packageName,
+				},
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder1"},
+							{Name: "builder2"},
+						},
+					},
+					Repository: Repository{
+						URI: sourceURI,
+					},
+				},
+			},
+			level:        1,
+			builderName:  "builder1",
+			verifierOpts: vopts,
+		},
+		{
+			name:        "any of builders second",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy: Policy{
+				Format: 1,
+				Package: Package{
+					Name: packageName,
+				},
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder1"},
+							{Name: "builder2", Repository: &Repository{URI: "other_source_name"}},
+						},
+					},
+					Repository: Repository{
+						URI: sourceURI,
+					},
+				},
+			},
+			level:       2,
+			builderName: "builder2",
+			verifierOpts: dummyVerifierOpts{
+				builderID: "builder2_id",
+				sourceURI: "other_source_name",
+				digests:   digests,
+			},
+		},
+		{
+			name:        "any of builders repository mismatch",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy: Policy{
+				Format: 1,
+				Package: Package{
+					Name: packageName,
+				},
+				BuildRequirements: BuildRequirements{
+					Builders: &Builders{
+						AnyOf: []Builder{
+							{Name: "builder1"},
+							{Name: "builder2", Repository: &Repository{URI: "other_source_name"}},
+						},
+					},
+					Repository: Repository{
+						URI: sourceURI,
+					},
+				},
+			},
+			verifierOpts: dummyVerifierOpts{
+				builderID: "builder2_id",
+				sourceURI: sourceURI,
+				digests:   digests,
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:        "environment override builder",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy: Policy{
+				Format: 1,
+				Package: Package{
+					Name: packageName,
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder1",
+					Repository: Repository{
+						URI: sourceURI,
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"prod": {
+							RequireSlsaBuilder: "builder2",
+						},
+					},
+				},
+			},
+			level: 2,
+			verifierOpts: dummyVerifierOpts{
+				builderID:   "builder2_id",
+				sourceURI:   sourceURI,
+				digests:     digests,
+				environment: common.AsPointer("prod"),
+			},
+		},
+		{
+			name:        "environment default builder",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy: Policy{
+				Format: 1,
+				Package: Package{
+					Name: packageName,
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder1",
+					Repository: Repository{
+						URI: sourceURI,
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"prod": {
+							RequireSlsaBuilder: "builder2",
+						},
+					},
+				},
+			},
+			level: 1,
+			verifierOpts: dummyVerifierOpts{
+				builderID:   "builder1_id",
+				sourceURI:   sourceURI,
+				digests:     digests,
+				environment: common.AsPointer("dev"),
+			},
+		},
+		{
+			name:        "environment override source",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy: Policy{
+				Format: 1,
+				Package: Package{
+					Name: packageName,
+					Environment: Environment{
+						AnyOf: []string{"dev", "prod"},
+					},
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder1",
+					Repository: Repository{
+						URI: sourceURI,
+					},
+					Environments: map[string]EnvironmentBuildRequirements{
+						"prod": {
+							Source: &SourceRequirements{
+								Refs: Patterns{
+									AnyOf: []string{"refs/tags/v*"},
+								},
+							},
+						},
+					},
+				},
+			},
+			verifierOpts: dummyVerifierOpts{
+				builderID:   "builder1_id",
+				sourceURI:   sourceURI,
+				digests:     digests,
+				environment: common.AsPointer("prod"),
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:        "source ref not verified",
+			packageName: packageName,
+			digests:     digests,
+			org:         org,
+			policy: Policy{
+				Format: 1,
+				Package: Package{
+					Name: packageName,
+				},
+				BuildRequirements: BuildRequirements{
+					RequireSlsaBuilder: "builder1",
+					Repository: Repository{
+						URI: sourceURI,
+					},
+					Source: &SourceRequirements{
+						Refs: Patterns{
+							AnyOf: []string{"refs/heads/main"},
+						},
+					},
+				},
+			},
+			verifierOpts: vopts,
+			expected:     errs.ErrorVerification,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Create the verifier that succeeds for the right parameters.
+			var verifier options.AttestationVerifier
+			if !tt.noVerifier {
+				verifier = common.NewAttestationVerifier(tt.verifierOpts.digests, tt.packageName,
+					tt.verifierOpts.builderID, tt.verifierOpts.sourceURI)
+			}
+			opts := options.BuildVerification{
+				Verifier: verifier,
+			}
+			req := options.Request{
+				Environment: tt.verifierOpts.environment,
+			}
+			level, result, err := tt.policy.Evaluate(tt.digests, tt.packageName, tt.org, req, opts)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.level, level); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			// The verifier returns the builder ID and source URI it verified.
+			expectedProvenance := options.BuildProvenance{
+				BuilderID: tt.verifierOpts.builderID,
+				SourceURI: tt.verifierOpts.sourceURI,
+			}
+			if diff := cmp.Diff(expectedProvenance, result.Provenance); diff != "" {
+				t.Fatalf("unexpected provenance (-want +got): \n%s", diff)
+			}
+			if tt.builderName != "" {
+				if diff := cmp.Diff(tt.builderName, result.BuilderName); diff != "" {
+					t.Fatalf("unexpected builder name (-want +got): \n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func Test_validateBuilderID(t *testing.T) {
+	t.Parallel()
+
+	builderID := "https://github.com/org/builder.yml"
+	tests := []struct {
+		name              string
+		verifiedBuilderID string
+		expected          error
+	}{
+		{
+			name: "empty verified builder ID",
+		},
+		{
+			name:              "same builder ID",
+			verifiedBuilderID: builderID,
+		},
+		{
+			name:              "versioned builder ID",
+			verifiedBuilderID: builderID + "@refs/tags/v1.2.3",
+		},
+		{
+			name:              "different builder ID",
+			verifiedBuilderID: "https://github.com/org/other-builder.yml",
+			expected:          errs.ErrorVerification,
+		},
+		{
+			name:              "builder ID prefix",
+			verifiedBuilderID: builderID + "2@refs/tags/v1.2.3",
+			expected:          errs.ErrorVerification,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateBuilderID(builderID, tt.verifiedBuilderID)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_SourceRequirements_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		source   *SourceRequirements
+		expected error
+	}{
+		{
+			name: "no source requirements",
+		},
+		{
+			name: "all fields set",
+			source: &SourceRequirements{
+				Refs: Patterns{
+					AnyOf: []string{"refs/heads/main", "refs/tags/v*"},
+				},
+				Workflows: Patterns{
+					AnyOf: []string{".github/workflows/release.yml"},
+				},
+				RequireSlsaLevel: common.AsPointer(0),
+			},
+		},
+		{
+			name: "empty ref",
+			source: &SourceRequirements{
+				Refs: Patterns{
+					AnyOf: []string{"refs/heads/main", ""},
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "invalid ref pattern",
+			source: &SourceRequirements{
+				Refs: Patterns{
+					AnyOf: []string{"refs/tags/[v"},
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "empty workflow",
+			source: &SourceRequirements{
+				Workflows: Patterns{
+					AnyOf: []string{""},
+				},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "negative level",
+			source: &SourceRequirements{
+				RequireSlsaLevel: common.AsPointer(-1),
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "level too large",
+			source: &SourceRequirements{
+				RequireSlsaLevel: common.AsPointer(5),
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "level not supported",
+			source: &SourceRequirements{
+				RequireSlsaLevel: common.AsPointer(1),
+			},
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.source.validate()
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_SourceRequirements_evaluate(t *testing.T) {
+	t.Parallel()
+
+	source := &SourceRequirements{
+		Refs: Patterns{
+			AnyOf: []string{"refs/heads/main", "refs/tags/v*"},
+		},
+		Workflows: Patterns{
+			AnyOf: []string{".github/workflows/release.yml"},
+		},
+		RequireSlsaLevel: common.AsPointer(2),
+	}
+	tests := []struct {
+		name       string
+		source     *SourceRequirements
+		provenance options.BuildProvenance
+		expected   error
+	}{
+		{
+			name: "no source requirements",
+			provenance: options.BuildProvenance{
+				SourceRef: "refs/heads/feature",
+			},
+		},
+		{
+			name:   "branch",
+			source: source,
+			provenance: options.BuildProvenance{
+				SourceRef:    "refs/heads/main",
+				WorkflowPath: ".github/workflows/release.yml",
+				SourceLevel:  2,
+			},
+		},
+		{
+			name:   "tag",
+			source: source,
+			provenance: options.BuildProvenance{
+				SourceRef:    "refs/tags/v1.2.3",
+				WorkflowPath: ".github/workflows/release.yml",
+				SourceLevel:  3,
+			},
+		},
+		{
+			name:   "feature branch",
+			source: source,
+			provenance: options.BuildProvenance{
+				SourceRef:    "refs/heads/feature",
+				WorkflowPath: ".github/workflows/release.yml",
+				SourceLevel:  2,
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:   "no ref",
+			source: source,
+			provenance: options.BuildProvenance{
+				WorkflowPath: ".github/workflows/release.yml",
+				SourceLevel:  2,
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:   "different workflow",
+			source: source,
+			provenance: options.BuildProvenance{
+				SourceRef:    "refs/heads/main",
+				WorkflowPath: ".github/workflows/test.yml",
+				SourceLevel:  2,
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:   "no workflow",
+			source: source,
+			provenance: options.BuildProvenance{
+				SourceRef:   "refs/heads/main",
+				SourceLevel: 2,
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:   "level too low",
+			source: source,
+			provenance: options.BuildProvenance{
+				SourceRef:    "refs/heads/main",
+				WorkflowPath: ".github/workflows/release.yml",
+				SourceLevel:  1,
+			},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name: "refs only",
+			source: &SourceRequirements{
+				Refs: Patterns{
+					AnyOf: []string{"refs/heads/main"},
+				},
+			},
+			provenance: options.BuildProvenance{
+				SourceRef: "refs/heads/main",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.source.evaluate("package_name", tt.provenance)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_OwnersFromReader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		owners   *Owners
+		expected error
+	}{
+		{
+			name:    "no owners",
+			content: `{"format":1}`,
+		},
+		{
+			name:    "owners",
+			content: `{"format":1,"owners":{"team":"team-x","editors":["@org/team-x"]}}`,
+			owners: &Owners{
+				Team:    "team-x",
+				Editors: []string{"@org/team-x"},
+			},
+		},
+		{
+			name:     "invalid owners",
+			content:  `{"format":1,"owners":{"team":"team-x"}}`,
 			expected: errs.ErrorInvalidField,
 		},
 	}
@@ -452,299 +1934,115 @@ func Test_FromReaders(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			// Create the org policy (only the builder names are needed).
-			orgPolicy := organization.Policy{}
-			for i := range tt.builders {
-				orgPolicy.Roots.Build = append(orgPolicy.Roots.Build, organization.Root{Name: tt.builders[i]})
-			}
-
-			// Marshal the project policies into bytes.
-			policies := make([][]byte, len(tt.policies), len(tt.policies))
-			for i := range tt.policies {
-				content, err := json.Marshal(tt.policies[i])
-				if err != nil {
-					t.Fatalf("failed to marshal: %v", err)
-				}
-				policies[i] = content
-			}
-			// Create the project iterator.
-			iter := common.NewBytesIterator(policies)
-
-			// Call the constructor.
-			_, err := FromReaders(iter, orgPolicy, nil)
-			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
-				t.Fatalf("unexpected err (-want +got): \n%s", diff)
-			}
-			// Same policy with a passing validator.
-			iter = common.NewBytesIterator(policies)
-			_, err = FromReaders(iter, orgPolicy, common.NewPolicyValidator(true))
+			owners, err := OwnersFromReader(io.NopCloser(strings.NewReader(tt.content)))
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
-			if err != nil {
-				return
-			}
-			// Same policy with a failing validator.
-			iter = common.NewBytesIterator(policies)
-			_, err = FromReaders(iter, orgPolicy, common.NewPolicyValidator(false))
-			if diff := cmp.Diff(errs.ErrorInvalidField, err, cmpopts.EquateErrors()); diff != "" {
-				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			if diff := cmp.Diff(tt.owners, owners); diff != "" {
+				t.Fatalf("unexpected owners (-want +got): \n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_Evaluate(t *testing.T) {
+func Test_FromReadersDefaults(t *testing.T) {
 	t.Parallel()
-	type dummyVerifierOpts struct {
-		builderID, sourceURI string
-		environment          *string
-		digests              intoto.DigestSet
-	}
-	digests := intoto.DigestSet{
-		"sha256": "val256",
-		"sha512": "val512",
-	}
-	packageName := "package_name"
-	sourceURI := "source_name"
-	projectBuilder1 := Policy{
-		Format: 1,
-		Package: Package{
-			Name: packageName,
-		},
-		BuildRequirements: BuildRequirements{
-			RequireSlsaBuilder: "builder1",
-			Repository: Repository{
-				URI: sourceURI,
-			},
-		},
-	}
-	projectBuilder2 := Policy{
+
+	orgPolicy := organization.Policy{
 		Format: 1,
-		Package: Package{
-			Name: packageName,
-		},
-		BuildRequirements: BuildRequirements{
-			RequireSlsaBuilder: "builder2",
-			Repository: Repository{
-				URI: sourceURI,
-			},
-		},
-	}
-	org := organization.Policy{
 		Roots: organization.Roots{
 			Build: []organization.Root{
 				{
-					ID:        "builder2_id",
-					Name:      "builder2",
+					ID:        "builder_id1",
+					Name:      "builder_name1",
 					SlsaLevel: common.AsPointer(2),
 				},
 				{
-					ID:        "builder1_id",
-					Name:      "builder1",
-					SlsaLevel: common.AsPointer(1),
+					ID:        "builder_id2",
+					Name:      "builder_name2",
+					SlsaLevel: common.AsPointer(3),
 				},
 			},
 		},
-	}
-	vopts := dummyVerifierOpts{
-		builderID: "builder1_id",
-		sourceURI: sourceURI,
-		digests:   digests,
+		Defaults: &organization.Defaults{
+			Build:       &organization.BuildDefaults{RequireSlsaBuilder: "builder_name2"},
+			Environment: &organization.EnvironmentDefaults{AnyOf: []string{"dev", "prod"}},
+		},
+		Minimums: &organization.Minimums{
+			Build: &organization.BuildMinimums{SlsaLevel: common.AsPointer(3)},
+		},
 	}
 	tests := []struct {
 		name         string
-		policy       Policy
-		org          organization.Policy
-		noVerifier   bool
-		packageName  string
-		digests      intoto.DigestSet
-		verifierOpts dummyVerifierOpts
-		level        int
+		content      string
+		builder      string
+		environments []string
 		expected     error
 	}{
 		{
-			name:        "no verifier defined",
-			packageName: packageName,
-			digests:     digests,
-			org:         org,
-			policy:      projectBuilder1,
-			noVerifier:  true,
-			expected:    errs.ErrorInvalidInput,
-		},
-		{
-			name:        "digest mismatch",
-			packageName: packageName,
-			digests: intoto.DigestSet{
-				"sha256": "val256_different",
-				"sha512": "val512",
-			},
-			org:      org,
-			policy:   projectBuilder1,
-			expected: errs.ErrorVerification,
-		},
-		{
-			name:        "digest mismatch single",
-			packageName: packageName,
-			digests: intoto.DigestSet{
-				"sha512": "val512_different",
-			},
-			org:      org,
-			policy:   projectBuilder1,
-			expected: errs.ErrorVerification,
+			name:         "defaults applied",
+			content:      `{"format": 1, "package": {"name": "package_name1"}, "build": {"repository": {"uri": "source_name1"}}}`,
+			builder:      "builder_name2",
+			environments: []string{"dev", "prod"},
 		},
 		{
-			name:        "digest mismatch one correct match",
-			packageName: packageName,
-			digests: intoto.DigestSet{
-				"sha512": "val512",
-			},
-			org:      org,
-			policy:   projectBuilder1,
-			expected: errs.ErrorVerification,
+			name: "defaults overridden",
+			content: `{"format": 1, "package": {"name": "package_name1", "environment": {"any_of": ["staging"]}},
+				"build": {"require_slsa_builder": "builder_name2", "repository": {"uri": "source_name1"}}}`,
+			builder:      "builder_name2",
+			environments: []string{"staging"},
 		},
 		{
-			name:        "empty digests",
-			packageName: packageName,
-			org:         org,
-			policy:      projectBuilder1,
-			expected:    errs.ErrorInvalidField,
+			name: "defaults applied to packages",
+			content: `{"format": 1, "packages": [{"name": "package_name1"}, {"name": "package_name2", "environment": {"any_of": ["staging"]}}],
+				"build": {"repository": {"uri": "source_name1"}}}`,
+			builder:      "builder_name2",
+			environments: []string{"dev", "prod"},
 		},
 		{
-			name:        "empty digest value",
-			packageName: packageName,
-			org:         org,
-			policy:      projectBuilder1,
-			digests: intoto.DigestSet{
-				"sha256": "val256",
-				"sha512": "",
-			},
+			name: "builder below minimum",
+			content: `{"format": 1, "package": {"name": "package_name1"},
+				"build": {"require_slsa_builder": "builder_name1", "repository": {"uri": "source_name1"}}}`,
 			expected: errs.ErrorInvalidField,
 		},
 		{
-			name:        "empty digest key",
-			packageName: packageName,
-			org:         org,
-			policy:      projectBuilder1,
-			digests: intoto.DigestSet{
-				"sha256": "val256",
-				"":       "val512",
-			},
+			name: "builders below minimum",
+			content: `{"format": 1, "package": {"name": "package_name1"},
+				"build": {"builders": {"any_of": [{"name": "builder_name2"}, {"name": "builder_name1"}]}, "repository": {"uri": "source_name1"}}}`,
 			expected: errs.ErrorInvalidField,
 		},
 		{
-			name:         "builder 1 success",
-			packageName:  packageName,
-			digests:      digests,
-			org:          org,
-			policy:       projectBuilder1,
-			level:        1,
-			verifierOpts: vopts,
-		},
-		{
-			name:        "builder 2 success",
-			packageName: packageName,
-			digests:     digests,
-			org:         org,
-			policy:      projectBuilder2,
-			verifierOpts: dummyVerifierOpts{
-				builderID: "builder2_id",
-				sourceURI: sourceURI,
-				digests:   digests,
-			},
-			level: 2,
-		},
-		{
-			name:        "no builder is supported",
-			packageName: packageName,
-			digests:     digests,
-			org:         org,
-			policy:      projectBuilder2,
-			verifierOpts: dummyVerifierOpts{
-				builderID: "builder3_id",
-				sourceURI: sourceURI,
-				digests:   digests,
-			},
-			expected: errs.ErrorVerification,
-		},
-		{
-			name:        "builder 2 different source",
-			packageName: packageName,
-			digests:     digests,
-			org:         org,
-			policy:      projectBuilder2,
-			verifierOpts: dummyVerifierOpts{
-				builderID: "builder2_id",
-				sourceURI: sourceURI + "_different",
-				digests:   digests,
-			},
-			expected: errs.ErrorVerification,
-		},
-		{
-			name:        "request with env policy no env",
-			packageName: packageName,
-			digests:     digests,
-			org:         org,
-			policy:      projectBuilder1,
-			level:       1,
-			verifierOpts: dummyVerifierOpts{
-				builderID:   "builder1_id",
-				sourceURI:   sourceURI,
-				digests:     digests,
-				environment: common.AsPointer("dev"),
-			},
-			expected: errs.ErrorInvalidInput,
-		},
-		{
-			name:        "request no env policy with env",
-			packageName: packageName,
-			digests:     digests,
-			org:         org,
-			policy: Policy{
-				Format: 1,
-				Package: Package{
-					Name: packageName,
-					Environment: Environment{
-						AnyOf: []string{"dev", "prod"},
-					},
-				},
-				BuildRequirements: BuildRequirements{
-					RequireSlsaBuilder: "builder1",
-					Repository: Repository{
-						URI: sourceURI,
-					},
-				},
-			},
-			level:        1,
-			verifierOpts: vopts,
-			expected:     errs.ErrorInvalidInput,
+			name: "environment builder below minimum",
+			content: `{"format": 1, "package": {"name": "package_name1"},
+				"build": {"repository": {"uri": "source_name1"}, "environments": {"dev": {"require_slsa_builder": "builder_name1"}}}}`,
+			expected: errs.ErrorInvalidField,
 		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			// Create the verifier that succeeds for the right parameters.
-			var verifier options.AttestationVerifier
-			if !tt.noVerifier {
-				verifier = common.NewAttestationVerifier(tt.verifierOpts.digests, tt.packageName,
-					tt.verifierOpts.builderID, tt.verifierOpts.sourceURI)
-			}
-			opts := options.BuildVerification{
-				Verifier: verifier,
-			}
-			req := options.Request{
-				Environment: tt.verifierOpts.environment,
-			}
-			level, err := tt.policy.Evaluate(tt.digests, tt.packageName, tt.org, req, opts)
+
+			iter := common.NewBytesIterator([][]byte{[]byte(tt.content)})
+			policies, err := FromReaders(iter, orgPolicy, nil)
 			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
 			if err != nil {
 				return
 			}
-			if diff := cmp.Diff(tt.level, level); diff != "" {
-				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			policy := policies["package_name1"]
+			if diff := cmp.Diff(tt.builder, policy.BuildRequirements.RequireSlsaBuilder); diff != "" {
+				t.Fatalf("unexpected builder (-want +got): \n%s", diff)
+			}
+			var pkg Package
+			for _, p := range policy.packages() {
+				if p.Name == "package_name1" {
+					pkg = p
+				}
+			}
+			if diff := cmp.Diff(tt.environments, pkg.Environment.AnyOf); diff != "" {
+				t.Fatalf("unexpected environments (-want +got): \n%s", diff)
 			}
 		})
 	}
@@ -0,0 +1,33 @@
+package publish
+
+import (
+	"io"
+
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/iterator"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/lint"
+)
+
+// LintRules returns the lint rules of the publish policy.
+func LintRules() []lint.Rule {
+	return internal.LintRules()
+}
+
+// Lint validates the policy and returns the findings of the lint rules.
+// The IDs of the project readers, e.g. their paths, are the locations
+// of the findings. Findings for the org policy have an empty location.
+func Lint(org io.ReadCloser, projects iterator.NamedReadCloserIterator, opts ...PolicyOption) ([]lint.Finding, error) {
+	// Initialize a policy with caller options.
+	p := new(Policy)
+	for _, option := range opts {
+		err := option(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	policy, err := internal.PolicyNewFromNamedReaders(org, projects, p.validator)
+	if err != nil {
+		return nil, err
+	}
+	return policy.Lint(), nil
+}
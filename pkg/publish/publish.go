@@ -1,6 +1,7 @@
 package publish
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 
@@ -13,10 +14,127 @@ import (
 
 // AttestationVerifier defines an interface to verify attestations.
 type AttestationVerifier interface {
-	// Build attestation verification.
+	// Build attestation verification. It returns the result
+	// of the verification of the build attestation.
+	VerifyBuildAttestation(digests intoto.DigestSet, policyPackageName, builderID, sourceURI string) (BuildVerificationResult, error)
+}
+
+// LegacyAttestationVerifier defines the interface of verifiers
+// that only return an error.
+type LegacyAttestationVerifier interface {
 	VerifyBuildAttestation(digests intoto.DigestSet, policyPackageName, builderID, sourceURI string) error
 }
 
+// AttestationVerifierFromLegacy returns an AttestationVerifier for a LegacyAttestationVerifier.
+// The results only contain the builder ID and source URI the verifier was asked to verify.
+func AttestationVerifierFromLegacy(verifier LegacyAttestationVerifier) AttestationVerifier {
+	return &legacyVerifier{verifier: verifier}
+}
+
+type legacyVerifier struct {
+	verifier LegacyAttestationVerifier
+}
+
+func (v *legacyVerifier) VerifyBuildAttestation(digests intoto.DigestSet, policyPackageName, builderID, sourceURI string) (BuildVerificationResult, error) {
+	if v.verifier == nil {
+		return BuildVerificationResult{}, fmt.Errorf("%w: verifier is nil", errs.ErrorInvalidInput)
+	}
+	if err := v.verifier.VerifyBuildAttestation(digests, policyPackageName, builderID, sourceURI); err != nil {
+		return BuildVerificationResult{}, err
+	}
+	return BuildVerificationResult{
+		Provenance: BuildProvenance{
+			BuilderID: builderID,
+			SourceURI: sourceURI,
+		},
+	}, nil
+}
+
+// BuildVerificationResult defines the result of a build attestation verification.
+type BuildVerificationResult struct {
+	// Provenance contains the facts recorded in the publish attestation.
+	Provenance BuildProvenance
+	// Materials are the materials listed in the build attestation.
+	Materials []intoto.ResourceDescriptor
+	// PredicateType is the build attestation's predicate type.
+	PredicateType string
+	// Predicate is the build attestation's predicate.
+	Predicate json.RawMessage
+	// AttestationDigests are the digests of the verified build attestation.
+	// If set, the attestation is recorded as evidence in the publish attestation's
+	// decision details.
+	AttestationDigests intoto.DigestSet
+	// BuilderName is the name of the builder in the organization policy
+	// that the build attestation was verified against. It is set by
+	// the policy evaluation, which ignores the value set by verifiers.
+	BuilderName string
+}
+
+// evidence returns the descriptor of the build attestation
+// recorded in the decision details.
+func (r BuildVerificationResult) evidence() *intoto.ResourceDescriptor {
+	if len(r.AttestationDigests) == 0 {
+		return nil
+	}
+	evidence := intoto.ResourceDescriptor{
+		// NOTE: make a copy of the map.
+		Digest: intoto.DigestSet{},
+	}
+	for k, v := range r.AttestationDigests {
+		evidence.Digest[k] = v
+	}
+	if r.PredicateType != "" {
+		evidence.Annotations = map[string]interface{}{
+			predicateTypeAnnotation: r.PredicateType,
+		}
+	}
+	return &evidence
+}
+
+// BuildProvenance defines the facts verified in a build attestation.
+// Empty fields are not recorded in the publish attestation.
+type BuildProvenance struct {
+	// BuilderID is the full builder ID, e.g. including its version.
+	BuilderID string
+	// SourceURI is the URI of the source repository.
+	SourceURI string
+	// SourceCommit is the git commit of the source.
+	SourceCommit string
+	// SourceRef is the git ref of the source, e.g. refs/heads/main.
+	SourceRef string
+	// BuildType is the type of build.
+	BuildType string
+	// InvocationID identifies the build invocation.
+	InvocationID string
+	// WorkflowPath is the path of the build workflow in the
+	// source repository, e.g. .github/workflows/release.yml.
+	WorkflowPath string
+	// SourceLevel is the verified SLSA source track level.
+	// Zero means the level is unknown.
+	SourceLevel int
+}
+
+// Intersect returns the fields that are identical in both provenances.
+// Other fields are empty. The source level is the lowest of both levels.
+func (b BuildProvenance) Intersect(other BuildProvenance) BuildProvenance {
+	intersect := func(a, b string) string {
+		if a == b {
+			return a
+		}
+		return ""
+	}
+	return BuildProvenance{
+		BuilderID:    intersect(b.BuilderID, other.BuilderID),
+		SourceURI:    intersect(b.SourceURI, other.SourceURI),
+		SourceCommit: intersect(b.SourceCommit, other.SourceCommit),
+		SourceRef:    intersect(b.SourceRef, other.SourceRef),
+		BuildType:    intersect(b.BuildType, other.BuildType),
+		InvocationID: intersect(b.InvocationID, other.InvocationID),
+		WorkflowPath: intersect(b.WorkflowPath, other.WorkflowPath),
+		SourceLevel:  min(b.SourceLevel, other.SourceLevel),
+	}
+}
+
 // AttestationVerificationOption defines the configuration to verify
 // build attestations.
 type AttestationVerificationOption struct {
@@ -29,11 +147,35 @@ type RequestOption struct {
 	Environment *string
 }
 
+// PublishedPackage defines a package the policy allows to publish.
+// The name is a package name or a pattern ending with '*'.
+type PublishedPackage struct {
+	Name         string
+	Environments []string
+}
+
+// OrgBuilder defines a builder trusted by the org policy.
+type OrgBuilder struct {
+	ID        string
+	Name      string
+	SlsaLevel int
+}
+
+// PackageBuilder defines a builder allowed to build a package,
+// with the repository it must build from.
+type PackageBuilder struct {
+	ID         string
+	Name       string
+	Repository string
+	SlsaLevel  int
+}
+
 // Policy defines the publish policy.
 type Policy struct {
-	policy        *internal.Policy
-	validator     options.PolicyValidator
-	packageHelper PackageHelper
+	policy           *internal.Policy
+	validator        options.PolicyValidator
+	packageHelper    PackageHelper
+	digestValidation intoto.DigestValidation
 }
 
 // PolicyOption defines a policy option.
@@ -45,11 +187,21 @@ type internal_verifier struct {
 	opts AttestationVerificationOption
 }
 
-func (i *internal_verifier) VerifyBuildAttestation(digests intoto.DigestSet, policyPackageName, builderID, sourceURI string) error {
+func (i *internal_verifier) VerifyBuildAttestation(digests intoto.DigestSet, policyPackageName, builderID, sourceURI string) (options.BuildVerificationResult, error) {
 	if i.opts.Verifier == nil {
-		return fmt.Errorf("%w: verifier is nil", errs.ErrorInvalidInput)
+		return options.BuildVerificationResult{}, fmt.Errorf("%w: verifier is nil", errs.ErrorInvalidInput)
 	}
-	return i.opts.Verifier.VerifyBuildAttestation(digests, policyPackageName, builderID, sourceURI)
+	result, err := i.opts.Verifier.VerifyBuildAttestation(digests, policyPackageName, builderID, sourceURI)
+	if err != nil {
+		return options.BuildVerificationResult{}, err
+	}
+	return options.BuildVerificationResult{
+		Provenance:         options.BuildProvenance(result.Provenance),
+		Materials:          result.Materials,
+		PredicateType:      result.PredicateType,
+		Predicate:          result.Predicate,
+		AttestationDigests: result.AttestationDigests,
+	}, nil
 }
 
 // This is a class to forward calls between internal
@@ -109,25 +261,89 @@ func (p *Policy) setValidator(validator PolicyValidator) error {
 	return nil
 }
 
+// SetDigestValidation sets how strictly the digests of evaluated
+// subjects are validated. The default is intoto.DigestValidationPermissive.
+func SetDigestValidation(mode intoto.DigestValidation) PolicyOption {
+	return func(p *Policy) error {
+		return p.setDigestValidation(mode)
+	}
+}
+
+func (p *Policy) setDigestValidation(mode intoto.DigestValidation) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+	p.digestValidation = mode
+	return nil
+}
+
 // Evaluate evalues the publish policy.
 func (p *Policy) Evaluate(digests intoto.DigestSet, policyPackageName string, reqOpts RequestOption,
 	opts AttestationVerificationOption) PolicyEvaluationResult {
-	level, err := p.policy.Evaluate(digests, policyPackageName,
-		options.Request{
-			Environment: reqOpts.Environment,
-		},
-		options.BuildVerification{
-			Verifier: &internal_verifier{
-				opts: opts,
-			},
-		},
-	)
+	return p.EvaluateSubjects([]intoto.Subject{{Digests: digests}}, policyPackageName, reqOpts, opts)
+}
+
+// EvaluateSubjects evaluates the publish policy for a set of subjects
+// of the same package, e.g. a multi-arch image index and its platform
+// manifests. Each subject is evaluated independently and the attestation
+// covers all subjects.
+func (p *Policy) EvaluateSubjects(subjects []intoto.Subject, policyPackageName string, reqOpts RequestOption,
+	opts AttestationVerificationOption) PolicyEvaluationResult {
+	if len(subjects) == 0 {
+		return PolicyEvaluationResult{
+			err:       fmt.Errorf("%w: no subjects", errs.ErrorInvalidInput),
+			evaluated: true,
+		}
+	}
+	// Validate and normalize the digests.
+	subjects, err := intoto.NormalizeSubjects(subjects, p.digestValidation)
 	if err != nil {
 		return PolicyEvaluationResult{
 			err:       err,
 			evaluated: true,
 		}
 	}
+	level := -1
+	var provenance BuildProvenance
+	verifications := make([]BuildVerificationResult, len(subjects))
+	for i := range subjects {
+		subject := &subjects[i]
+		subjectLevel, subjectResult, err := p.policy.Evaluate(subject.Digests, policyPackageName,
+			options.Request{
+				Environment: reqOpts.Environment,
+			},
+			options.BuildVerification{
+				Verifier: &internal_verifier{
+					opts: opts,
+				},
+			},
+		)
+		if err != nil {
+			return PolicyEvaluationResult{
+				err:       err,
+				evaluated: true,
+			}
+		}
+		// The attestation's level is the lowest level of all subjects.
+		if level == -1 || subjectLevel < level {
+			level = subjectLevel
+		}
+		verifications[i] = BuildVerificationResult{
+			Provenance:         BuildProvenance(subjectResult.Provenance),
+			Materials:          subjectResult.Materials,
+			PredicateType:      subjectResult.PredicateType,
+			Predicate:          subjectResult.Predicate,
+			AttestationDigests: subjectResult.AttestationDigests,
+			BuilderName:        subjectResult.BuilderName,
+		}
+		// The attestation's provenance contains the facts common to all subjects.
+		current := verifications[i].Provenance
+		if i == 0 {
+			provenance = current
+		} else {
+			provenance = provenance.Intersect(current)
+		}
+	}
 
 	// Translate the policy package names to a package descriptor.
 	packageDesc, err := p.packageHelper.PackageDescriptor(policyPackageName)
@@ -138,12 +354,16 @@ func (p *Policy) Evaluate(digests intoto.DigestSet, policyPackageName string, re
 		}
 	}
 	return PolicyEvaluationResult{
-		level:       level,
-		err:         err,
-		packageDesc: packageDesc,
-		digests:     digests,
-		environment: reqOpts.Environment,
-		evaluated:   true,
+		level:            level,
+		err:              err,
+		packageDesc:      packageDesc,
+		digests:          subjects[0].Digests,
+		subjects:         subjects,
+		environment:      reqOpts.Environment,
+		digestValidation: p.digestValidation,
+		provenance:       provenance,
+		verifications:    verifications,
+		evaluated:        true,
 	}
 }
 
@@ -151,3 +371,62 @@ func (p *Policy) Evaluate(digests intoto.DigestSet, policyPackageName string, re
 func PredicateType() string {
 	return predicateType
 }
+
+// PublishedPackages returns the packages the policy allows to publish, sorted by name.
+func (p *Policy) PublishedPackages() []PublishedPackage {
+	packages := p.policy.PublishedPackages()
+	res := make([]PublishedPackage, len(packages))
+	for i := range packages {
+		res[i] = publishedPackage(packages[i])
+	}
+	return res
+}
+
+// FindPackage returns the published package for a package name, either
+// defined for its name or for a matching pattern.
+func (p *Policy) FindPackage(packageName string) (PublishedPackage, bool) {
+	pkg, exists := p.policy.FindPackage(packageName)
+	if !exists {
+		return PublishedPackage{}, false
+	}
+	return publishedPackage(pkg), true
+}
+
+// OrgBuilders returns the builders trusted by the org policy.
+func (p *Policy) OrgBuilders() []OrgBuilder {
+	builders := p.policy.OrgBuilders()
+	res := make([]OrgBuilder, len(builders))
+	for i := range builders {
+		res[i] = OrgBuilder(builders[i])
+	}
+	return res
+}
+
+// PackageBuilders returns the builders allowed to build a package in an environment.
+// The environment must be nil if the package is published without environment.
+func (p *Policy) PackageBuilders(packageName string, environment *string) ([]PackageBuilder, error) {
+	builders, err := p.policy.PackageBuilders(packageName, environment)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]PackageBuilder, len(builders))
+	for i := range builders {
+		res[i] = PackageBuilder(builders[i])
+	}
+	return res, nil
+}
+
+// MaxSlsaLevel returns the highest SLSA level a package can be published
+// with in an environment. The environment must be nil if the package
+// is published without environment.
+func (p *Policy) MaxSlsaLevel(packageName string, environment *string) (int, error) {
+	return p.policy.MaxSlsaLevel(packageName, environment)
+}
+
+func publishedPackage(pkg options.PublishedPackage) PublishedPackage {
+	return PublishedPackage{
+		Name: pkg.Name,
+		// NOTE: make a copy of the array.
+		Environments: append([]string{}, pkg.Environments...),
+	}
+}
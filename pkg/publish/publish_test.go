@@ -18,8 +18,8 @@ import (
 func Test_AttestationNew(t *testing.T) {
 	t.Parallel()
 	digests := intoto.DigestSet{
-		"sha256":    "some_value",
-		"gitCommit": "another_value",
+		"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+		"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
 	}
 	subject := intoto.Subject{
 		Digests: digests,
@@ -38,6 +38,7 @@ func Test_AttestationNew(t *testing.T) {
 		options    []AttestationCreationOption
 		subject    intoto.Subject
 		buildLevel int
+		evidence   []intoto.ResourceDescriptor
 		expected   error
 	}{
 		{
@@ -65,6 +66,75 @@ func Test_AttestationNew(t *testing.T) {
 			subject:    subject,
 			buildLevel: level,
 		},
+		{
+			name: "multiple subjects",
+			result: PolicyEvaluationResult{
+				evaluated:   true,
+				level:       level,
+				packageDesc: packageDesc,
+				digests:     digests,
+				subjects: []intoto.Subject{
+					subject,
+					{
+						Digests: intoto.DigestSet{
+							"sha256": "77a73f170325fc6a4dc852ca8c5f0fdf45633eeb6dee51a903716a58d4d48e9c",
+						},
+					},
+				},
+			},
+			options:    []AttestationCreationOption{},
+			subject:    subject,
+			buildLevel: level,
+		},
+		{
+			name: "verified attestations",
+			result: PolicyEvaluationResult{
+				evaluated:   true,
+				level:       level,
+				packageDesc: packageDesc,
+				digests:     digests,
+				verifications: []BuildVerificationResult{
+					{
+						PredicateType: "https://slsa.dev/provenance/v1",
+						AttestationDigests: intoto.DigestSet{
+							"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51",
+						},
+					},
+					{
+						PredicateType: "https://slsa.dev/provenance/v1",
+						AttestationDigests: intoto.DigestSet{
+							"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51",
+						},
+					},
+					{
+						AttestationDigests: intoto.DigestSet{
+							"sha256": "77a73f170325fc6a4dc852ca8c5f0fdf45633eeb6dee51a903716a58d4d48e9c",
+						},
+					},
+					{
+						PredicateType: "https://slsa.dev/provenance/v1",
+					},
+				},
+			},
+			options:    []AttestationCreationOption{},
+			subject:    subject,
+			buildLevel: level,
+			evidence: []intoto.ResourceDescriptor{
+				{
+					Digest: intoto.DigestSet{
+						"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51",
+					},
+					Annotations: map[string]interface{}{
+						predicateTypeAnnotation: "https://slsa.dev/provenance/v1",
+					},
+				},
+				{
+					Digest: intoto.DigestSet{
+						"sha256": "77a73f170325fc6a4dc852ca8c5f0fdf45633eeb6dee51a903716a58d4d48e9c",
+					},
+				},
+			},
+		},
 		{
 			name: "error result",
 			result: PolicyEvaluationResult{
@@ -95,7 +165,11 @@ func Test_AttestationNew(t *testing.T) {
 			if diff := cmp.Diff(predicateType, att.Header.PredicateType); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
-			if diff := cmp.Diff([]intoto.Subject{tt.subject}, att.attestation.Header.Subjects); diff != "" {
+			subjects := tt.result.subjects
+			if len(subjects) == 0 {
+				subjects = []intoto.Subject{tt.subject}
+			}
+			if diff := cmp.Diff(subjects, att.attestation.Header.Subjects); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
 			// TODO: need translation.
@@ -127,6 +201,68 @@ func Test_AttestationNew(t *testing.T) {
 			if diff := cmp.Diff(expectedEnv, env); diff != "" {
 				t.Fatalf("unexpected err (-want +got): \n%s", diff)
 			}
+			var evidence []intoto.ResourceDescriptor
+			if att.attestation.Predicate.DecisionDetails != nil {
+				evidence = att.attestation.Predicate.DecisionDetails.Evidence
+			}
+			if diff := cmp.Diff(tt.evidence, evidence); diff != "" {
+				t.Fatalf("unexpected evidence (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+type legacyAttestationVerifier struct {
+	err error
+}
+
+func (v *legacyAttestationVerifier) VerifyBuildAttestation(digests intoto.DigestSet, packageName, builderID, sourceURI string) error {
+	return v.err
+}
+
+func Test_AttestationVerifierFromLegacy(t *testing.T) {
+	t.Parallel()
+	digests := intoto.DigestSet{
+		"sha256": "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+	}
+	tests := []struct {
+		name     string
+		verifier LegacyAttestationVerifier
+		result   BuildVerificationResult
+		expected error
+	}{
+		{
+			name:     "verification succeeds",
+			verifier: &legacyAttestationVerifier{},
+			result: BuildVerificationResult{
+				Provenance: BuildProvenance{
+					BuilderID: "builder_id",
+					SourceURI: "source_uri",
+				},
+			},
+		},
+		{
+			name:     "verification fails",
+			verifier: &legacyAttestationVerifier{err: errs.ErrorVerification},
+			expected: errs.ErrorVerification,
+		},
+		{
+			name:     "nil verifier",
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			verifier := AttestationVerifierFromLegacy(tt.verifier)
+			result, err := verifier.VerifyBuildAttestation(digests, "package_name", "builder_id", "source_uri")
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.result, result); diff != "" {
+				t.Fatalf("unexpected result (-want +got): \n%s", diff)
+			}
 		})
 	}
 }
@@ -134,8 +270,8 @@ func Test_AttestationNew(t *testing.T) {
 func Test_e2e(t *testing.T) {
 	t.Parallel()
 	digests := intoto.DigestSet{
-		"sha256":    "some_value",
-		"gitCommit": "another_value",
+		"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+		"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
 	}
 	packageRegistry := "registry"
 	packageName := "package_name"
@@ -382,7 +518,7 @@ func Test_e2e(t *testing.T) {
 			if err != nil {
 				t.Fatalf("failed to create policy: %v", err)
 			}
-			verifier := common.NewAttestationVerifier(tt.digests, tt.packageName, tt.builderID, tt.sourceURI)
+			verifier := newAttestationVerifier(tt.digests, tt.packageName, tt.builderID, tt.sourceURI)
 			opts := AttestationVerificationOption{
 				Verifier: verifier,
 			}
@@ -416,6 +552,8 @@ func Test_e2e(t *testing.T) {
 			// Create verification options.
 			options := []VerificationOption{
 				IsSlsaBuildLevel(tt.buildLevel),
+				IsBuilderID(tt.builderID),
+				IsSourceURI(tt.sourceURI),
 			}
 
 			if tt.packageVersion != "" {
@@ -2,6 +2,8 @@ package publish
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/slsa-framework/slsa-policy/pkg/errs"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
@@ -9,12 +11,16 @@ import (
 
 // PolicyEvaluationResult defines the result of policy evaluation.
 type PolicyEvaluationResult struct {
-	level       int
-	err         error
-	packageDesc intoto.PackageDescriptor
-	digests     intoto.DigestSet
-	environment *string
-	evaluated   bool
+	level            int
+	err              error
+	packageDesc      intoto.PackageDescriptor
+	digests          intoto.DigestSet
+	subjects         []intoto.Subject
+	digestValidation intoto.DigestValidation
+	provenance       BuildProvenance
+	verifications    []BuildVerificationResult
+	environment      *string
+	evaluated        bool
 }
 
 // Attestation creates a publish attestation.
@@ -25,29 +31,65 @@ func (r PolicyEvaluationResult) AttestationNew(options ...AttestationCreationOpt
 	if err := r.isValid(); err != nil {
 		return nil, err
 	}
-	subject := intoto.Subject{
-		Digests: r.digests,
+	subjects := r.subjects
+	if len(subjects) == 0 {
+		subjects = []intoto.Subject{{Digests: r.digests}}
 	}
 	// Set environment if not empty.
 	if r.environment != nil {
 		r.packageDesc.Environment = *r.environment
 	}
 	// Create the options.
-	opts := []AttestationCreationOption{
+	var opts []AttestationCreationOption
+	if r.digestValidation == intoto.DigestValidationStrict {
+		opts = append(opts, SetStrictDigests())
+	}
+	opts = append(opts, []AttestationCreationOption{
 		// Set SLSA build level.
 		SetSlsaBuildLevel(r.level),
-	}
+		// Set the verified build provenance.
+		SetBuildProvenance(r.provenance),
+		// Record the verified build attestations.
+		AddEvidence(r.evidence()...),
+		// Add the other evaluated subjects.
+		AddSubjects(subjects[1:]...),
+	}...)
 	// Enter safe mode.
 	opts = append(opts, EnterSafeMode())
 	// Add caller options.
 	opts = append(opts, options...)
-	att, err := CreationNew(subject, r.packageDesc, opts...)
+	att, err := CreationNew(subjects[0], r.packageDesc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return att, err
 }
 
+// BuildVerifications returns the results of the build attestation
+// verifications, one per evaluated subject.
+func (r PolicyEvaluationResult) BuildVerifications() []BuildVerificationResult {
+	// NOTE: make a copy of the array.
+	return append([]BuildVerificationResult{}, r.verifications...)
+}
+
+// evidence returns the unique build attestations verified.
+func (r PolicyEvaluationResult) evidence() []intoto.ResourceDescriptor {
+	var evidence []intoto.ResourceDescriptor
+	for i := range r.verifications {
+		descriptor := r.verifications[i].evidence()
+		if descriptor == nil {
+			continue
+		}
+		if slices.ContainsFunc(evidence, func(e intoto.ResourceDescriptor) bool {
+			return maps.Equal(e.Digest, descriptor.Digest)
+		}) {
+			continue
+		}
+		evidence = append(evidence, *descriptor)
+	}
+	return evidence
+}
+
 func (r PolicyEvaluationResult) Error() error {
 	return r.err
 }
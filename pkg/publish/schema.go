@@ -0,0 +1,17 @@
+package publish
+
+import (
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/project"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/schema"
+)
+
+// OrgSchema returns the JSON Schema of the org policy file.
+func OrgSchema() ([]byte, error) {
+	return schema.JSON("Publish org policy", organization.Policy{})
+}
+
+// ProjectSchema returns the JSON Schema of the project policy files.
+func ProjectSchema() ([]byte, error) {
+	return schema.JSON("Publish project policy", project.Policy{})
+}
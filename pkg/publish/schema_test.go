@@ -0,0 +1,140 @@
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/common"
+)
+
+func Test_Schemas(t *testing.T) {
+	t.Parallel()
+
+	levels := []interface{}{0.0, 1.0, 2.0, 3.0, 4.0}
+	tests := []struct {
+		name     string
+		schema   func() ([]byte, error)
+		path     []string
+		required []interface{}
+		enum     []interface{}
+	}{
+		{
+			name:     "org",
+			schema:   OrgSchema,
+			required: []interface{}{"format", "roots"},
+		},
+		{
+			name:   "org builder level",
+			schema: OrgSchema,
+			path:   []string{"roots", "build", "slsa_level"},
+			enum:   levels,
+		},
+		{
+			name:     "project",
+			schema:   ProjectSchema,
+			required: []interface{}{"format"},
+		},
+		{
+			name:   "project source level",
+			schema: ProjectSchema,
+			path:   []string{"build", "source", "require_slsa_level"},
+			enum:   []interface{}{0.0},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := tt.schema()
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			var schema map[string]interface{}
+			if err := json.Unmarshal(content, &schema); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			for _, name := range tt.path {
+				if items, ok := schema["items"].(map[string]interface{}); ok {
+					schema = items
+				}
+				schema = schema["properties"].(map[string]interface{})[name].(map[string]interface{})
+			}
+			required, _ := schema["required"].([]interface{})
+			if diff := cmp.Diff(tt.required, required); diff != "" {
+				t.Fatalf("unexpected required (-want +got): \n%s", diff)
+			}
+			enum, _ := schema["enum"].([]interface{})
+			if diff := cmp.Diff(tt.enum, enum); diff != "" {
+				t.Fatalf("unexpected enum (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ProjectSchemaDefaults(t *testing.T) {
+	t.Parallel()
+	org := `{
+		"format": 1,
+		"roots": {"build": [{"id": "builder_id", "name": "builder_name", "slsa_level": 3}]},
+		"defaults": {"build": {"require_slsa_builder": "builder_name"}, "environment": {"any_of": ["prod"]}}
+	}`
+	// The project relies on the org policy's defaults.
+	project := `{
+		"format": 1,
+		"package": {"name": "package_name"},
+		"build": {"repository": {"uri": "github.com/org/repo"}}
+	}`
+	if _, err := PolicyNew(io.NopCloser(strings.NewReader(org)), common.NewBytesIterator([][]byte{[]byte(project)}),
+		newPackageHelper("registry")); err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	content, err := ProjectSchema()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(content, &schema); err != nil {
+		t.Fatalf("failed to unmarshal schema: %v", err)
+	}
+	var document interface{}
+	if err := json.Unmarshal([]byte(project), &document); err != nil {
+		t.Fatalf("failed to unmarshal project: %v", err)
+	}
+	if diff := cmp.Diff([]string(nil), missingRequired(schema, document, "")); diff != "" {
+		t.Fatalf("unexpected missing properties (-want +got): \n%s", diff)
+	}
+}
+
+// missingRequired returns the paths of the properties the schema
+// requires and the document does not set.
+func missingRequired(schema map[string]interface{}, document interface{}, path string) []string {
+	var missing []string
+	switch value := document.(type) {
+	case map[string]interface{}:
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, exists := value[name.(string)]; !exists {
+				missing = append(missing, path+"/"+name.(string))
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, v := range value {
+			if property, ok := properties[name].(map[string]interface{}); ok {
+				missing = append(missing, missingRequired(property, v, path+"/"+name)...)
+			}
+		}
+	case []interface{}:
+		items, _ := schema["items"].(map[string]interface{})
+		for i, v := range value {
+			missing = append(missing, missingRequired(items, v, fmt.Sprintf("%s/%d", path, i))...)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
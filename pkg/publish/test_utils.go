@@ -3,6 +3,8 @@ package publish
 import (
 	"fmt"
 
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/options"
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 )
 
@@ -39,3 +41,21 @@ func (v *policyValidator) ValidatePackage(pkg ValidationPackage) error {
 	}
 	return fmt.Errorf("failed to validate package: pass (%v)", v.pass)
 }
+
+func newAttestationVerifier(digests intoto.DigestSet, packageName, builderID, sourceURI string) AttestationVerifier {
+	return &attestationVerifier{
+		verifier: common.NewAttestationVerifier(digests, packageName, builderID, sourceURI),
+	}
+}
+
+// attestationVerifier adapts the internal test verifier to the public interface.
+type attestationVerifier struct {
+	verifier options.AttestationVerifier
+}
+
+func (v *attestationVerifier) VerifyBuildAttestation(digests intoto.DigestSet, packageName, builderID, sourceURI string) (BuildVerificationResult, error) {
+	result, err := v.verifier.VerifyBuildAttestation(digests, packageName, builderID, sourceURI)
+	return BuildVerificationResult{
+		Provenance: BuildProvenance(result.Provenance),
+	}, err
+}
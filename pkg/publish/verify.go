@@ -48,10 +48,7 @@ func (v *Verification) Verify(digests intoto.DigestSet, policyPackageName string
 			v.attestation.Header.PredicateType, predicateType)
 	}
 	// Subjects and digests.
-	if len(v.attestation.Header.Subjects) == 0 {
-		return fmt.Errorf("%w: no subjects in attestation", errs.ErrorInvalidField)
-	}
-	if err := verifyDigests(v.attestation.Header.Subjects[0].Digests, digests); err != nil {
+	if err := verifySubjects(v.attestation.Header.Subjects, digests); err != nil {
 		return err
 	}
 
@@ -92,25 +89,10 @@ func (v *Verification) verifyPackage(policyPackageName string) error {
 	return nil
 }
 
-func verifyDigests(ds intoto.DigestSet, digests intoto.DigestSet) error {
-	if err := ds.Validate(); err != nil {
-		return err
-	}
-	if err := digests.Validate(); err != nil {
-		return err
-	}
-	for name, value := range digests {
-		val, exists := ds[name]
-		if !exists {
-			return fmt.Errorf("%w: subject with digest (%q:%q) is not present in attestation", errs.ErrorMismatch,
-				name, value)
-		}
-		if val != value {
-			return fmt.Errorf("%w: subject with digest (%q:%q) != attestation (%q:%q)", errs.ErrorMismatch,
-				name, value, name, val)
-		}
-	}
-	return nil
+// verifySubjects verifies that one of the subjects matches the digests.
+func verifySubjects(subjects []intoto.Subject, digests intoto.DigestSet) error {
+	_, err := intoto.MatchSubjects(subjects, digests)
+	return err
 }
 
 func IsPackageEnvironment(env string) VerificationOption {
@@ -208,3 +190,49 @@ func (v *Verification) attestationLevel() (int, error) {
 	}
 	return int(vv), nil
 }
+
+// IsBuilderID verifies the builder ID recorded in the attestation.
+func IsBuilderID(builderID string) VerificationOption {
+	return func(v *Verification) error {
+		return v.isStringProperty(builderIDProperty, builderID)
+	}
+}
+
+// IsSourceURI verifies the source URI recorded in the attestation.
+func IsSourceURI(sourceURI string) VerificationOption {
+	return func(v *Verification) error {
+		return v.isStringProperty(sourceURIProperty, sourceURI)
+	}
+}
+
+func (v *Verification) isStringProperty(name, expected string) error {
+	if expected == "" {
+		return fmt.Errorf("%w: empty (%q) value", errs.ErrorInvalidInput, name)
+	}
+	value, exists := v.attestation.Predicate.Properties[name]
+	if !exists {
+		return fmt.Errorf("%w: (%q) field not present in properties", errs.ErrorMismatch, name)
+	}
+	if value != expected {
+		return fmt.Errorf("%w: (%q) value (%q) != attestation (%v)", errs.ErrorMismatch,
+			name, expected, value)
+	}
+	return nil
+}
+
+// HasStrictDigests verifies that the digests of all subjects
+// are valid in strict mode. See intoto.DigestValidationStrict.
+func HasStrictDigests() VerificationOption {
+	return func(v *Verification) error {
+		return v.hasStrictDigests()
+	}
+}
+
+func (v *Verification) hasStrictDigests() error {
+	for i := range v.attestation.Header.Subjects {
+		if err := v.attestation.Header.Subjects[i].ValidateWithMode(intoto.DigestValidationStrict); err != nil {
+			return err
+		}
+	}
+	return nil
+}
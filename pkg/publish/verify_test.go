@@ -13,95 +13,13 @@ import (
 	"github.com/slsa-framework/slsa-policy/pkg/utils/intoto"
 )
 
-func Test_verifyDigests(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name         string
-		attDigests   intoto.DigestSet
-		inputDigests intoto.DigestSet
-		expected     error
-	}{
-		{
-			name: "same digests",
-			attDigests: intoto.DigestSet{
-				"sha256":    "another",
-				"gitCommit": "mismatch_another_com",
-			},
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-				"sha256":    "another",
-			},
-		},
-		{
-			name: "subset in attestations",
-			attDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-			},
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-			},
-		},
-		{
-			name: "empty input digests",
-			attDigests: intoto.DigestSet{
-				"sha256":    "another",
-				"gitCommit": "mismatch_another_com",
-			},
-			expected: errs.ErrorInvalidField,
-		},
-		{
-			name: "empty att digests",
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-				"sha256":    "another",
-			},
-			expected: errs.ErrorInvalidField,
-		},
-		{
-			name: "different digest names",
-			attDigests: intoto.DigestSet{
-				"a-sha256":    "another",
-				"a-gitCommit": "mismatch_another_com",
-			},
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-				"sha256":    "another",
-			},
-			expected: errs.ErrorMismatch,
-		},
-		{
-			name: "mismatch sha256 digest",
-			attDigests: intoto.DigestSet{
-				"sha256":    "not_another",
-				"gitCommit": "mismatch_another_com",
-			},
-			inputDigests: intoto.DigestSet{
-				"gitCommit": "mismatch_another_com",
-				"sha256":    "another",
-			},
-			expected: errs.ErrorMismatch,
-		},
-	}
-	for _, tt := range tests {
-		tt := tt // Re-initializing variable so it is not changed while executing the closure below
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			err := verifyDigests(tt.attDigests, tt.inputDigests)
-			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
-				t.Fatalf("unexpected err (-want +got): \n%s", diff)
-			}
-		})
-	}
-}
-
 // TODO: split up the function?
 // TODO: support time verification.
 func Test_Verify(t *testing.T) {
 	t.Parallel()
 	digests := intoto.DigestSet{
-		"sha256":    "another",
-		"gitCommit": "another_com",
+		"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+		"gitCommit": "d83f6526d87d2d52ef4d789b2933c6cd7d9b8981",
 	}
 	subjects := []intoto.Subject{
 		intoto.Subject{
@@ -304,7 +222,7 @@ func Test_Verify(t *testing.T) {
 					Subjects: []intoto.Subject{
 						{
 							Digests: intoto.DigestSet{
-								"sha256": "another",
+								"sha256": "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
 								"":       "mismatch_another_com",
 							},
 						},
@@ -327,7 +245,7 @@ func Test_Verify(t *testing.T) {
 			packageName:        packageName,
 			packageVersion:     packageVersion,
 			digests: intoto.DigestSet{
-				"sha256": "another",
+				"sha256": "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
 				"":       "mismatch_another_com",
 			},
 			expected: errs.ErrorInvalidField,
@@ -341,7 +259,7 @@ func Test_Verify(t *testing.T) {
 					Subjects: []intoto.Subject{
 						{
 							Digests: intoto.DigestSet{
-								"sha256":    "another",
+								"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
 								"gitCommit": "",
 							},
 						},
@@ -365,8 +283,8 @@ func Test_Verify(t *testing.T) {
 					Subjects: []intoto.Subject{
 						{
 							Digests: intoto.DigestSet{
-								"sha256":    "another",
-								"gitCommit": "mismatch_another_com",
+								"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+								"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
 							},
 						},
 					},
@@ -378,7 +296,7 @@ func Test_Verify(t *testing.T) {
 			packageName:        packageName,
 			packageVersion:     packageVersion,
 			digests: intoto.DigestSet{
-				"sha256":    "another",
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
 				"gitCommit": "",
 			},
 			expected: errs.ErrorInvalidField,
@@ -391,8 +309,8 @@ func Test_Verify(t *testing.T) {
 			packageVersion:     packageVersion,
 			buildLevel:         buildLevel,
 			digests: intoto.DigestSet{
-				"sha256":    "not_another",
-				"gitCommit": "mismatch_another_com",
+				"sha256":    "cd84e8eb5ec577de03d8b159a56c809b9ec7ae1956f828bfab6479de57a1e88d",
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
 			},
 			expected: errs.ErrorMismatch,
 		},
@@ -404,8 +322,8 @@ func Test_Verify(t *testing.T) {
 			packageName:        packageName,
 			packageVersion:     packageVersion,
 			digests: intoto.DigestSet{
-				"sha256":    "another",
-				"gitCommit": "mismatch_another_com",
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
 			},
 			expected: errs.ErrorMismatch,
 		},
@@ -430,7 +348,7 @@ func Test_Verify(t *testing.T) {
 			packageName:        packageName,
 			packageVersion:     packageVersion,
 			digests: intoto.DigestSet{
-				"gitCommit": "another_com",
+				"gitCommit": "d83f6526d87d2d52ef4d789b2933c6cd7d9b8981",
 			},
 		},
 		{
@@ -625,3 +543,91 @@ func Test_Verify(t *testing.T) {
 		})
 	}
 }
+
+func Test_verifySubjects(t *testing.T) {
+	t.Parallel()
+
+	index := intoto.Subject{
+		Digests: intoto.DigestSet{
+			"sha256": "1bc04b5291c26a46d918139138b992d2de976d6851d0893b0476b85bfbdfc6e6",
+		},
+	}
+	amd64 := intoto.Subject{
+		Name: "linux/amd64",
+		Digests: intoto.DigestSet{
+			"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51",
+		},
+	}
+	arm64 := intoto.Subject{
+		Name: "linux/arm64",
+		Digests: intoto.DigestSet{
+			"sha256": "f69162950f235e3cdbbad33f1f912d1a504be90d8a37d002c735d6f3e3882265",
+		},
+	}
+	tests := []struct {
+		name     string
+		subjects []intoto.Subject
+		digests  intoto.DigestSet
+		expected error
+	}{
+		{
+			name:     "first subject",
+			subjects: []intoto.Subject{index, amd64, arm64},
+			digests:  index.Digests,
+		},
+		{
+			name:     "last subject",
+			subjects: []intoto.Subject{index, amd64, arm64},
+			digests:  arm64.Digests,
+		},
+		{
+			name:     "no matching subject",
+			subjects: []intoto.Subject{index, amd64},
+			digests:  arm64.Digests,
+			expected: errs.ErrorMismatch,
+		},
+		{
+			name:     "no subjects",
+			digests:  arm64.Digests,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "empty digests",
+			subjects: []intoto.Subject{index, amd64},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "invalid subject",
+			subjects: []intoto.Subject{index, amd64, intoto.Subject{
+				Name: "linux/arm64",
+			}},
+			digests:  amd64.Digests,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "uppercase digests",
+			subjects: []intoto.Subject{index, amd64, arm64},
+			digests: intoto.DigestSet{
+				"sha256": "F69162950F235E3CDBBAD33F1F912D1A504BE90D8A37D002C735D6F3E3882265",
+			},
+		},
+		{
+			name:     "invalid digests",
+			subjects: []intoto.Subject{index, amd64, arm64},
+			digests: intoto.DigestSet{
+				"sha256": "xyz",
+			},
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := verifySubjects(tt.subjects, tt.digests)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package publish
+
+import (
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/project"
+)
+
+// ProjectPolicy is a read-only view of the project policy of a package.
+// A project policy file with multiple packages has one view per package.
+type ProjectPolicy struct {
+	// ID is the ID of the reader the policy was created from, if known.
+	ID      string
+	Package PublishedPackage
+	// Requirements are the effective build requirements for each environment
+	// of the package. The key is empty if the package has no environment.
+	Requirements map[string]BuildRequirements
+	Owners       *Owners
+}
+
+// BuildRequirements defines the requirements to publish a package.
+type BuildRequirements struct {
+	Builders []PackageBuilder
+	Source   *SourceRequirements
+}
+
+// SourceRequirements defines the requirements on the source
+// the package is built from.
+type SourceRequirements struct {
+	Refs             []string
+	Workflows        []string
+	RequireSlsaLevel *int
+}
+
+// ProjectPolicies returns the project policies, one per package name
+// or pattern, sorted by name.
+func (p *Policy) ProjectPolicies() []ProjectPolicy {
+	policies := p.policy.ProjectPolicies()
+	res := make([]ProjectPolicy, len(policies))
+	for i := range policies {
+		res[i] = p.projectPolicy(policies[i])
+	}
+	return res
+}
+
+// ProjectPolicy returns the project policy for a package name, either
+// defined for its name or for a matching pattern.
+func (p *Policy) ProjectPolicy(packageName string) (ProjectPolicy, bool) {
+	policy, exists := p.policy.ProjectPolicy(packageName)
+	if !exists {
+		return ProjectPolicy{}, false
+	}
+	return p.projectPolicy(policy), true
+}
+
+func (p *Policy) projectPolicy(policy project.Policy) ProjectPolicy {
+	res := ProjectPolicy{
+		ID: policy.ID(),
+		Package: PublishedPackage{
+			Name: policy.Package.Name,
+			// NOTE: make a copy of the array.
+			Environments: append([]string{}, policy.Package.Environment.AnyOf...),
+		},
+		Requirements: make(map[string]BuildRequirements),
+	}
+	environments := []*string{nil}
+	if len(policy.Package.Environment.AnyOf) > 0 {
+		environments = make([]*string, len(policy.Package.Environment.AnyOf))
+		for i := range policy.Package.Environment.AnyOf {
+			environments[i] = &policy.Package.Environment.AnyOf[i]
+		}
+	}
+	for _, env := range environments {
+		key := ""
+		if env != nil {
+			key = *env
+		}
+		// NOTE: the environment is one of the package's environments, so there is no error.
+		builders, _ := p.PackageBuilders(policy.Package.Name, env)
+		requirements, _ := policy.EffectiveBuildRequirements(env)
+		res.Requirements[key] = BuildRequirements{
+			Builders: builders,
+			Source:   sourceRequirements(requirements.Source),
+		}
+	}
+	if policy.Owners != nil {
+		res.Owners = &Owners{
+			Team: policy.Owners.Team,
+			// NOTE: make a copy of the arrays.
+			Contacts:     append([]string{}, policy.Owners.Contacts...),
+			Editors:      append([]string{}, policy.Owners.Editors...),
+			Repositories: append([]string(nil), policy.Owners.Repositories...),
+		}
+	}
+	return res
+}
+
+func sourceRequirements(source *project.SourceRequirements) *SourceRequirements {
+	if source == nil {
+		return nil
+	}
+	res := &SourceRequirements{
+		// NOTE: make a copy of the arrays.
+		Refs:      append([]string{}, source.Refs.AnyOf...),
+		Workflows: append([]string{}, source.Workflows.AnyOf...),
+	}
+	if source.RequireSlsaLevel != nil {
+		level := *source.RequireSlsaLevel
+		res.RequireSlsaLevel = &level
+	}
+	return res
+}
@@ -0,0 +1,203 @@
+package publish
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/common"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/organization"
+	"github.com/slsa-framework/slsa-policy/pkg/publish/internal/project"
+)
+
+func Test_ProjectPolicies(t *testing.T) {
+	t.Parallel()
+	org := organization.Policy{
+		Format: 1,
+		Roots: organization.Roots{
+			Build: []organization.Root{
+				{
+					ID:        "builder_id1",
+					Name:      "builder_name1",
+					SlsaLevel: common.AsPointer(2),
+				},
+				{
+					ID:        "builder_id2",
+					Name:      "builder_name2",
+					SlsaLevel: common.AsPointer(3),
+				},
+			},
+		},
+	}
+	projects := []project.Policy{
+		{
+			Format: 1,
+			Package: project.Package{
+				Name: "package_name1",
+				Environment: project.Environment{
+					AnyOf: []string{"dev", "prod"},
+				},
+			},
+			BuildRequirements: project.BuildRequirements{
+				RequireSlsaBuilder: "builder_name2",
+				Repository: project.Repository{
+					URI: "source_name1",
+				},
+				Source: &project.SourceRequirements{
+					Refs: project.Patterns{
+						AnyOf: []string{"refs/heads/main"},
+					},
+				},
+				Environments: map[string]project.EnvironmentBuildRequirements{
+					"dev": {
+						RequireSlsaBuilder: "builder_name1",
+					},
+				},
+			},
+			Owners: &project.Owners{
+				Team:    "team1",
+				Editors: []string{"@org/team1"},
+			},
+		},
+		{
+			Format: 1,
+			Package: project.Package{
+				Name: "org/*",
+			},
+			BuildRequirements: project.BuildRequirements{
+				RequireSlsaBuilder: "builder_name1",
+				Repository: project.Repository{
+					URI: "source_name2",
+				},
+			},
+		},
+	}
+	policy1 := ProjectPolicy{
+		Package: PublishedPackage{
+			Name:         "package_name1",
+			Environments: []string{"dev", "prod"},
+		},
+		Requirements: map[string]BuildRequirements{
+			"dev": {
+				Builders: []PackageBuilder{
+					{
+						ID:         "builder_id1",
+						Name:       "builder_name1",
+						Repository: "source_name1",
+						SlsaLevel:  2,
+					},
+				},
+				Source: &SourceRequirements{
+					Refs:      []string{"refs/heads/main"},
+					Workflows: []string{},
+				},
+			},
+			"prod": {
+				Builders: []PackageBuilder{
+					{
+						ID:         "builder_id2",
+						Name:       "builder_name2",
+						Repository: "source_name1",
+						SlsaLevel:  3,
+					},
+				},
+				Source: &SourceRequirements{
+					Refs:      []string{"refs/heads/main"},
+					Workflows: []string{},
+				},
+			},
+		},
+		Owners: &Owners{
+			Team:     "team1",
+			Contacts: []string{},
+			Editors:  []string{"@org/team1"},
+		},
+	}
+	policy2 := ProjectPolicy{
+		Package: PublishedPackage{
+			Name:         "org/*",
+			Environments: []string{},
+		},
+		Requirements: map[string]BuildRequirements{
+			"": {
+				Builders: []PackageBuilder{
+					{
+						ID:         "builder_id1",
+						Name:       "builder_name1",
+						Repository: "source_name2",
+						SlsaLevel:  2,
+					},
+				},
+			},
+		},
+	}
+
+	// Marshal the org policy.
+	content, err := json.Marshal(org)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	orgReader := io.NopCloser(bytes.NewReader(content))
+	// Marshal the project policies into bytes.
+	policies := make([][]byte, len(projects), len(projects))
+	for i := range projects {
+		content, err := json.Marshal(projects[i])
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		policies[i] = content
+	}
+	policy, err := PolicyNew(orgReader, common.NewBytesIterator(policies), newPackageHelper("registry"))
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+
+	if diff := cmp.Diff([]ProjectPolicy{policy2, policy1}, policy.ProjectPolicies()); diff != "" {
+		t.Fatalf("unexpected policies (-want +got): \n%s", diff)
+	}
+	tests := []struct {
+		name        string
+		packageName string
+		expected    *ProjectPolicy
+	}{
+		{
+			name:        "package name",
+			packageName: "package_name1",
+			expected:    &policy1,
+		},
+		{
+			name:        "pattern",
+			packageName: "org/*",
+			expected:    &policy2,
+		},
+		{
+			name:        "package matching a pattern",
+			packageName: "org/package_name2",
+			expected:    &policy2,
+		},
+		{
+			name:        "unknown package",
+			packageName: "package_name3",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectPolicy, exists := policy.ProjectPolicy(tt.packageName)
+			if exists != (tt.expected != nil) {
+				t.Fatalf("unexpected exists: %v", exists)
+			}
+			if !exists {
+				return
+			}
+			if diff := cmp.Diff(*tt.expected, projectPolicy); diff != "" {
+				t.Fatalf("unexpected policy (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package diff
+
+import (
+	"slices"
+	"sort"
+)
+
+// Kind defines the kind of a change.
+type Kind string
+
+const (
+	KindAdded    Kind = "added"
+	KindRemoved  Kind = "removed"
+	KindModified Kind = "modified"
+)
+
+// Change defines a semantic change between two versions of a policy.
+type Change struct {
+	Kind Kind `json:"kind"`
+	// Subject is what changed, e.g. a package name or a policy ID.
+	// It is empty for the org policy.
+	Subject string `json:"subject,omitempty"`
+	// Field is the changed field of the subject, e.g. environment.
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+	// Weakening is true if the change weakens the policy,
+	// e.g. a lower SLSA level or a new environment.
+	Weakening bool `json:"weakening"`
+}
+
+// Sort sorts changes by subject, field, kind and values.
+func Sort(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Subject != b.Subject {
+			return a.Subject < b.Subject
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Old != b.Old {
+			return a.Old < b.Old
+		}
+		return a.New < b.New
+	})
+}
+
+// Strings returns the changes between two sets of values: the values
+// removed and the values added.
+func Strings(old, new []string) (removed, added []string) {
+	oldSet := make(map[string]bool, len(old))
+	for _, value := range old {
+		oldSet[value] = true
+	}
+	newSet := make(map[string]bool, len(new))
+	for _, value := range new {
+		newSet[value] = true
+		if !oldSet[value] && !slices.Contains(added, value) {
+			added = append(added, value)
+		}
+	}
+	for _, value := range old {
+		if !newSet[value] && !slices.Contains(removed, value) {
+			removed = append(removed, value)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+	return removed, added
+}
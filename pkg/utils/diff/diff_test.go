@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Strings(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		old     []string
+		new     []string
+		removed []string
+		added   []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "same values",
+			old:  []string{"dev", "prod"},
+			new:  []string{"prod", "dev"},
+		},
+		{
+			name:    "removed and added",
+			old:     []string{"prod", "dev", "dev"},
+			new:     []string{"staging", "prod", "canary", "staging"},
+			removed: []string{"dev"},
+			added:   []string{"canary", "staging"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			removed, added := Strings(tt.old, tt.new)
+			if diff := cmp.Diff(tt.removed, removed); diff != "" {
+				t.Fatalf("unexpected removed (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.added, added); diff != "" {
+				t.Fatalf("unexpected added (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Sort(t *testing.T) {
+	t.Parallel()
+	changes := []Change{
+		{Kind: KindAdded, Subject: "b", Field: "package"},
+		{Kind: KindRemoved, Subject: "a", Field: "environment", Old: "prod"},
+		{Kind: KindAdded, Subject: "a", Field: "environment", New: "dev"},
+		{Kind: KindModified, Field: "builder[name].slsa_level", Old: "2", New: "3"},
+	}
+	expected := []Change{
+		{Kind: KindModified, Field: "builder[name].slsa_level", Old: "2", New: "3"},
+		{Kind: KindAdded, Subject: "a", Field: "environment", New: "dev"},
+		{Kind: KindRemoved, Subject: "a", Field: "environment", Old: "prod"},
+		{Kind: KindAdded, Subject: "b", Field: "package"},
+	}
+	Sort(changes)
+	if diff := cmp.Diff(expected, changes); diff != "" {
+		t.Fatalf("unexpected changes (-want +got): \n%s", diff)
+	}
+}
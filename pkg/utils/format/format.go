@@ -0,0 +1,150 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+// JSON returns the canonical encoding of a policy file: object keys are sorted,
+// null values and empty objects are removed, packages are sorted by name,
+// environments are sorted, and the content is indented with two spaces
+// and ends with a newline.
+func JSON(v interface{}) ([]byte, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	return FormatJSON(content)
+}
+
+// FormatJSON returns the canonical encoding of JSON content. See JSON.
+func FormatJSON(content []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// NOTE: keep numbers as is, to avoid float conversions.
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal: %w", errs.ErrorInvalidInput, err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: trailing data after JSON value", errs.ErrorInvalidInput)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	// NOTE: the encoder sorts map keys and adds a trailing newline.
+	if err := encoder.Encode(canonicalize(value, "")); err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// YAML returns the canonical YAML encoding of a policy file. See JSON.
+func YAML(v interface{}) ([]byte, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	return FormatYAML(content)
+}
+
+// FormatYAML returns the canonical YAML encoding of JSON content. See JSON.
+func FormatYAML(content []byte) ([]byte, error) {
+	canonical, err := FormatJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: the encoder sorts map keys.
+	content, err = yaml.JSONToYAML(canonical)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	return content, nil
+}
+
+// canonicalize removes the null values and empty objects of maps,
+// sorts packages by name and sorts environments. The key is the field
+// name of the value in its parent object.
+func canonicalize(value interface{}, key string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			field = canonicalize(field, name)
+			if isEmpty(field) {
+				delete(v, name)
+				continue
+			}
+			v[name] = field
+		}
+		if key == "environment" {
+			sortStrings(v["any_of"])
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = canonicalize(v[i], "")
+		}
+		if key == "packages" {
+			sortByName(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// sortStrings sorts an array of strings. Other values are left as is.
+func sortStrings(value interface{}) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return
+	}
+	strs := make([]string, len(values))
+	for i := range values {
+		str, ok := values[i].(string)
+		if !ok {
+			return
+		}
+		strs[i] = str
+	}
+	sort.Strings(strs)
+	for i := range strs {
+		values[i] = strs[i]
+	}
+}
+
+// sortByName sorts an array of objects by their name field.
+// Arrays with other values are left as is.
+func sortByName(values []interface{}) {
+	for _, value := range values {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if _, ok := object["name"].(string); !ok {
+			return
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return name(values[i]) < name(values[j])
+	})
+}
+
+func name(value interface{}) string {
+	return value.(map[string]interface{})["name"].(string)
+}
+
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	m, ok := value.(map[string]interface{})
+	return ok && len(m) == 0
+}
@@ -0,0 +1,178 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_FormatJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		output   string
+		expected error
+	}{
+		{
+			name:    "sorted keys",
+			content: `{"b": 1, "a": {"d": [3, 2], "c": "x"}}`,
+			output: "" +
+				"{\n" +
+				"  \"a\": {\n" +
+				"    \"c\": \"x\",\n" +
+				"    \"d\": [\n" +
+				"      3,\n" +
+				"      2\n" +
+				"    ]\n" +
+				"  },\n" +
+				"  \"b\": 1\n" +
+				"}\n",
+		},
+		{
+			name:    "null and empty objects removed",
+			content: `{"a": null, "b": {"c": {}, "d": null}, "e": [], "f": [{"g": null}]}`,
+			output: "" +
+				"{\n" +
+				"  \"e\": [],\n" +
+				"  \"f\": [\n" +
+				"    {}\n" +
+				"  ]\n" +
+				"}\n",
+		},
+		{
+			name:    "html not escaped",
+			content: `{"a": "<b>&"}`,
+			output: "" +
+				"{\n" +
+				"  \"a\": \"<b>&\"\n" +
+				"}\n",
+		},
+		{
+			name:    "packages and environments sorted",
+			content: `{"packages": [{"name": "b", "environment": {"any_of": ["prod", "dev"]}}, {"name": "a"}], "builders": {"any_of": [{"name": "y"}, {"name": "x"}]}}`,
+			output: "" +
+				"{\n" +
+				"  \"builders\": {\n" +
+				"    \"any_of\": [\n" +
+				"      {\n" +
+				"        \"name\": \"y\"\n" +
+				"      },\n" +
+				"      {\n" +
+				"        \"name\": \"x\"\n" +
+				"      }\n" +
+				"    ]\n" +
+				"  },\n" +
+				"  \"packages\": [\n" +
+				"    {\n" +
+				"      \"name\": \"a\"\n" +
+				"    },\n" +
+				"    {\n" +
+				"      \"environment\": {\n" +
+				"        \"any_of\": [\n" +
+				"          \"dev\",\n" +
+				"          \"prod\"\n" +
+				"        ]\n" +
+				"      },\n" +
+				"      \"name\": \"b\"\n" +
+				"    }\n" +
+				"  ]\n" +
+				"}\n",
+		},
+		{
+			name:    "packages without names not sorted",
+			content: `{"packages": [{"name": "b"}, {"id": "a"}]}`,
+			output: "" +
+				"{\n" +
+				"  \"packages\": [\n" +
+				"    {\n" +
+				"      \"name\": \"b\"\n" +
+				"    },\n" +
+				"    {\n" +
+				"      \"id\": \"a\"\n" +
+				"    }\n" +
+				"  ]\n" +
+				"}\n",
+		},
+		{
+			name:     "trailing data",
+			content:  `{"a": 1} {"b": 2}`,
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name:     "invalid JSON",
+			content:  `{"a": `,
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := FormatJSON([]byte(tt.content))
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.output, string(output)); diff != "" {
+				t.Fatalf("unexpected output (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_FormatYAML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		output   string
+		expected error
+	}{
+		{
+			name:    "canonical",
+			content: `{"format": 1, "protection": {"google_service_account": "sa@x.com"}, "packages": [{"name": "b", "environment": {"any_of": ["prod", "dev"]}}, {"name": "a"}], "owners": null}`,
+			output: "" +
+				"format: 1\n" +
+				"packages:\n" +
+				"- name: a\n" +
+				"- environment:\n" +
+				"    any_of:\n" +
+				"    - dev\n" +
+				"    - prod\n" +
+				"  name: b\n" +
+				"protection:\n" +
+				"  google_service_account: sa@x.com\n",
+		},
+		{
+			name:     "invalid JSON",
+			content:  `{"a": `,
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := FormatYAML([]byte(tt.content))
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.output, string(output)); diff != "" {
+				t.Fatalf("unexpected output (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
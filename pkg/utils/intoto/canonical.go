@@ -0,0 +1,46 @@
+package intoto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+// CanonicalJSON returns the canonical JSON encoding of v: object keys
+// are sorted, there is no insignificant whitespace and HTML characters
+// are not escaped. Numbers are preserved as they are encoded by v's
+// JSON marshaller. Two values with the same JSON content have
+// the same canonical encoding.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	return CanonicalizeJSON(content)
+}
+
+// CanonicalizeJSON returns the canonical encoding of JSON content.
+// See CanonicalJSON.
+func CanonicalizeJSON(content []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// NOTE: keep numbers as is, to avoid float conversions.
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal: %w", errs.ErrorInvalidInput, err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: trailing data after JSON value", errs.ErrorInvalidInput)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	// NOTE: the encoder sorts map keys.
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	// NOTE: Encode() adds a trailing newline.
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,62 @@
+package intoto
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_CanonicalizeJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		content   string
+		canonical string
+		expected  error
+	}{
+		{
+			name:      "sorted keys",
+			content:   `{"b": 1, "a": {"d": [3, 2], "c": null}}`,
+			canonical: `{"a":{"c":null,"d":[3,2]},"b":1}`,
+		},
+		{
+			name:      "numbers preserved",
+			content:   `{"big": 12345678901234567890, "float": 1.50}`,
+			canonical: `{"big":12345678901234567890,"float":1.50}`,
+		},
+		{
+			name:      "html not escaped",
+			content:   `{"a": "<b>&"}`,
+			canonical: `{"a":"<b>&"}`,
+		},
+		{
+			name:     "trailing data",
+			content:  `{"a": 1} {"b": 2}`,
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name:     "invalid json",
+			content:  `{"a":`,
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			canonical, err := CanonicalizeJSON([]byte(tt.content))
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.canonical, string(canonical)); diff != "" {
+				t.Fatalf("unexpected content (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package intoto
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+// Digest algorithm names, as defined in
+// https://github.com/in-toto/attestation/blob/main/spec/v1/digest_set.md.
+const (
+	AlgorithmSha1      = "sha1"
+	AlgorithmSha224    = "sha224"
+	AlgorithmSha256    = "sha256"
+	AlgorithmSha384    = "sha384"
+	AlgorithmSha512    = "sha512"
+	AlgorithmGitCommit = "gitCommit"
+	AlgorithmGitTree   = "gitTree"
+)
+
+// DigestValidation defines how strictly digests are validated.
+type DigestValidation int
+
+const (
+	// DigestValidationPermissive accepts algorithms that are not registered,
+	// as allowed by the in-toto specification, and uppercase hex values
+	// for registered algorithms. Values are normalized to lowercase.
+	DigestValidationPermissive DigestValidation = iota
+	// DigestValidationStrict only accepts registered algorithms
+	// with lowercase hex values.
+	DigestValidationStrict
+)
+
+// Validate validates the mode.
+func (m DigestValidation) Validate() error {
+	switch m {
+	case DigestValidationPermissive, DigestValidationStrict:
+		return nil
+	}
+	return fmt.Errorf("%w: invalid digest validation (%d)", errs.ErrorInvalidInput, m)
+}
+
+// DigestAlgorithm defines a digest algorithm and the format of its values.
+type DigestAlgorithm struct {
+	// Name is the algorithm name, e.g. sha256.
+	Name string
+	// HexLengths contains the allowed lengths of the
+	// lowercase hex-encoded values.
+	HexLengths []int
+}
+
+var digestAlgorithms = map[string]DigestAlgorithm{
+	AlgorithmSha1:   {Name: AlgorithmSha1, HexLengths: []int{40}},
+	AlgorithmSha224: {Name: AlgorithmSha224, HexLengths: []int{56}},
+	AlgorithmSha256: {Name: AlgorithmSha256, HexLengths: []int{64}},
+	AlgorithmSha384: {Name: AlgorithmSha384, HexLengths: []int{96}},
+	AlgorithmSha512: {Name: AlgorithmSha512, HexLengths: []int{128}},
+	// NOTE: git uses sha1 or sha256 object IDs.
+	AlgorithmGitCommit: {Name: AlgorithmGitCommit, HexLengths: []int{40, 64}},
+	AlgorithmGitTree:   {Name: AlgorithmGitTree, HexLengths: []int{40, 64}},
+}
+
+// DigestAlgorithmByName returns the registered algorithm with the name.
+func DigestAlgorithmByName(name string) (DigestAlgorithm, bool) {
+	alg, exists := digestAlgorithms[name]
+	return alg, exists
+}
+
+// DigestAlgorithms returns the names of the registered algorithms, sorted.
+func DigestAlgorithms() []string {
+	names := make([]string, 0, len(digestAlgorithms))
+	for name := range digestAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate validates a value for the algorithm.
+func (a DigestAlgorithm) Validate(value string) error {
+	if !slices.Contains(a.HexLengths, len(value)) {
+		return fmt.Errorf("%w: digest (%q) has length %d, expected one of %v for algorithm (%q)",
+			errs.ErrorInvalidField, value, len(value), a.HexLengths, a.Name)
+	}
+	for _, c := range value {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return fmt.Errorf("%w: digest (%q) is not lowercase hex for algorithm (%q)",
+				errs.ErrorInvalidField, value, a.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateDigest validates a digest value for a registered algorithm.
+func ValidateDigest(name, value string) error {
+	return validateDigestWithMode(name, value, DigestValidationStrict)
+}
+
+func validateDigestWithMode(name, value string, mode DigestValidation) error {
+	alg, exists := DigestAlgorithmByName(name)
+	if !exists {
+		if mode == DigestValidationPermissive {
+			return nil
+		}
+		return fmt.Errorf("%w: unsupported digest algorithm (%q). Must be one of %q",
+			errs.ErrorInvalidField, name, DigestAlgorithms())
+	}
+	if mode == DigestValidationPermissive {
+		value = strings.ToLower(value)
+	}
+	return alg.Validate(value)
+}
+
+// DigestSetFromString parses a digest of the form algorithm:value,
+// e.g. sha256:xxxx. The algorithm must be registered.
+func DigestSetFromString(digest string) (DigestSet, error) {
+	name, value, found := strings.Cut(digest, ":")
+	if !found {
+		return nil, fmt.Errorf("%w: digest (%q) is not of the form algorithm:value", errs.ErrorInvalidField, digest)
+	}
+	if err := ValidateDigest(name, value); err != nil {
+		return nil, err
+	}
+	return DigestSet{name: value}, nil
+}
+
+// Select returns the first algorithm in the preference list that is present
+// in the digest set, along with its value. It lets verifiers choose an
+// algorithm they support.
+func (ds DigestSet) Select(algorithms ...string) (string, string, error) {
+	for _, name := range algorithms {
+		if value, exists := ds[name]; exists {
+			return name, value, nil
+		}
+	}
+	return "", "", fmt.Errorf("%w: no supported digest algorithm (%q) in digests (%q)",
+		errs.ErrorNotFound, algorithms, ds)
+}
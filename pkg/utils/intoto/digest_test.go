@@ -0,0 +1,221 @@
+package intoto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_DigestSetFromString(t *testing.T) {
+	t.Parallel()
+
+	sha256 := strings.Repeat("a", 64)
+	sha512 := strings.Repeat("b", 128)
+	sha1 := strings.Repeat("c", 40)
+	tests := []struct {
+		name     string
+		digest   string
+		digests  DigestSet
+		expected error
+	}{
+		{
+			name:    "sha256",
+			digest:  "sha256:" + sha256,
+			digests: DigestSet{"sha256": sha256},
+		},
+		{
+			name:    "sha512",
+			digest:  "sha512:" + sha512,
+			digests: DigestSet{"sha512": sha512},
+		},
+		{
+			name:    "sha1 git commit",
+			digest:  "gitCommit:" + sha1,
+			digests: DigestSet{"gitCommit": sha1},
+		},
+		{
+			name:    "sha256 git commit",
+			digest:  "gitCommit:" + sha256,
+			digests: DigestSet{"gitCommit": sha256},
+		},
+		{
+			name:     "no separator",
+			digest:   sha256,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "unsupported algorithm",
+			digest:   "md5:" + strings.Repeat("a", 32),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "invalid length",
+			digest:   "sha512:" + sha256,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "uppercase value",
+			digest:   "sha256:" + strings.Repeat("A", 64),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "non hex value",
+			digest:   "sha256:" + strings.Repeat("g", 64),
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "empty value",
+			digest:   "sha256:",
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			digests, err := DigestSetFromString(tt.digest)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.digests, digests); diff != "" {
+				t.Fatalf("unexpected digests (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Select(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		digests    DigestSet
+		algorithms []string
+		algorithm  string
+		value      string
+		expected   error
+	}{
+		{
+			name:       "first preference",
+			digests:    DigestSet{"sha256": "a", "sha512": "b"},
+			algorithms: []string{"sha256", "sha512"},
+			algorithm:  "sha256",
+			value:      "a",
+		},
+		{
+			name:       "second preference",
+			digests:    DigestSet{"sha512": "b", "gitCommit": "c"},
+			algorithms: []string{"sha256", "sha512"},
+			algorithm:  "sha512",
+			value:      "b",
+		},
+		{
+			name:       "no supported algorithm",
+			digests:    DigestSet{"gitCommit": "c"},
+			algorithms: []string{"sha256", "sha512"},
+			expected:   errs.ErrorNotFound,
+		},
+		{
+			name:     "no algorithms",
+			digests:  DigestSet{"sha256": "a"},
+			expected: errs.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			algorithm, value, err := tt.digests.Select(tt.algorithms...)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.algorithm, algorithm); diff != "" {
+				t.Fatalf("unexpected algorithm (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.value, value); diff != "" {
+				t.Fatalf("unexpected value (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Normalize(t *testing.T) {
+	t.Parallel()
+
+	sha256 := strings.Repeat("ab", 32)
+	tests := []struct {
+		name       string
+		digests    DigestSet
+		mode       DigestValidation
+		normalized DigestSet
+		expected   error
+	}{
+		{
+			name:       "permissive lowercase",
+			digests:    DigestSet{"sha256": sha256},
+			normalized: DigestSet{"sha256": sha256},
+		},
+		{
+			name:       "permissive uppercase",
+			digests:    DigestSet{"sha256": strings.ToUpper(sha256)},
+			normalized: DigestSet{"sha256": sha256},
+		},
+		{
+			name:       "permissive unknown algorithm",
+			digests:    DigestSet{"sha256": sha256, "custom": "Some_Value"},
+			normalized: DigestSet{"sha256": sha256, "custom": "Some_Value"},
+		},
+		{
+			name:     "permissive invalid value",
+			digests:  DigestSet{"sha256": "xyz"},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:       "strict lowercase",
+			digests:    DigestSet{"sha256": sha256},
+			mode:       DigestValidationStrict,
+			normalized: DigestSet{"sha256": sha256},
+		},
+		{
+			name:     "strict uppercase",
+			digests:  DigestSet{"sha256": strings.ToUpper(sha256)},
+			mode:     DigestValidationStrict,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "strict unknown algorithm",
+			digests:  DigestSet{"sha256": sha256, "custom": "some_value"},
+			mode:     DigestValidationStrict,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "strict invalid value",
+			digests:  DigestSet{"sha256": "xyz"},
+			mode:     DigestValidationStrict,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "empty digests",
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			normalized, err := tt.digests.Normalize(tt.mode)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.normalized, normalized); diff != "" {
+				t.Fatalf("unexpected digests (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package intoto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+// PayloadType is the DSSE payload type of in-toto statements.
+const PayloadType = "application/vnd.in-toto+json"
+
+// Envelope defines a DSSE envelope.
+// See https://github.com/secure-systems-lab/dsse/blob/master/envelope.md.
+type Envelope struct {
+	PayloadType string `json:"payloadType"`
+	// Payload is the base64-encoded payload.
+	Payload    string      `json:"payload"`
+	Signatures []Signature `json:"signatures"`
+}
+
+// Signature defines a DSSE signature.
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	// Sig is the base64-encoded signature over the PAE
+	// of the payload type and payload.
+	Sig string `json:"sig"`
+}
+
+// EnvelopeNew creates an unsigned envelope for the payload.
+func EnvelopeNew(payloadType string, payload []byte) (*Envelope, error) {
+	if payloadType == "" {
+		return nil, fmt.Errorf("%w: empty payload type", errs.ErrorInvalidInput)
+	}
+	return &Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{},
+	}, nil
+}
+
+// EnvelopeFromBytes parses a JSON-encoded envelope.
+func EnvelopeFromBytes(content []byte) (*Envelope, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal envelope: %w", errs.ErrorInvalidInput, err)
+	}
+	if err := envelope.Validate(); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
+// Validate validates the envelope.
+func (e *Envelope) Validate() error {
+	if e.PayloadType == "" {
+		return fmt.Errorf("%w: empty payload type", errs.ErrorInvalidField)
+	}
+	if _, err := e.DecodePayload(); err != nil {
+		return err
+	}
+	for i := range e.Signatures {
+		if _, err := base64.StdEncoding.DecodeString(e.Signatures[i].Sig); err != nil {
+			return fmt.Errorf("%w: signature #%d is not base64: %w", errs.ErrorInvalidField, i, err)
+		}
+	}
+	return nil
+}
+
+// DecodePayload returns the decoded payload.
+func (e *Envelope) DecodePayload() ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: payload is not base64: %w", errs.ErrorInvalidField, err)
+	}
+	return payload, nil
+}
+
+// PAE returns the pre-authentication encoding of the envelope,
+// i.e. the bytes that are signed.
+func (e *Envelope) PAE() ([]byte, error) {
+	payload, err := e.DecodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return PAE(e.PayloadType, payload), nil
+}
+
+// AddSignature adds a signature over the envelope's PAE.
+func (e *Envelope) AddSignature(keyID string, sig []byte) error {
+	if len(sig) == 0 {
+		return fmt.Errorf("%w: empty signature", errs.ErrorInvalidInput)
+	}
+	e.Signatures = append(e.Signatures, Signature{
+		KeyID: keyID,
+		Sig:   base64.StdEncoding.EncodeToString(sig),
+	})
+	return nil
+}
+
+// ToBytes returns the JSON encoding of the envelope.
+func (e *Envelope) ToBytes() ([]byte, error) {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+	return content, nil
+}
+
+// PAE returns the DSSE v1 pre-authentication encoding:
+// "DSSEv1" SP LEN(type) SP type SP LEN(body) SP body.
+func PAE(payloadType string, payload []byte) []byte {
+	pae := []byte("DSSEv1 ")
+	pae = strconv.AppendInt(pae, int64(len(payloadType)), 10)
+	pae = append(pae, ' ')
+	pae = append(pae, payloadType...)
+	pae = append(pae, ' ')
+	pae = strconv.AppendInt(pae, int64(len(payload)), 10)
+	pae = append(pae, ' ')
+	return append(pae, payload...)
+}
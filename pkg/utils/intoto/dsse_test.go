@@ -0,0 +1,135 @@
+package intoto
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_PAE(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		payloadType string
+		payload     string
+		pae         string
+	}{
+		{
+			// See https://github.com/secure-systems-lab/dsse/blob/master/protocol.md#test-vectors.
+			name:        "spec vector",
+			payloadType: "http://example.com/HelloWorld",
+			payload:     "hello world",
+			pae:         "DSSEv1 29 http://example.com/HelloWorld 11 hello world",
+		},
+		{
+			name:        "empty payload",
+			payloadType: PayloadType,
+			pae:         "DSSEv1 28 application/vnd.in-toto+json 0 ",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pae := PAE(tt.payloadType, []byte(tt.payload))
+			if diff := cmp.Diff(tt.pae, string(pae)); diff != "" {
+				t.Fatalf("unexpected pae (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_EnvelopeFromBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		payload  string
+		expected error
+	}{
+		{
+			name:    "valid envelope",
+			content: `{"payloadType":"application/vnd.in-toto+json","payload":"aGVsbG8gd29ybGQ=","signatures":[{"keyid":"key","sig":"c2ln"}]}`,
+			payload: "hello world",
+		},
+		{
+			name:    "no signatures",
+			content: `{"payloadType":"application/vnd.in-toto+json","payload":"aGVsbG8gd29ybGQ="}`,
+			payload: "hello world",
+		},
+		{
+			name:     "empty payload type",
+			content:  `{"payload":"aGVsbG8gd29ybGQ="}`,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "payload not base64",
+			content:  `{"payloadType":"application/vnd.in-toto+json","payload":"not base64"}`,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "signature not base64",
+			content:  `{"payloadType":"application/vnd.in-toto+json","payload":"aGVsbG8gd29ybGQ=","signatures":[{"sig":"not base64"}]}`,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "invalid json",
+			content:  `{"payloadType":`,
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			envelope, err := EnvelopeFromBytes([]byte(tt.content))
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			payload, err := envelope.DecodePayload()
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if diff := cmp.Diff(tt.payload, string(payload)); diff != "" {
+				t.Fatalf("unexpected payload (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_EnvelopeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	envelope, err := EnvelopeNew(PayloadType, []byte("hello world"))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := envelope.AddSignature("key", []byte("sig")); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	content, err := envelope.ToBytes()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	parsed, err := EnvelopeFromBytes(content)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := cmp.Diff(envelope, parsed); diff != "" {
+		t.Fatalf("unexpected envelope (-want +got): \n%s", diff)
+	}
+	pae, err := parsed.PAE()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := cmp.Diff("DSSEv1 28 application/vnd.in-toto+json 11 hello world", string(pae)); diff != "" {
+		t.Fatalf("unexpected pae (-want +got): \n%s", diff)
+	}
+}
@@ -2,6 +2,7 @@ package intoto
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/slsa-framework/slsa-policy/pkg/errs"
@@ -51,6 +52,34 @@ func (s Subject) Validate() error {
 	return s.Digests.Validate()
 }
 
+// ValidateWithMode validates the subject's digests.
+func (s Subject) ValidateWithMode(mode DigestValidation) error {
+	return s.Digests.ValidateWithMode(mode)
+}
+
+// Normalize validates the subject and returns a copy with normalized digests.
+func (s Subject) Normalize(mode DigestValidation) (Subject, error) {
+	digests, err := s.Digests.Normalize(mode)
+	if err != nil {
+		return Subject{}, err
+	}
+	return Subject{Name: s.Name, Digests: digests}, nil
+}
+
+// NormalizeSubjects validates the subjects and returns
+// a copy with normalized digests.
+func NormalizeSubjects(subjects []Subject, mode DigestValidation) ([]Subject, error) {
+	normalized := make([]Subject, len(subjects))
+	for i := range subjects {
+		subject, err := subjects[i].Normalize(mode)
+		if err != nil {
+			return nil, err
+		}
+		normalized[i] = subject
+	}
+	return normalized, nil
+}
+
 func (r PackageDescriptor) Validate() error {
 	if r.Name == "" {
 		return fmt.Errorf("%w: package name is empty", errs.ErrorInvalidField)
@@ -61,7 +90,15 @@ func (r PackageDescriptor) Validate() error {
 	return nil
 }
 
+// Validate validates the digests in permissive mode.
 func (ds DigestSet) Validate() error {
+	return ds.ValidateWithMode(DigestValidationPermissive)
+}
+
+// ValidateWithMode validates the digests. Values of registered algorithms
+// must have the correct length and be hex-encoded. See DigestValidation
+// for the differences between modes.
+func (ds DigestSet) ValidateWithMode(mode DigestValidation) error {
 	if len(ds) == 0 {
 		return fmt.Errorf("%w: digests empty", errs.ErrorInvalidField)
 	}
@@ -72,6 +109,39 @@ func (ds DigestSet) Validate() error {
 		if v == "" {
 			return fmt.Errorf("%w: digests key (%q) has empty value", errs.ErrorInvalidField, k)
 		}
+		if err := validateDigestWithMode(k, v, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Normalize validates the digests and returns a copy with the values
+// of registered algorithms in lowercase.
+func (ds DigestSet) Normalize(mode DigestValidation) (DigestSet, error) {
+	if err := ds.ValidateWithMode(mode); err != nil {
+		return nil, err
+	}
+	normalized := make(DigestSet, len(ds))
+	for k, v := range ds {
+		if _, exists := DigestAlgorithmByName(k); exists {
+			v = strings.ToLower(v)
+		}
+		normalized[k] = v
+	}
+	return normalized, nil
+}
+
+// Validate validates the resource descriptor. At least one of
+// the URI, digest or content must be set.
+func (r ResourceDescriptor) Validate() error {
+	if r.URI == "" && len(r.Digest) == 0 && len(r.Content) == 0 {
+		return fmt.Errorf("%w: resource descriptor has no uri, digest or content", errs.ErrorInvalidField)
+	}
+	if len(r.Digest) > 0 {
+		if err := r.Digest.Validate(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
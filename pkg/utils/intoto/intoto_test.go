@@ -20,8 +20,8 @@ func Test_ValidateSubject(t *testing.T) {
 			name: "valid subject",
 			subject: Subject{
 				Digests: DigestSet{
-					"sha256":    "some_value",
-					"gitCommit": "another_value",
+					"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+					"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
 				},
 			},
 		},
@@ -34,7 +34,7 @@ func Test_ValidateSubject(t *testing.T) {
 			name: "empty digest key",
 			subject: Subject{
 				Digests: DigestSet{
-					"sha256": "some_value",
+					"sha256": "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
 					"":       "another_value",
 				},
 			},
@@ -44,7 +44,7 @@ func Test_ValidateSubject(t *testing.T) {
 			name: "empty digest value",
 			subject: Subject{
 				Digests: DigestSet{
-					"sha256":    "some_value",
+					"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
 					"gitCommit": "",
 				},
 			},
@@ -74,8 +74,8 @@ func Test_ValidateDigests(t *testing.T) {
 		{
 			name: "valid digests",
 			digests: DigestSet{
-				"sha256":    "some_value",
-				"gitCommit": "another_value",
+				"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
+				"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
 			},
 		},
 		{
@@ -85,7 +85,7 @@ func Test_ValidateDigests(t *testing.T) {
 		{
 			name: "empty key",
 			digests: DigestSet{
-				"sha256": "some_value",
+				"sha256": "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
 				"":       "another_value",
 			},
 			expected: errs.ErrorInvalidField,
@@ -93,7 +93,7 @@ func Test_ValidateDigests(t *testing.T) {
 		{
 			name: "empty value",
 			digests: DigestSet{
-				"sha256":    "some_value",
+				"sha256":    "bf8260204a85f123e8c486c01057463ae681906de652202e82c7aa25d9e06bfe",
 				"gitCommit": "",
 			},
 			expected: errs.ErrorInvalidField,
@@ -0,0 +1,155 @@
+package intoto
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+// StatementTypeV1 is the type of in-toto v1 statements.
+const StatementTypeV1 = "https://in-toto.io/Statement/v1"
+
+// StatementTypeV01 is the type of legacy in-toto v0.1 statements,
+// e.g. used by SLSA v0.2 provenance. They have the same layout as v1 statements.
+const StatementTypeV01 = "https://in-toto.io/Statement/v0.1"
+
+// Statement defines an in-toto v1 statement with an opaque predicate.
+// See https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md.
+type Statement struct {
+	Header
+	Predicate json.RawMessage `json:"predicate,omitempty"`
+}
+
+// Validate validates the header.
+func (h Header) Validate() error {
+	if h.Type != StatementTypeV1 && h.Type != StatementTypeV01 {
+		return fmt.Errorf("%w: statement type (%q) not in (%q)", errs.ErrorInvalidField, h.Type,
+			[]string{StatementTypeV1, StatementTypeV01})
+	}
+	if h.PredicateType == "" {
+		return fmt.Errorf("%w: empty predicate type", errs.ErrorInvalidField)
+	}
+	if len(h.Subjects) == 0 {
+		return fmt.Errorf("%w: no subjects", errs.ErrorInvalidField)
+	}
+	for i := range h.Subjects {
+		if err := h.Subjects[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StatementNew creates a statement. The predicate is encoded to canonical JSON.
+func StatementNew(predicateType string, subjects []Subject, predicate interface{}) (*Statement, error) {
+	content, err := CanonicalJSON(predicate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to marshal predicate: %w", errs.ErrorInvalidInput, err)
+	}
+	statement := Statement{
+		Header: Header{
+			Type:          StatementTypeV1,
+			PredicateType: predicateType,
+			// NOTE: Make a copy of the array.
+			Subjects: append([]Subject{}, subjects...),
+		},
+		Predicate: content,
+	}
+	if err := statement.Validate(); err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// StatementFromBytes parses a JSON-encoded statement.
+func StatementFromBytes(content []byte) (*Statement, error) {
+	var statement Statement
+	if err := json.Unmarshal(content, &statement); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal statement: %w", errs.ErrorInvalidInput, err)
+	}
+	if err := statement.Validate(); err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// StatementFromEnvelope parses the statement in a DSSE envelope.
+// NOTE: the envelope's signatures are not verified.
+func StatementFromEnvelope(envelope *Envelope) (*Statement, error) {
+	if envelope.PayloadType != PayloadType {
+		return nil, fmt.Errorf("%w: payload type (%q) != (%q)", errs.ErrorInvalidField,
+			envelope.PayloadType, PayloadType)
+	}
+	payload, err := envelope.DecodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return StatementFromBytes(payload)
+}
+
+// ToBytes returns the canonical JSON encoding of the statement.
+func (s *Statement) ToBytes() ([]byte, error) {
+	return CanonicalJSON(s)
+}
+
+// EnvelopeNew returns an unsigned DSSE envelope containing the statement.
+func (s *Statement) EnvelopeNew() (*Envelope, error) {
+	content, err := s.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	return EnvelopeNew(PayloadType, content)
+}
+
+// PredicateParser parses a predicate.
+type PredicateParser func(predicate json.RawMessage) (interface{}, error)
+
+// PredicateRegistry dispatches the parsing of predicates
+// by predicate type. It is safe to use concurrently.
+type PredicateRegistry struct {
+	mu      sync.RWMutex
+	parsers map[string]PredicateParser
+}
+
+// PredicateRegistryNew creates an empty registry.
+func PredicateRegistryNew() *PredicateRegistry {
+	return &PredicateRegistry{
+		parsers: make(map[string]PredicateParser),
+	}
+}
+
+// Register registers the parser for a predicate type.
+func (r *PredicateRegistry) Register(predicateType string, parser PredicateParser) error {
+	if predicateType == "" {
+		return fmt.Errorf("%w: empty predicate type", errs.ErrorInvalidInput)
+	}
+	if parser == nil {
+		return fmt.Errorf("%w: nil parser for predicate type (%q)", errs.ErrorInvalidInput, predicateType)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.parsers[predicateType]; exists {
+		return fmt.Errorf("%w: predicate type (%q) already registered", errs.ErrorInvalidInput, predicateType)
+	}
+	r.parsers[predicateType] = parser
+	return nil
+}
+
+// Parse parses the statement's predicate with the parser
+// registered for its predicate type.
+func (r *PredicateRegistry) Parse(statement *Statement) (interface{}, error) {
+	r.mu.RLock()
+	parser, exists := r.parsers[statement.PredicateType]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: no parser for predicate type (%q)", errs.ErrorNotFound, statement.PredicateType)
+	}
+	predicate, err := parser(statement.Predicate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse predicate type (%q): %w", errs.ErrorInvalidField,
+			statement.PredicateType, err)
+	}
+	return predicate, nil
+}
@@ -0,0 +1,203 @@
+package intoto
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_StatementFromBytes(t *testing.T) {
+	t.Parallel()
+
+	digest := "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51"
+	subject := `"subject":[{"name":"image","digest":{"sha256":"` + digest + `"}}]`
+	tests := []struct {
+		name      string
+		content   string
+		statement *Statement
+		expected  error
+	}{
+		{
+			name:    "valid statement",
+			content: `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://example.com/p/v1",` + subject + `,"predicate":{"key":"value"}}`,
+			statement: &Statement{
+				Header: Header{
+					Type:          StatementTypeV1,
+					PredicateType: "https://example.com/p/v1",
+					Subjects: []Subject{
+						{Name: "image", Digests: DigestSet{"sha256": digest}},
+					},
+				},
+				Predicate: json.RawMessage(`{"key":"value"}`),
+			},
+		},
+		{
+			name:     "invalid statement type",
+			content:  `{"_type":"https://in-toto.io/Statement/v2","predicateType":"https://example.com/p/v1",` + subject + `}`,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:    "legacy statement type",
+			content: `{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://example.com/p/v1",` + subject + `}`,
+			statement: &Statement{
+				Header: Header{
+					Type:          StatementTypeV01,
+					PredicateType: "https://example.com/p/v1",
+					Subjects: []Subject{
+						{Name: "image", Digests: DigestSet{"sha256": digest}},
+					},
+				},
+			},
+		},
+		{
+			name:     "empty predicate type",
+			content:  `{"_type":"https://in-toto.io/Statement/v1",` + subject + `}`,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "no subjects",
+			content:  `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://example.com/p/v1"}`,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "invalid subject",
+			content:  `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://example.com/p/v1","subject":[{"digest":{"sha256":"xyz"}}]}`,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "invalid json",
+			content:  `{"_type":`,
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			statement, err := StatementFromBytes([]byte(tt.content))
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.statement, statement); diff != "" {
+				t.Fatalf("unexpected statement (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_StatementEnvelopeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	subjects := []Subject{
+		{Digests: DigestSet{"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51"}},
+	}
+	predicate := map[string]interface{}{"z": 1, "a": "<b>"}
+	statement, err := StatementNew("https://example.com/p/v1", subjects, predicate)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	envelope, err := statement.EnvelopeNew()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	parsed, err := StatementFromEnvelope(envelope)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := cmp.Diff(statement, parsed); diff != "" {
+		t.Fatalf("unexpected statement (-want +got): \n%s", diff)
+	}
+	content, err := parsed.ToBytes()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expected := `{"_type":"https://in-toto.io/Statement/v1","predicate":{"a":"<b>","z":1},` +
+		`"predicateType":"https://example.com/p/v1","subject":[{"digest":{"sha256":"5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51"}}]}`
+	if diff := cmp.Diff(expected, string(content)); diff != "" {
+		t.Fatalf("unexpected content (-want +got): \n%s", diff)
+	}
+
+	// Invalid payload type.
+	envelope.PayloadType = "application/json"
+	_, err = StatementFromEnvelope(envelope)
+	if diff := cmp.Diff(errs.ErrorInvalidField, err, cmpopts.EquateErrors()); diff != "" {
+		t.Fatalf("unexpected err (-want +got): \n%s", diff)
+	}
+}
+
+func Test_PredicateRegistry(t *testing.T) {
+	t.Parallel()
+
+	type predicate struct {
+		Key string `json:"key"`
+	}
+	parser := func(content json.RawMessage) (interface{}, error) {
+		var p predicate
+		if err := json.Unmarshal(content, &p); err != nil {
+			return nil, err
+		}
+		if p.Key == "" {
+			return nil, fmt.Errorf("empty key")
+		}
+		return p, nil
+	}
+	registry := PredicateRegistryNew()
+	if err := registry.Register("https://example.com/p/v1", parser); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	// Duplicate registration.
+	err := registry.Register("https://example.com/p/v1", parser)
+	if diff := cmp.Diff(errs.ErrorInvalidInput, err, cmpopts.EquateErrors()); diff != "" {
+		t.Fatalf("unexpected err (-want +got): \n%s", diff)
+	}
+
+	tests := []struct {
+		name          string
+		predicateType string
+		predicate     string
+		result        interface{}
+		expected      error
+	}{
+		{
+			name:          "registered type",
+			predicateType: "https://example.com/p/v1",
+			predicate:     `{"key":"value"}`,
+			result:        predicate{Key: "value"},
+		},
+		{
+			name:          "invalid predicate",
+			predicateType: "https://example.com/p/v1",
+			predicate:     `{"other":"value"}`,
+			expected:      errs.ErrorInvalidField,
+		},
+		{
+			name:          "unregistered type",
+			predicateType: "https://example.com/p/v2",
+			predicate:     `{"key":"value"}`,
+			expected:      errs.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			statement := Statement{
+				Header: Header{
+					PredicateType: tt.predicateType,
+				},
+				Predicate: json.RawMessage(tt.predicate),
+			}
+			result, err := registry.Parse(&statement)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.result, result); diff != "" {
+				t.Fatalf("unexpected result (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package intoto
+
+import (
+	"fmt"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+// MatchSubjects returns the first subject whose digests contain all the digests.
+// Digests are normalized before comparison. An invalid subject makes the entire
+// list invalid.
+func MatchSubjects(subjects []Subject, digests DigestSet) (*Subject, error) {
+	if len(subjects) == 0 {
+		return nil, fmt.Errorf("%w: no subjects", errs.ErrorInvalidField)
+	}
+	digests, err := digests.Normalize(DigestValidationPermissive)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := NormalizeSubjects(subjects, DigestValidationPermissive)
+	if err != nil {
+		return nil, err
+	}
+	var errList []error
+	for i := range normalized {
+		err := MatchDigests(normalized[i].Digests, digests)
+		if err == nil {
+			return &subjects[i], nil
+		}
+		errList = append(errList, err)
+	}
+	return nil, fmt.Errorf("%w: no subject matches digests (%q): %v", errs.ErrorMismatch, digests, errList)
+}
+
+// MatchDigests verifies that ds contains all the digests.
+// Values are compared as is, so callers should normalize them first.
+func MatchDigests(ds DigestSet, digests DigestSet) error {
+	if err := ds.Validate(); err != nil {
+		return err
+	}
+	if err := digests.Validate(); err != nil {
+		return err
+	}
+	for name, value := range digests {
+		val, exists := ds[name]
+		if !exists {
+			return fmt.Errorf("%w: subject with digest (%q:%q) is not present", errs.ErrorMismatch,
+				name, value)
+		}
+		if val != value {
+			return fmt.Errorf("%w: subject with digest (%q:%q) != (%q:%q)", errs.ErrorMismatch,
+				name, value, name, val)
+		}
+	}
+	return nil
+}
+
+// SubjectByName returns the subject with the name.
+func SubjectByName(subjects []Subject, name string) (*Subject, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: empty subject name", errs.ErrorInvalidInput)
+	}
+	for i := range subjects {
+		if subjects[i].Name == name {
+			return &subjects[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: subject (%q)", errs.ErrorNotFound, name)
+}
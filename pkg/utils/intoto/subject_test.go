@@ -0,0 +1,214 @@
+package intoto
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_MatchSubjects(t *testing.T) {
+	t.Parallel()
+
+	index := Subject{
+		Digests: DigestSet{
+			"sha256": "1bc04b5291c26a46d918139138b992d2de976d6851d0893b0476b85bfbdfc6e6",
+		},
+	}
+	amd64 := Subject{
+		Name: "linux/amd64",
+		Digests: DigestSet{
+			"sha256":    "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51",
+			"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
+		},
+	}
+	tests := []struct {
+		name     string
+		subjects []Subject
+		digests  DigestSet
+		subject  *Subject
+		expected error
+	}{
+		{
+			name:     "first subject",
+			subjects: []Subject{index, amd64},
+			digests:  index.Digests,
+			subject:  &index,
+		},
+		{
+			name:     "subset of digests",
+			subjects: []Subject{index, amd64},
+			digests: DigestSet{
+				"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
+			},
+			subject: &amd64,
+		},
+		{
+			name:     "uppercase digests",
+			subjects: []Subject{index, amd64},
+			digests: DigestSet{
+				"sha256": "1BC04B5291C26A46D918139138B992D2DE976D6851D0893B0476B85BFBDFC6E6",
+			},
+			subject: &index,
+		},
+		{
+			name:     "extra digest",
+			subjects: []Subject{index, amd64},
+			digests: DigestSet{
+				"sha256":    "1bc04b5291c26a46d918139138b992d2de976d6851d0893b0476b85bfbdfc6e6",
+				"gitCommit": "a3623e630f9d01bdda426723ca7ec17a8146f25c",
+			},
+			expected: errs.ErrorMismatch,
+		},
+		{
+			name:     "no subjects",
+			digests:  index.Digests,
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name:     "invalid subject",
+			subjects: []Subject{index, {Name: "linux/arm64"}},
+			digests:  index.Digests,
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			subject, err := MatchSubjects(tt.subjects, tt.digests)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.subject, subject); diff != "" {
+				t.Fatalf("unexpected subject (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_SubjectByName(t *testing.T) {
+	t.Parallel()
+
+	subjects := []Subject{
+		{Digests: DigestSet{"sha256": "1bc04b5291c26a46d918139138b992d2de976d6851d0893b0476b85bfbdfc6e6"}},
+		{Name: "linux/amd64", Digests: DigestSet{"sha256": "5861314d7fccb39c2192173240eab44fa35ca66426201ca2acd0630a6258dd51"}},
+	}
+	tests := []struct {
+		name        string
+		subjectName string
+		subject     *Subject
+		expected    error
+	}{
+		{
+			name:        "existing subject",
+			subjectName: "linux/amd64",
+			subject:     &subjects[1],
+		},
+		{
+			name:        "missing subject",
+			subjectName: "linux/arm64",
+			expected:    errs.ErrorNotFound,
+		},
+		{
+			name:     "empty name",
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			subject, err := SubjectByName(subjects, tt.subjectName)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if diff := cmp.Diff(tt.subject, subject); diff != "" {
+				t.Fatalf("unexpected subject (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_MatchDigests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		attDigests   DigestSet
+		inputDigests DigestSet
+		expected     error
+	}{
+		{
+			name: "same digests",
+			attDigests: DigestSet{
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+			},
+			inputDigests: DigestSet{
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+			},
+		},
+		{
+			name: "subset in attestations",
+			attDigests: DigestSet{
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+			},
+			inputDigests: DigestSet{
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+			},
+		},
+		{
+			name: "empty input digests",
+			attDigests: DigestSet{
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "empty att digests",
+			inputDigests: DigestSet{
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "different digest names",
+			attDigests: DigestSet{
+				"a-sha256":    "another",
+				"a-gitCommit": "mismatch_another_com",
+			},
+			inputDigests: DigestSet{
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+			},
+			expected: errs.ErrorMismatch,
+		},
+		{
+			name: "mismatch sha256 digest",
+			attDigests: DigestSet{
+				"sha256":    "cd84e8eb5ec577de03d8b159a56c809b9ec7ae1956f828bfab6479de57a1e88d",
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+			},
+			inputDigests: DigestSet{
+				"gitCommit": "685d6428520757122269412ce6f9c5b7d1911f61",
+				"sha256":    "ae448ac86c4e8e4dec645729708ef41873ae79c6dff84eff73360989487f08e5",
+			},
+			expected: errs.ErrorMismatch,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := MatchDigests(tt.attDigests, tt.inputDigests)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
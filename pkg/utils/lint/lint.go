@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+// Severity defines the severity of a rule. The values
+// are the levels defined by SARIF.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Validate validates the severity.
+func (s Severity) Validate() error {
+	switch s {
+	case SeverityError, SeverityWarning, SeverityNote:
+		return nil
+	}
+	return fmt.Errorf("%w: invalid severity (%q)", errs.ErrorInvalidInput, s)
+}
+
+// AtLeast returns true if the severity is at least as severe as other.
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank()
+}
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	}
+	return 0
+}
+
+// Rule defines a lint rule.
+type Rule struct {
+	ID          string
+	Name        string
+	Description string
+	Severity    Severity
+}
+
+// Finding defines a violation of a rule.
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	// Location is the ID of the project policy, e.g. its path.
+	// It is empty for the org policy.
+	Location string
+	Message  string
+}
+
+// Suppression defines findings to ignore. An empty location
+// suppresses the rule for all the policies.
+type Suppression struct {
+	RuleID   string
+	Location string
+}
+
+// Filter returns the findings that are not suppressed.
+func Filter(findings []Finding, suppressions []Suppression) []Finding {
+	var res []Finding
+	for _, finding := range findings {
+		if !isSuppressed(finding, suppressions) {
+			res = append(res, finding)
+		}
+	}
+	return res
+}
+
+func isSuppressed(finding Finding, suppressions []Suppression) bool {
+	for _, suppression := range suppressions {
+		if suppression.RuleID != finding.RuleID {
+			continue
+		}
+		if suppression.Location == "" || suppression.Location == finding.Location {
+			return true
+		}
+	}
+	return false
+}
+
+// Sort sorts findings by location, rule ID and message.
+func Sort(findings []Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Location != findings[j].Location {
+			return findings[i].Location < findings[j].Location
+		}
+		if findings[i].RuleID != findings[j].RuleID {
+			return findings[i].RuleID < findings[j].RuleID
+		}
+		return findings[i].Message < findings[j].Message
+	})
+}
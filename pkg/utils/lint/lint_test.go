@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Filter(t *testing.T) {
+	t.Parallel()
+
+	findings := []Finding{
+		{RuleID: "RULE1", Location: "a.json"},
+		{RuleID: "RULE1", Location: "b.json"},
+		{RuleID: "RULE2", Location: "a.json"},
+	}
+	tests := []struct {
+		name         string
+		suppressions []Suppression
+		expected     []Finding
+	}{
+		{
+			name:     "no suppressions",
+			expected: findings,
+		},
+		{
+			name: "rule suppressed",
+			suppressions: []Suppression{
+				{RuleID: "RULE1"},
+			},
+			expected: []Finding{
+				{RuleID: "RULE2", Location: "a.json"},
+			},
+		},
+		{
+			name: "rule suppressed for location",
+			suppressions: []Suppression{
+				{RuleID: "RULE1", Location: "b.json"},
+			},
+			expected: []Finding{
+				{RuleID: "RULE1", Location: "a.json"},
+				{RuleID: "RULE2", Location: "a.json"},
+			},
+		},
+		{
+			name: "all suppressed",
+			suppressions: []Suppression{
+				{RuleID: "RULE1"},
+				{RuleID: "RULE2", Location: "a.json"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := Filter(findings, tt.suppressions)
+			if diff := cmp.Diff(tt.expected, res); diff != "" {
+				t.Fatalf("unexpected findings (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		severity Severity
+		other    Severity
+		expected bool
+	}{
+		{
+			name:     "same",
+			severity: SeverityWarning,
+			other:    SeverityWarning,
+			expected: true,
+		},
+		{
+			name:     "more severe",
+			severity: SeverityError,
+			other:    SeverityNote,
+			expected: true,
+		},
+		{
+			name:     "less severe",
+			severity: SeverityNote,
+			other:    SeverityWarning,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.expected, tt.severity.AtLeast(tt.other)); diff != "" {
+				t.Fatalf("unexpected result (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package owners
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+// Owners defines the team owning a policy.
+type Owners struct {
+	Team     string   `json:"team" schema:"required"`
+	Contacts []string `json:"contacts,omitempty"`
+	// Editors are the GitHub users or teams allowed to edit the policy,
+	// e.g. @org/team-x, or their email.
+	Editors []string `json:"editors" schema:"required"`
+	// Repositories are the repositories allowed to edit the policy,
+	// e.g. github.com/org/repo, such as the repository whose release
+	// automation updates the policy. CODEOWNERS cannot assign repositories,
+	// so the changes they make are checked separately.
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// Validate validates the owners. Owners are optional.
+// Fields are written verbatim into CODEOWNERS files, so
+// whitespace and control characters are rejected.
+func (o *Owners) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Team == "" {
+		return fmt.Errorf("%w: owners's team is empty", errs.ErrorInvalidField)
+	}
+	if !IsSafe(o.Team) {
+		return fmt.Errorf("%w: owners's team (%q) contains whitespace or control characters",
+			errs.ErrorInvalidField, o.Team)
+	}
+	for _, contact := range o.Contacts {
+		if contact == "" {
+			return fmt.Errorf("%w: owners's contacts has an empty field", errs.ErrorInvalidField)
+		}
+		if !IsSafe(contact) {
+			return fmt.Errorf("%w: owners's contact (%q) contains whitespace or control characters",
+				errs.ErrorInvalidField, contact)
+		}
+	}
+	if len(o.Editors) == 0 {
+		return fmt.Errorf("%w: owners's editors is empty", errs.ErrorInvalidField)
+	}
+	for _, editor := range o.Editors {
+		if !isEditor(editor) {
+			return fmt.Errorf("%w: owners's editor (%q) is invalid. Must be @user, @org/team or an email",
+				errs.ErrorInvalidField, editor)
+		}
+	}
+	for _, repository := range o.Repositories {
+		if !isRepository(repository) {
+			return fmt.Errorf("%w: owners's repository (%q) is invalid. Must be host/owner/name",
+				errs.ErrorInvalidField, repository)
+		}
+	}
+	return nil
+}
+
+// CanEdit returns true if the repository is allowed to edit the policy.
+func (o *Owners) CanEdit(repository string) bool {
+	return o != nil && slices.Contains(o.Repositories, repository)
+}
+
+// IsSafe returns true if the value contains no whitespace
+// and no control characters.
+func IsSafe(value string) bool {
+	return strings.IndexFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}) == -1
+}
+
+// isEditor returns true if the editor is a GitHub user, a GitHub team or an email.
+func isEditor(editor string) bool {
+	if !IsSafe(editor) {
+		return false
+	}
+	user, domain, found := strings.Cut(editor, "@")
+	if !found {
+		return false
+	}
+	// A GitHub user or team.
+	if user == "" {
+		return domain != "" && !strings.HasPrefix(domain, "/") && !strings.HasSuffix(domain, "/") &&
+			strings.Count(domain, "/") <= 1 && !strings.Contains(domain, "@")
+	}
+	// An email.
+	return domain != "" && !strings.Contains(domain, "@")
+}
+
+// isRepository returns true if the repository is of the form host/owner/name.
+func isRepository(repository string) bool {
+	if !IsSafe(repository) {
+		return false
+	}
+	parts := strings.Split(repository, "/")
+	return len(parts) == 3 && !slices.Contains(parts, "")
+}
@@ -0,0 +1,221 @@
+package owners
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+func Test_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		owners   *Owners
+		expected error
+	}{
+		{
+			name: "no owners",
+		},
+		{
+			name: "all fields set",
+			owners: &Owners{
+				Team:         "team-x",
+				Contacts:     []string{"team-x@example.com"},
+				Editors:      []string{"@org/team-x", "@user", "user@example.com"},
+				Repositories: []string{"github.com/org/repo"},
+			},
+		},
+		{
+			name: "empty team",
+			owners: &Owners{
+				Editors: []string{"@org/team-x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "empty contact",
+			owners: &Owners{
+				Team:     "team-x",
+				Contacts: []string{""},
+				Editors:  []string{"@org/team-x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "no editors",
+			owners: &Owners{
+				Team: "team-x",
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "editor without @",
+			owners: &Owners{
+				Team:    "team-x",
+				Editors: []string{"team-x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "editor nested team",
+			owners: &Owners{
+				Team:    "team-x",
+				Editors: []string{"@org/team/x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "editor with space",
+			owners: &Owners{
+				Team:    "team-x",
+				Editors: []string{"@org/team x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "team with newline",
+			owners: &Owners{
+				Team:    "team-x\n* @org/evil",
+				Editors: []string{"@org/team-x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "team with space",
+			owners: &Owners{
+				Team:    "team x",
+				Editors: []string{"@org/team-x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "contact with newline",
+			owners: &Owners{
+				Team:     "team-x",
+				Contacts: []string{"team-x@example.com\n* @org/evil"},
+				Editors:  []string{"@org/team-x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "contact with control character",
+			owners: &Owners{
+				Team:     "team-x",
+				Contacts: []string{"team-x@example.com\r"},
+				Editors:  []string{"@org/team-x"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "editor with newline",
+			owners: &Owners{
+				Team:    "team-x",
+				Editors: []string{"@org/team-x\n*"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "editor with control character",
+			owners: &Owners{
+				Team:    "team-x",
+				Editors: []string{"@org/team-x\x00"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "repository without host",
+			owners: &Owners{
+				Team:         "team-x",
+				Editors:      []string{"@org/team-x"},
+				Repositories: []string{"org/repo"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "repository with empty owner",
+			owners: &Owners{
+				Team:         "team-x",
+				Editors:      []string{"@org/team-x"},
+				Repositories: []string{"github.com//repo"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+		{
+			name: "repository with newline",
+			owners: &Owners{
+				Team:         "team-x",
+				Editors:      []string{"@org/team-x"},
+				Repositories: []string{"github.com/org/repo\n*"},
+			},
+			expected: errs.ErrorInvalidField,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.owners.Validate()
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_CanEdit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		owners     *Owners
+		repository string
+		expected   bool
+	}{
+		{
+			name:       "no owners",
+			repository: "github.com/org/repo",
+		},
+		{
+			name: "no repositories",
+			owners: &Owners{
+				Team:    "team-x",
+				Editors: []string{"@org/team-x"},
+			},
+			repository: "github.com/org/repo",
+		},
+		{
+			name: "allowed repository",
+			owners: &Owners{
+				Team:         "team-x",
+				Editors:      []string{"@org/team-x"},
+				Repositories: []string{"github.com/org/other", "github.com/org/repo"},
+			},
+			repository: "github.com/org/repo",
+			expected:   true,
+		},
+		{
+			name: "other repository",
+			owners: &Owners{
+				Team:         "team-x",
+				Editors:      []string{"@org/team-x"},
+				Repositories: []string{"github.com/org/other"},
+			},
+			repository: "github.com/org/repo",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.expected, tt.owners.CanEdit(tt.repository)); diff != "" {
+				t.Fatalf("unexpected result (-want +got): \n%s", diff)
+			}
+		})
+	}
+}
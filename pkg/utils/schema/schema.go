@@ -0,0 +1,153 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+	"github.com/slsa-framework/slsa-policy/pkg/utils/format"
+)
+
+// Version is the JSON Schema version of the generated schemas.
+const Version = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema defines a JSON Schema.
+type Schema struct {
+	Schema     string             `json:"$schema,omitempty"`
+	Title      string             `json:"title,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	// AdditionalProperties is either a schema or false.
+	AdditionalProperties interface{}   `json:"additionalProperties,omitempty"`
+	Items                *Schema       `json:"items,omitempty"`
+	Enum                 []interface{} `json:"enum,omitempty"`
+}
+
+// Generate returns the schema of a policy file decoded into v.
+// The properties are the JSON names of the exported fields, and objects
+// do not accept other properties. A 'schema' tag on a field adds
+// constraints, separated by commas:
+//
+//   - required: the field must be present.
+//   - enum=a|b: the value must be one of the listed values.
+func Generate(title string, v interface{}) (*Schema, error) {
+	s, err := generate(reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Version
+	s.Title = title
+	return s, nil
+}
+
+// JSON returns the canonical encoding of the schema of a policy file. See Generate.
+func JSON(title string, v interface{}) ([]byte, error) {
+	s, err := Generate(title, v)
+	if err != nil {
+		return nil, err
+	}
+	return format.JSON(s)
+}
+
+func generate(t reflect.Type) (*Schema, error) {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return generate(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := generate(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: map key of type (%q) is not a string", errs.ErrorInvalidInput, t.Key())
+		}
+		values, err := generate(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return generateStruct(t)
+	default:
+		return nil, fmt.Errorf("%w: unsupported type (%q)", errs.ErrorInvalidInput, t)
+	}
+}
+
+func generateStruct(t reflect.Type) (*Schema, error) {
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property, err := generate(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field (%q): %w", field.Name, err)
+		}
+		required, err := applyTag(property, field.Tag.Get("schema"))
+		if err != nil {
+			return nil, fmt.Errorf("field (%q): %w", field.Name, err)
+		}
+		if required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = property
+	}
+	return s, nil
+}
+
+// applyTag adds the constraints of a 'schema' tag to a property,
+// and returns whether the property is required.
+func applyTag(property *Schema, tag string) (bool, error) {
+	if tag == "" {
+		return false, nil
+	}
+	required := false
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "required":
+			required = true
+		case "enum":
+			for _, str := range strings.Split(value, "|") {
+				if property.Type != "integer" {
+					property.Enum = append(property.Enum, str)
+					continue
+				}
+				n, err := strconv.Atoi(str)
+				if err != nil {
+					return false, fmt.Errorf("%w: enum value (%q) is not an integer", errs.ErrorInvalidInput, str)
+				}
+				property.Enum = append(property.Enum, n)
+			}
+		default:
+			return false, fmt.Errorf("%w: unknown schema option (%q)", errs.ErrorInvalidInput, key)
+		}
+	}
+	return required, nil
+}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/slsa-framework/slsa-policy/pkg/errs"
+)
+
+type item struct {
+	Name  string `json:"name" schema:"required"`
+	Level *int   `json:"level,omitempty" schema:"enum=0|1|2"`
+	Kind  string `json:"kind" schema:"enum=a|b"`
+}
+
+type policy struct {
+	Format   int             `json:"format" schema:"required,enum=1"`
+	Items    []item          `json:"items"`
+	Labels   map[string]bool `json:"labels,omitempty"`
+	Ignored  string          `json:"-"`
+	internal string
+}
+
+func Test_Generate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		schema   *Schema
+		expected error
+	}{
+		{
+			name:  "policy",
+			value: policy{},
+			schema: &Schema{
+				Schema:               Version,
+				Title:                "title",
+				Type:                 "object",
+				AdditionalProperties: false,
+				Required:             []string{"format"},
+				Properties: map[string]*Schema{
+					"format": {Type: "integer", Enum: []interface{}{1}},
+					"items": {
+						Type: "array",
+						Items: &Schema{
+							Type:                 "object",
+							AdditionalProperties: false,
+							Required:             []string{"name"},
+							Properties: map[string]*Schema{
+								"name":  {Type: "string"},
+								"level": {Type: "integer", Enum: []interface{}{0, 1, 2}},
+								"kind":  {Type: "string", Enum: []interface{}{"a", "b"}},
+							},
+						},
+					},
+					"labels": {Type: "object", AdditionalProperties: &Schema{Type: "boolean"}},
+				},
+			},
+		},
+		{
+			name: "invalid enum",
+			value: struct {
+				Level int `json:"level" schema:"enum=high"`
+			}{},
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name: "unknown option",
+			value: struct {
+				Level int `json:"level" schema:"minimum=0"`
+			}{},
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name: "unsupported type",
+			value: struct {
+				Value interface{} `json:"value"`
+			}{},
+			expected: errs.ErrorInvalidInput,
+		},
+		{
+			name: "non-string map key",
+			value: struct {
+				Values map[int]string `json:"values"`
+			}{},
+			expected: errs.ErrorInvalidInput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schema, err := Generate("title", tt.value)
+			if diff := cmp.Diff(tt.expected, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected err (-want +got): \n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.schema, schema); diff != "" {
+				t.Fatalf("unexpected schema (-want +got): \n%s", diff)
+			}
+		})
+	}
+}